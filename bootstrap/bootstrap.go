@@ -41,7 +41,6 @@ import (
 	"github.com/dispatchlabs/disgo/disgover"
 	"github.com/dispatchlabs/disgo/dvm"
 	"github.com/dispatchlabs/disgo/localapi"
-	"os/exec"
 )
 
 
@@ -64,26 +63,9 @@ func NewServer() *Server {
 func (server *Server) Go() {
 	utils.Info(fmt.Sprintf("booting Disgo ..."))
 
-	// Run update?
-	fileName := "." + string(os.PathSeparator) + "disgo-update"
-	if utils.Exists(fileName) {
-
-		// Run.
-		command := exec.Command(fileName)
-		var out bytes.Buffer
-		command.Stdout = &out
-		err := command.Run()
-		if err != nil {
-			utils.Error(err)
-		}
-		utils.Info(fmt.Sprintf("executed %s - %s", fileName, out.String()))
-
-		// Delete update.
-		err = os.Remove(fileName)
-		if err != nil {
-			utils.Warn(fmt.Sprintf("unable to delete file %s", fileName), err)
-		}
-	}
+	// Install a staged release or roll back one that failed to start.
+	installRelease()
+	utils.Events().On(types.Events.ReleaseStaged, releaseStaged)
 
 	// Add services.
 	server.services = append(server.services, services.GetDbService())
@@ -99,6 +81,7 @@ func (server *Server) Go() {
 		utils.Info("starting " + utils.GetStructName(service) + "...")
 		go service.Go()
 	}
+	go checkReleaseHealth(server.services)
 
 	// Safely handle shutdown and close the DB.
 	signal_chan := make(chan os.Signal, 1)
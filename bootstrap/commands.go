@@ -0,0 +1,181 @@
+/*
+ *    This file is part of Disgo library.
+ *
+ *    The Disgo library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package bootstrap
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/dispatchlabs/disgo/commons/helper"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/localapi"
+)
+
+// command - a CLI sub command, args exclude the command name
+type command func(args []string) error
+
+var commands = map[string]command{
+	"backup":  backupCommand,
+	"restore": restoreCommand,
+	"db":      dbCommand,
+}
+
+// RunCommand - runs the CLI sub command named by args[0], returns false when args do not name a command and the server should boot
+func RunCommand(args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		return false, nil
+	}
+	return true, run(args[1:])
+}
+
+// backupCommand - disgo backup [-since version] <file>, streams a backup from the running node's local API
+func backupCommand(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	since := flags.Uint64("since", 0, "only back up entries newer than this version (from a previous backup)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: disgo backup [-since version] <file>")
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/v1/local/backup?since=%d", types.GetConfig().LocalHttpApiPort, *since)
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", types.GetConfig().LocalHttpApiUsername+" "+types.GetConfig().LocalHttpApiPassword)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("backup failed [status=%s]", response.Status)
+	}
+
+	file, err := os.Create(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, response.Body); err != nil {
+		return err
+	}
+
+	// Trailers are only populated once the body has been read.
+	version := response.Trailer.Get(localapi.BackupVersionTrailer)
+	if version == "" {
+		return fmt.Errorf("backup %s is incomplete, node did not finish streaming", flags.Arg(0))
+	}
+	fmt.Printf("backup written [file=%s, since=%d, version=%s]\n", flags.Arg(0), *since, version)
+	fmt.Printf("next incremental backup: disgo backup -since %s <file>\n", version)
+	return nil
+}
+
+// restoreCommand - disgo restore [-force] <file> [<incremental file> ...], loads backups into ./db while the node is stopped
+func restoreCommand(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	force := flags.Bool("force", false, "restore into a non empty DB")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("usage: disgo restore [-force] <file> [<incremental file> ...]")
+	}
+	defer services.GetDb().Close()
+	if !services.IsDbEmpty() && !*force {
+		return errors.New("DB is not empty, use -force to restore over existing data")
+	}
+
+	expectedDigest, keys, err := getBackupDigest(flags.Args())
+	if err != nil {
+		return err
+	}
+	for _, fileName := range flags.Args() {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		err = services.Restore(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("restored [file=%s]\n", fileName)
+	}
+
+	digest, err := services.VerifyRestore(expectedDigest, keys)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("restore verified [accountStateDigest=%s]\n", digest)
+	return nil
+}
+
+// dbCommand - disgo db check [-repair], verifies table records against their index keys while the node is stopped
+func dbCommand(args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		return errors.New("usage: disgo db check [-repair]")
+	}
+	flags := flag.NewFlagSet("db check", flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "rebuild missing index keys and delete dangling or stale ones")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	defer services.GetDb().Close()
+
+	report, err := helper.CheckDb(services.GetDb(), *repair)
+	if report != nil {
+		fmt.Println(report.ToPrettyJson())
+	}
+	if err != nil {
+		return err
+	}
+	if report.IsHealthy() {
+		fmt.Printf("DB is consistent [records=%d, indexes=%d]\n", report.RecordCount, report.IndexCount)
+		return nil
+	}
+	if *repair {
+		fmt.Printf("repaired %d index keys, invalid records must be fixed by a resync\n", report.RepairedIndexes)
+		return nil
+	}
+	return fmt.Errorf("DB is inconsistent [missing=%d, dangling=%d, stale=%d, invalidRecords=%d, invalidSync=%d], run disgo db check -repair", len(report.MissingIndexes), len(report.DanglingIndexes), len(report.StaleIndexes), len(report.InvalidRecords), len(report.InvalidSync))
+}
+
+// getBackupDigest
+func getBackupDigest(fileNames []string) (string, [][]byte, error) {
+	var readers []io.Reader
+	for _, fileName := range fileNames {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return "", nil, err
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+	return services.GetBackupAccountStateDigest(readers...)
+}
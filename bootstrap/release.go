@@ -0,0 +1,276 @@
+/*
+ *    This file is part of Disgo library.
+ *
+ *    The Disgo library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// A staged release is installed at boot after the files it replaces are copied to releasePreviousDirectory. Until the new
+// version passes its health check it is pending, a pending release that boots a second time (the first boot crashed) or
+// fails the health check is rolled back.
+const (
+	releasePreviousDirectory  = "previous"
+	releasePendingFile        = "pending.json"
+	releaseHealthCheckTimeout = 2 * time.Minute
+	releaseRestartDelay       = 10 * time.Second
+)
+
+// pendingRelease - An installed release that has not passed its health check yet
+type pendingRelease struct {
+	Version         string            `json:"version"`
+	PreviousVersion string            `json:"previousVersion"`
+	Files           map[string]string `json:"files"` // Installed path by name in releasePreviousDirectory, empty name when the path is new
+	Booted          bool              `json:"booted"`
+}
+
+// installRelease - Runs at boot before any service, returns after restarting into a new or previous binary only on error
+func installRelease() {
+	pending, err := loadPendingRelease()
+	if err != nil {
+		utils.Error("unable to load pending release", err)
+	}
+	if pending != nil {
+		if pending.Booted {
+			rollbackRelease(pending, "did not start")
+			restart()
+			return
+		}
+		pending.Booted = true
+		err = savePendingRelease(pending)
+		if err != nil {
+			utils.Error("unable to save pending release", err)
+		}
+		utils.Info(fmt.Sprintf("booting release, waiting for health check [version=%s, previousVersion=%s]", pending.Version, pending.PreviousVersion))
+		return
+	}
+
+	version := types.GetStagedRelease()
+	if version == "" {
+		return
+	}
+	os.Remove(filepath.Join(types.ReleaseDirectory, types.ReleaseStagedFile))
+	if version == types.GetVersion().Version {
+		return
+	}
+	directory := filepath.Join(types.ReleaseDirectory, version)
+	seeds := make([]string, 0, len(types.GetConfig().Seeds))
+	for _, seed := range types.GetConfig().Seeds {
+		seeds = append(seeds, seed.Address)
+	}
+	manifest, err := types.VerifyStagedRelease(directory, seeds)
+	if err != nil {
+		utils.Error(fmt.Sprintf("unable to verify staged release %s", version), err)
+		return
+	}
+
+	pending, err = install(manifest, directory)
+	if err != nil {
+		utils.Error(fmt.Sprintf("unable to install release %s", version), err)
+		if pending != nil {
+			rollbackRelease(pending, err.Error())
+		}
+		return
+	}
+	utils.Info(fmt.Sprintf("installed release [version=%s, previousVersion=%s]", pending.Version, pending.PreviousVersion))
+	restart()
+}
+
+// install - Keeps the files a release replaces and copies the release over them
+func install(manifest *types.ReleaseManifest, directory string) (*pendingRelease, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	previousDirectory := filepath.Join(types.ReleaseDirectory, releasePreviousDirectory)
+	err = os.RemoveAll(previousDirectory)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(previousDirectory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &pendingRelease{Version: manifest.Version, PreviousVersion: types.GetVersion().Version, Files: map[string]string{}}
+	targets := map[string]string{}
+	for _, artifact := range manifest.Artifacts {
+		target := filepath.Join(filepath.Dir(executable), artifact.Name)
+		if artifact.Name == types.ReleaseBinary {
+			target = executable
+		}
+		targets[artifact.Name] = target
+		if !utils.Exists(target) {
+			pending.Files[target] = ""
+			continue
+		}
+		err = copyFile(target, filepath.Join(previousDirectory, artifact.Name))
+		if err != nil {
+			return nil, err
+		}
+		pending.Files[target] = artifact.Name
+	}
+	err = savePendingRelease(pending)
+	if err != nil {
+		return nil, err
+	}
+	for name, target := range targets {
+		err = copyFile(filepath.Join(directory, name), target)
+		if err != nil {
+			return pending, err
+		}
+	}
+	return pending, nil
+}
+
+// rollbackRelease - Restores the files a pending release replaced and marks the release failed so it is never staged again
+func rollbackRelease(pending *pendingRelease, reason string) {
+	utils.Error(fmt.Sprintf("rolling back release [version=%s, previousVersion=%s, reason=%s]", pending.Version, pending.PreviousVersion, reason))
+	previousDirectory := filepath.Join(types.ReleaseDirectory, releasePreviousDirectory)
+	for target, name := range pending.Files {
+		var err error
+		if name == "" {
+			err = os.Remove(target)
+		} else {
+			err = copyFile(filepath.Join(previousDirectory, name), target)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			utils.Error(fmt.Sprintf("unable to restore %s", target), err)
+		}
+	}
+	err := os.MkdirAll(filepath.Join(types.ReleaseDirectory, pending.Version), 0755)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(types.ReleaseDirectory, pending.Version, types.ReleaseFailedFile), []byte(reason), 0644)
+	}
+	if err != nil {
+		utils.Error("unable to mark release failed", err)
+	}
+	os.Remove(filepath.Join(types.ReleaseDirectory, releasePendingFile))
+}
+
+// checkReleaseHealth - Clears a pending release once the services are up, rolls it back when they are not in time
+func checkReleaseHealth(services []types.IService) {
+	pending, err := loadPendingRelease()
+	if err != nil || pending == nil {
+		return
+	}
+	started := make(chan bool, 1)
+	utils.Events().On(types.Events.DisGoverServiceInitFinished, func() { started <- true })
+	select {
+	case <-started:
+	case <-time.After(releaseHealthCheckTimeout):
+		rollbackRelease(pending, "did not find delegates in time")
+		shutdownAndRestart()
+		return
+	}
+	for _, service := range services {
+		if !service.IsRunning() {
+			rollbackRelease(pending, fmt.Sprintf("%s is not running", utils.GetStructName(service)))
+			shutdownAndRestart()
+			return
+		}
+	}
+	err = os.Remove(filepath.Join(types.ReleaseDirectory, releasePendingFile))
+	if err != nil {
+		utils.Error("unable to clear pending release", err)
+		return
+	}
+	utils.Info(fmt.Sprintf("release passed health check [version=%s, previousVersion=%s]", pending.Version, pending.PreviousVersion))
+}
+
+// releaseStaged - Restarts into the staged release
+func releaseStaged() {
+	if utils.Exists(filepath.Join(types.ReleaseDirectory, releasePendingFile)) {
+		utils.Warn("release staged while the installed release is pending, it is installed on the next boot")
+		return
+	}
+	utils.Info(fmt.Sprintf("restarting in %s to install staged release %s", releaseRestartDelay, types.GetStagedRelease()))
+	time.Sleep(releaseRestartDelay)
+	shutdownAndRestart()
+}
+
+// shutdownAndRestart
+func shutdownAndRestart() {
+	utils.Info("closing DB...")
+	services.GetDb().Close()
+	restart()
+}
+
+// restart - Replaces this process with the executable, which may have been replaced
+func restart() {
+	executable, err := os.Executable()
+	if err != nil {
+		utils.Fatal("unable to restart", err)
+	}
+	utils.Info(fmt.Sprintf("restarting %s...", executable))
+	err = syscall.Exec(executable, os.Args, os.Environ())
+	utils.Fatal("unable to restart", err)
+}
+
+// loadPendingRelease - nil without a pending release
+func loadPendingRelease() (*pendingRelease, error) {
+	bytes, err := ioutil.ReadFile(filepath.Join(types.ReleaseDirectory, releasePendingFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pending := &pendingRelease{}
+	err = json.Unmarshal(bytes, pending)
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// savePendingRelease
+func savePendingRelease(pending *pendingRelease) error {
+	bytes, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(types.ReleaseDirectory, releasePendingFile), bytes, 0644)
+}
+
+// copyFile - Writes next to the destination first so a running executable is replaced, not truncated
+func copyFile(source string, destination string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	bytes, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	temporary := destination + ".tmp"
+	err = ioutil.WriteFile(temporary, bytes, info.Mode())
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporary, destination)
+}
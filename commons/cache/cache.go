@@ -0,0 +1,309 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package cache
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// NoExpiration - the item never expires, it is still bounded by the namespace size
+	NoExpiration time.Duration = -1
+	// DefaultExpiration - the item expires after its namespace's TTL
+	DefaultExpiration time.Duration = 0
+	// DefaultNamespace - holds keys that do not match any namespace prefix
+	DefaultNamespace = "default"
+)
+
+// Namespace - keys starting with one of Prefixes share a size limit and TTL
+type Namespace struct {
+	Name       string
+	Prefixes   []string
+	MaxEntries int                                 // Least recently used items are evicted above this, zero is unbounded
+	TTL        time.Duration                       // Used for DefaultExpiration, zero never expires
+	Sliding    bool                                // Get extends the expiration by TTL
+	OnEvicted  func(key string, value interface{}) // Called when an item is evicted, expires, is replaced or deleted
+}
+
+// Item
+type Item struct {
+	Object     interface{}
+	Expiration int64 // Nanoseconds, zero never expires
+}
+
+// Stats
+type Stats struct {
+	Name        string `json:"name"`
+	Entries     int    `json:"entries"`
+	MaxEntries  int    `json:"maxEntries"`
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+}
+
+// Cache - a cache partitioned into LRU namespaces by key prefix
+type Cache struct {
+	namespaces []*namespace
+	prefixes   []prefix // Longest first
+	fallback   *namespace
+}
+
+// prefix
+type prefix struct {
+	value     string
+	namespace *namespace
+}
+
+// namespace
+type namespace struct {
+	config Namespace
+	mutex  sync.Mutex
+	items  map[string]*list.Element
+	lru    *list.List // Front is most recently used
+	stats  Stats
+}
+
+// entry
+type entry struct {
+	key  string
+	item Item
+}
+
+// eviction - OnEvicted callbacks run after the namespace lock is released
+type eviction struct {
+	onEvicted func(key string, value interface{})
+	key       string
+	value     interface{}
+}
+
+// New - keys that match no namespace share an unbounded default namespace with defaultTTL, expired items are purged every cleanupInterval
+func New(defaultTTL, cleanupInterval time.Duration, namespaces ...Namespace) *Cache {
+	this := &Cache{}
+	this.fallback = newNamespace(Namespace{Name: DefaultNamespace, TTL: defaultTTL})
+	for _, config := range namespaces {
+		namespace := newNamespace(config)
+		if config.Name == DefaultNamespace {
+			this.fallback = namespace
+			continue
+		}
+		this.namespaces = append(this.namespaces, namespace)
+		for _, value := range config.Prefixes {
+			this.prefixes = append(this.prefixes, prefix{value: value, namespace: namespace})
+		}
+	}
+	this.namespaces = append(this.namespaces, this.fallback)
+	sort.SliceStable(this.prefixes, func(i, j int) bool {
+		return len(this.prefixes[i].value) > len(this.prefixes[j].value)
+	})
+	if cleanupInterval > 0 {
+		go this.janitor(cleanupInterval)
+	}
+	return this
+}
+
+// newNamespace
+func newNamespace(config Namespace) *namespace {
+	return &namespace{
+		config: config,
+		items:  map[string]*list.Element{},
+		lru:    list.New(),
+		stats:  Stats{Name: config.Name, MaxEntries: config.MaxEntries},
+	}
+}
+
+// Set
+func (this *Cache) Set(key string, value interface{}, ttl time.Duration) {
+	namespace := this.getNamespace(key)
+	namespace.mutex.Lock()
+	now := time.Now().UnixNano()
+	item := Item{Object: value, Expiration: namespace.expiration(now, ttl)}
+	var evictions []eviction
+	if element, ok := namespace.items[key]; ok {
+		existing := element.Value.(*entry)
+		if namespace.config.OnEvicted != nil {
+			evictions = append(evictions, eviction{namespace.config.OnEvicted, key, existing.item.Object})
+		}
+		existing.item = item
+		namespace.lru.MoveToFront(element)
+	} else {
+		namespace.items[key] = namespace.lru.PushFront(&entry{key: key, item: item})
+	}
+	for namespace.config.MaxEntries > 0 && namespace.lru.Len() > namespace.config.MaxEntries {
+		evictions = append(evictions, namespace.remove(namespace.lru.Back()))
+		namespace.stats.Evictions++
+	}
+	namespace.mutex.Unlock()
+	runEvictions(evictions)
+}
+
+// Get
+func (this *Cache) Get(key string) (interface{}, bool) {
+	namespace := this.getNamespace(key)
+	namespace.mutex.Lock()
+	element, ok := namespace.items[key]
+	if !ok {
+		namespace.stats.Misses++
+		namespace.mutex.Unlock()
+		return nil, false
+	}
+	existing := element.Value.(*entry)
+	now := time.Now().UnixNano()
+	if existing.item.Expiration > 0 && now > existing.item.Expiration {
+		evicted := namespace.remove(element)
+		namespace.stats.Expirations++
+		namespace.stats.Misses++
+		namespace.mutex.Unlock()
+		runEvictions([]eviction{evicted})
+		return nil, false
+	}
+	if namespace.config.Sliding {
+		existing.item.Expiration = namespace.expiration(now, DefaultExpiration)
+	}
+	namespace.lru.MoveToFront(element)
+	namespace.stats.Hits++
+	namespace.mutex.Unlock()
+	return existing.item.Object, true
+}
+
+// Delete
+func (this *Cache) Delete(key string) {
+	namespace := this.getNamespace(key)
+	namespace.mutex.Lock()
+	element, ok := namespace.items[key]
+	if !ok {
+		namespace.mutex.Unlock()
+		return
+	}
+	evicted := namespace.remove(element)
+	namespace.mutex.Unlock()
+	runEvictions([]eviction{evicted})
+}
+
+// Items - copy of every unexpired item, does not affect recency or stats
+func (this *Cache) Items() map[string]Item {
+	now := time.Now().UnixNano()
+	items := map[string]Item{}
+	for _, namespace := range this.namespaces {
+		namespace.mutex.Lock()
+		for key, element := range namespace.items {
+			item := element.Value.(*entry).item
+			if item.Expiration > 0 && now > item.Expiration {
+				continue
+			}
+			items[key] = item
+		}
+		namespace.mutex.Unlock()
+	}
+	return items
+}
+
+// ItemCount - includes expired items that have not been purged yet
+func (this *Cache) ItemCount() int {
+	count := 0
+	for _, namespace := range this.namespaces {
+		namespace.mutex.Lock()
+		count += namespace.lru.Len()
+		namespace.mutex.Unlock()
+	}
+	return count
+}
+
+// DeleteExpired
+func (this *Cache) DeleteExpired() {
+	now := time.Now().UnixNano()
+	for _, namespace := range this.namespaces {
+		var evictions []eviction
+		namespace.mutex.Lock()
+		for element := namespace.lru.Back(); element != nil; {
+			previous := element.Prev()
+			item := element.Value.(*entry).item
+			if item.Expiration > 0 && now > item.Expiration {
+				evictions = append(evictions, namespace.remove(element))
+				namespace.stats.Expirations++
+			}
+			element = previous
+		}
+		namespace.mutex.Unlock()
+		runEvictions(evictions)
+	}
+}
+
+// Stats - per namespace counters, the default namespace is last
+func (this *Cache) Stats() []Stats {
+	stats := make([]Stats, 0, len(this.namespaces))
+	for _, namespace := range this.namespaces {
+		namespace.mutex.Lock()
+		namespaceStats := namespace.stats
+		namespaceStats.Entries = namespace.lru.Len()
+		namespace.mutex.Unlock()
+		stats = append(stats, namespaceStats)
+	}
+	return stats
+}
+
+// getNamespace
+func (this *Cache) getNamespace(key string) *namespace {
+	for _, prefix := range this.prefixes {
+		if strings.HasPrefix(key, prefix.value) {
+			return prefix.namespace
+		}
+	}
+	return this.fallback
+}
+
+// janitor
+func (this *Cache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	for range ticker.C {
+		this.DeleteExpired()
+	}
+}
+
+// expiration - nanosecond deadline for an item set now, zero never expires
+func (this *namespace) expiration(now int64, ttl time.Duration) int64 {
+	if ttl == NoExpiration {
+		return 0
+	}
+	if ttl == DefaultExpiration {
+		ttl = this.config.TTL
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return now + int64(ttl)
+}
+
+// remove - caller holds the lock
+func (this *namespace) remove(element *list.Element) eviction {
+	existing := this.lru.Remove(element).(*entry)
+	delete(this.items, existing.key)
+	return eviction{this.config.OnEvicted, existing.key, existing.item.Object}
+}
+
+// runEvictions
+func runEvictions(evictions []eviction) {
+	for _, evicted := range evictions {
+		if evicted.onEvicted != nil {
+			evicted.onEvicted(evicted.key, evicted.value)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheNamespaceEviction(t *testing.T) {
+	var evicted []string
+	c := New(time.Hour, 0, Namespace{
+		Name:       "accounts",
+		Prefixes:   []string{"table-account-"},
+		MaxEntries: 2,
+		OnEvicted: func(key string, value interface{}) {
+			evicted = append(evicted, key)
+		},
+	})
+	c.Set("table-account-1", 1, DefaultExpiration)
+	c.Set("table-account-2", 2, DefaultExpiration)
+	c.Get("table-account-1")
+	c.Set("table-account-3", 3, DefaultExpiration)
+	c.Set("other", 4, DefaultExpiration)
+
+	if _, ok := c.Get("table-account-2"); ok {
+		t.Error("least recently used item was not evicted")
+	}
+	if _, ok := c.Get("table-account-1"); !ok {
+		t.Error("recently used item was evicted")
+	}
+	if _, ok := c.Get("other"); !ok {
+		t.Error("default namespace item missing")
+	}
+	if len(evicted) != 1 || evicted[0] != "table-account-2" {
+		t.Errorf("unexpected evictions %v", evicted)
+	}
+
+	stats := c.Stats()
+	if stats[0].Name != "accounts" || stats[0].Entries != 2 || stats[0].Hits != 2 || stats[0].Misses != 1 || stats[0].Evictions != 1 {
+		t.Errorf("unexpected accounts stats %+v", stats[0])
+	}
+	if stats[1].Name != DefaultNamespace || stats[1].Entries != 1 {
+		t.Errorf("unexpected default stats %+v", stats[1])
+	}
+}
+
+func TestCacheExpiration(t *testing.T) {
+	c := New(time.Hour, 0, Namespace{Name: "pools", Prefixes: []string{"pool-"}, TTL: time.Millisecond})
+	c.Set("pool-1", 1, NoExpiration)
+	c.Set("item", 2, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("pool-1"); !ok {
+		t.Error("NoExpiration item expired")
+	}
+	c.Set("pool-2", 3, DefaultExpiration)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("pool-2"); ok {
+		t.Error("DefaultExpiration did not use the namespace TTL")
+	}
+	c.Delete("pool-1")
+	c.DeleteExpired()
+	if c.ItemCount() != 0 {
+		t.Errorf("expired items not deleted [count=%d]", c.ItemCount())
+	}
+	if stats := c.Stats(); stats[0].Expirations != 1 || stats[1].Expirations != 1 {
+		t.Errorf("unexpected expirations %+v", stats)
+	}
+}
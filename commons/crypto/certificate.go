@@ -0,0 +1,117 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NodeCertificateBindingOID - Extension holding the node key's signature of the certificate public key, it binds a
+// self-signed TLS certificate to the Dispatch address in its common name
+var NodeCertificateBindingOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 53594, 1, 1}
+
+// nodeCertificateValidity
+const nodeCertificateValidity = 10 * 365 * 24 * time.Hour
+
+// NewNodeCertificate - A self-signed P-256 certificate for the address, signed over by the node's secp256k1 key. Returns
+// the PEM encoded certificate and key.
+func NewNodeCertificate(privateKey []byte, address string) ([]byte, []byte, error) {
+	tlsKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&tlsKey.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	hash := NewHash(publicKeyBytes)
+	binding, err := NewSignature(privateKey, hash[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:    serialNumber,
+		Subject:         pkix.Name{CommonName: address, Organization: []string{"Dispatch"}},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(nodeCertificateValidity),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{{Id: NodeCertificateBindingOID, Value: binding}},
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, template, &tlsKey.PublicKey, tlsKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(tlsKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	certificatePem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateBytes})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	return certificatePem, keyPem, nil
+}
+
+// ToNodeCertificateAddress - The Dispatch address a node certificate is bound to, an error unless the certificate is
+// self-signed, current and its public key is signed by the key of that address
+func ToNodeCertificateAddress(certificate *x509.Certificate) (string, error) {
+	err := certificate.CheckSignature(certificate.SignatureAlgorithm, certificate.RawTBSCertificate, certificate.Signature)
+	if err != nil {
+		return "", errors.Wrap(err, "certificate is not self-signed")
+	}
+	now := time.Now()
+	if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return "", errors.New("certificate is expired or not yet valid")
+	}
+	var binding []byte
+	for _, extension := range certificate.Extensions {
+		if extension.Id.Equal(NodeCertificateBindingOID) {
+			binding = extension.Value
+		}
+	}
+	if len(binding) != 65 {
+		return "", errors.New("certificate is not bound to a node address")
+	}
+	hash := NewHash(certificate.RawSubjectPublicKeyInfo)
+	publicKey, err := ToPublicKey(hash[:], binding)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid certificate binding")
+	}
+	if !VerifySignature(publicKey, hash[:], binding) {
+		return "", errors.New("invalid certificate binding signature")
+	}
+	address := hex.EncodeToString(ToAddress(publicKey))
+	if address != certificate.Subject.CommonName {
+		return "", errors.Errorf("certificate is bound to %s, not %s", address, certificate.Subject.CommonName)
+	}
+	return address, nil
+}
@@ -0,0 +1,62 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package crypto
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+)
+
+// newTestNodeCertificate
+func newTestNodeCertificate(t *testing.T, address string, privateKey []byte) *x509.Certificate {
+	certificatePem, _, err := NewNodeCertificate(privateKey, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(certificatePem)
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+// TestNodeCertificateAddress
+func TestNodeCertificateAddress(t *testing.T) {
+	publicKey, privateKey := GenerateKeyPair()
+	address := hex.EncodeToString(ToAddress(publicKey))
+	certificate := newTestNodeCertificate(t, address, privateKey)
+	boundAddress, err := ToNodeCertificateAddress(certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boundAddress != address {
+		t.Errorf("expected %s, got %s", address, boundAddress)
+	}
+}
+
+// TestNodeCertificateForgedAddress
+func TestNodeCertificateForgedAddress(t *testing.T) {
+	victim, _ := GenerateKeyPair()
+	_, privateKey := GenerateKeyPair()
+	certificate := newTestNodeCertificate(t, hex.EncodeToString(ToAddress(victim)), privateKey)
+	if _, err := ToNodeCertificateAddress(certificate); err == nil {
+		t.Error("certificate claiming another node's address was accepted")
+	}
+}
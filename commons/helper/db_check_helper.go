@@ -0,0 +1,245 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Index key prefixes written by the domain types' Persist
+var indexPrefixes = []string{"key-transaction-", "key-account-name-", "key-node-type-"}
+
+// maxRepairsPerTxn - keep repair transactions well below badger's ErrTxnTooBig
+const maxRepairsPerTxn = 1000
+
+// DbCheckReport
+type DbCheckReport struct {
+	RecordCount     int64
+	IndexCount      int64
+	InvalidRecords  []string
+	InvalidSync     []string // Records ValidateSync refuses, peers would not accept them
+	MissingIndexes  []string // Index keys a record should have but does not
+	DanglingIndexes []string // Index keys pointing at a record that does not exist
+	StaleIndexes    []string // Index keys pointing at a record that no longer produces them
+	RepairedIndexes int64
+}
+
+// IsHealthy
+func (this DbCheckReport) IsHealthy() bool {
+	return len(this.InvalidRecords) == 0 && len(this.InvalidSync) == 0 && len(this.MissingIndexes) == 0 && len(this.DanglingIndexes) == 0 && len(this.StaleIndexes) == 0
+}
+
+// ToPrettyJson
+func (this DbCheckReport) ToPrettyJson() string {
+	bytes, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		utils.Error("unable to marshal DbCheckReport", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// CheckDb - verifies every table record against its index keys and every index key against its record, repair rebuilds missing indexes and deletes dangling or stale ones.
+// Records are decoded one at a time, only the keys of problems are kept.
+func CheckDb(db *badger.DB, repair bool) (*DbCheckReport, error) {
+	report := &DbCheckReport{}
+
+	// Missing index key -> record key to rebuild it from
+	missing := map[string]string{}
+	err := db.View(func(txn *badger.Txn) error {
+		// Badger allows a single open iterator per transaction.
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		prefix := []byte("table-")
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			key := string(iterator.Item().Key())
+			value, err := iterator.Item().Value()
+			if err != nil {
+				iterator.Close()
+				return err
+			}
+			report.RecordCount++
+			if ValidateSyncRecord([]byte(key), value) != nil {
+				report.InvalidSync = append(report.InvalidSync, key)
+			}
+			indexKeys, err := toIndexKeys(key, value)
+			if err != nil {
+				report.InvalidRecords = append(report.InvalidRecords, key)
+				continue
+			}
+
+			// From/to keys collide when an address sends twice in the same millisecond, any owner will do.
+			for _, indexKey := range indexKeys {
+				_, err := txn.Get([]byte(indexKey))
+				if err == badger.ErrKeyNotFound {
+					if _, ok := missing[indexKey]; !ok {
+						report.MissingIndexes = append(report.MissingIndexes, indexKey)
+					}
+					missing[indexKey] = key
+				} else if err != nil {
+					iterator.Close()
+					return err
+				}
+			}
+		}
+		iterator.Close()
+
+		for _, indexPrefix := range indexPrefixes {
+			indexIterator := txn.NewIterator(badger.DefaultIteratorOptions)
+			for indexIterator.Seek([]byte(indexPrefix)); indexIterator.ValidForPrefix([]byte(indexPrefix)); indexIterator.Next() {
+				indexKey := string(indexIterator.Item().Key())
+				recordKey, err := indexIterator.Item().Value()
+				if err != nil {
+					indexIterator.Close()
+					return err
+				}
+				report.IndexCount++
+				item, err := txn.Get(recordKey)
+				if err == badger.ErrKeyNotFound {
+					report.DanglingIndexes = append(report.DanglingIndexes, indexKey)
+					continue
+				} else if err != nil {
+					indexIterator.Close()
+					return err
+				}
+				if indexKey == (types.Account{}).NameKey() {
+					continue
+				}
+				value, err := item.Value()
+				if err != nil {
+					indexIterator.Close()
+					return err
+				}
+
+				// Invalid records are already reported, their index keys are left alone.
+				indexKeys, err := toIndexKeys(string(recordKey), value)
+				if err == nil && !contains(indexKeys, indexKey) {
+					report.StaleIndexes = append(report.StaleIndexes, indexKey)
+				}
+			}
+			indexIterator.Close()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if repair {
+		err = repairIndexes(db, report, missing)
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// toIndexKeys - the index keys Persist writes for a table record
+func toIndexKeys(key string, value []byte) ([]string, error) {
+	if strings.HasPrefix(key, "table-transaction-") {
+		transaction, err := types.ToTransactionFromRecord(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{transaction.TypeKey(), transaction.TimeKey(), transaction.FromKey(), transaction.ToKey()}, nil
+	} else if strings.HasPrefix(key, "table-account-") {
+		account, err := types.ToAccountFromRecord(value)
+		if err != nil {
+			return nil, err
+		}
+
+		// Every unnamed account writes the same empty name key, it cannot be checked.
+		if account.Name == "" {
+			return nil, nil
+		}
+		return []string{account.NameKey()}, nil
+	} else if strings.HasPrefix(key, "table-node-") {
+		node, err := types.ToNodeFromRecord(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{node.TypeKey()}, nil
+	} else if strings.HasPrefix(key, "table-receipt-") {
+		_, err := types.ToReceiptFromRecord(value)
+		return nil, err
+	} else if strings.HasPrefix(key, "table-gossip-") {
+		_, err := types.ToGossipFromRecord(value)
+		return nil, err
+	} else if strings.HasPrefix(key, "table-ratelimit-") {
+		return nil, nil
+	} else if !json.Valid(value) {
+		return nil, fmt.Errorf("invalid record [key=%s]", key)
+	}
+	return nil, nil
+}
+
+// repairIndexes
+func repairIndexes(db *badger.DB, report *DbCheckReport, missing map[string]string) error {
+	txn := db.NewTransaction(true)
+	count := 0
+	commit := func(force bool) error {
+		if count == 0 || (!force && count < maxRepairsPerTxn) {
+			return nil
+		}
+		err := txn.Commit(nil)
+		if err != nil {
+			return err
+		}
+		report.RepairedIndexes += int64(count)
+		count = 0
+		txn = db.NewTransaction(true)
+		return nil
+	}
+	defer func() { txn.Discard() }()
+
+	for indexKey, recordKey := range missing {
+		if err := txn.Set([]byte(indexKey), []byte(recordKey)); err != nil {
+			return err
+		}
+		count++
+		if err := commit(false); err != nil {
+			return err
+		}
+	}
+	for _, indexKeys := range [][]string{report.DanglingIndexes, report.StaleIndexes} {
+		for _, indexKey := range indexKeys {
+			if err := txn.Delete([]byte(indexKey)); err != nil {
+				return err
+			}
+			count++
+			if err := commit(false); err != nil {
+				return err
+			}
+		}
+	}
+	return commit(true)
+}
+
+// contains
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
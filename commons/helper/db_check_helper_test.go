@@ -0,0 +1,117 @@
+package helper
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+func TestCheckDb(t *testing.T) {
+	tx, err := types.NewTransferTokensTransaction(
+		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a",
+		"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
+		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
+		big.NewInt(1),
+		0,
+		utils.ToMilliSeconds(time.Now()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	danglingKey := "key-transaction-time-1-doesnotexist"
+	staleKey := "key-transaction-time-2-" + tx.Hash
+	txn := db.NewTransaction(true)
+	tx.Persist(txn)
+	txn.Delete([]byte(tx.TimeKey()))
+	txn.Set([]byte(danglingKey), []byte("table-transaction-doesnotexist"))
+	txn.Set([]byte(staleKey), []byte(tx.Key()))
+	if err = txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := CheckDb(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(report.MissingIndexes, tx.TimeKey()) {
+		t.Errorf("missing index %s not reported", tx.TimeKey())
+	}
+	if !contains(report.DanglingIndexes, danglingKey) {
+		t.Errorf("dangling index %s not reported", danglingKey)
+	}
+	if !contains(report.StaleIndexes, staleKey) {
+		t.Errorf("stale index %s not reported", staleKey)
+	}
+	if report.IsHealthy() {
+		t.Error("inconsistent DB reported healthy")
+	}
+
+	report, err = CheckDb(db, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.RepairedIndexes == 0 {
+		t.Error("no indexes repaired")
+	}
+	report, err = CheckDb(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contains(report.MissingIndexes, tx.TimeKey()) || contains(report.DanglingIndexes, danglingKey) || contains(report.StaleIndexes, staleKey) {
+		t.Error("repair did not rebuild the indexes")
+	}
+
+	txn = db.NewTransaction(true)
+	for _, key := range []string{tx.Key(), tx.TypeKey(), tx.TimeKey(), tx.FromKey(), tx.ToKey()} {
+		txn.Delete([]byte(key))
+	}
+	txn.Commit(nil)
+}
+
+func TestCheckDbRecords(t *testing.T) {
+	receipt := &types.Receipt{TransactionHash: "checkdbreceipt", Status: types.StatusOk, Created: time.Now()}
+	gossip := &types.Gossip{
+		Transaction: types.Transaction{Hash: "checkdbgossip"},
+		Rumors:      []types.Rumor{{Hash: "hash", Address: "address", TransactionHash: "checkdbgossip", Time: 1, Signature: "signature"}},
+	}
+	corruptKey := "table-receipt-checkdbcorrupt"
+	corruptWindowKey := "table-ratelimit-window-checkdbcorrupt"
+	txn := db.NewTransaction(true)
+	if err := receipt.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	if err := gossip.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	txn.Set([]byte(corruptKey), []byte{types.RecordVersionProto, 0xff, 0xff})
+	txn.Set([]byte(corruptWindowKey), []byte("{"))
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := CheckDb(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contains(report.InvalidRecords, receipt.Key()) || contains(report.InvalidRecords, gossip.Key()) {
+		t.Errorf("valid records reported invalid: %v", report.InvalidRecords)
+	}
+	if !contains(report.InvalidRecords, corruptKey) {
+		t.Errorf("invalid record %s not reported", corruptKey)
+	}
+	if !contains(report.InvalidSync, corruptWindowKey) || contains(report.InvalidSync, receipt.Key()) || contains(report.InvalidSync, gossip.Key()) {
+		t.Errorf("invalid sync records reported as %v, expected %s", report.InvalidSync, corruptWindowKey)
+	}
+	if report.IsHealthy() {
+		t.Error("DB with invalid records reported healthy")
+	}
+
+	txn = db.NewTransaction(true)
+	for _, key := range []string{receipt.Key(), gossip.Key(), corruptKey, corruptWindowKey} {
+		txn.Delete([]byte(key))
+	}
+	txn.Commit(nil)
+}
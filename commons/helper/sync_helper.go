@@ -90,36 +90,34 @@ func ValidateSync(keyBytes []byte, valueBytes []byte) bool {
 	value := string(valueBytes)
 	if strings.HasPrefix(key, "table-account") {
 		addToCountMap("TotalAccountCount", key, value)
-		account := &types.Account{}
-		if err := json.Unmarshal(valueBytes, account); err == nil {
+		if account, err := types.ToAccountFromRecord(valueBytes); err == nil {
 			addToCountMap("goodAccountCount", key, value)
 			fmt.Printf("Account: %s\n\n", account.ToPrettyJson())
 			return true
 		} else {
 			err = HandleInvalidAccount(err, key, value)
-			utils.Error(err, fmt.Sprintf("Received value: %s is not a valid JSON: %s\n", key, value))
+			utils.Error(err, fmt.Sprintf("Received value: %s is not a valid record: %s\n", key, value))
 			return false
 		}
 	} else if strings.HasPrefix(key, "table-transaction") {
 		addToCountMap("TotalTransactionCount", key, value)
-		tx := &types.Transaction{}
-		if err := json.Unmarshal(valueBytes, tx); err == nil {
+		if tx, err := types.ToTransactionFromRecord(valueBytes); err == nil {
 			addToCountMap("goodTransactionCount", key, value)
 			fmt.Printf("Transaction: %s\n\n", tx.ToPrettyJson())
 			return true
 		} else {
 			err = HandleInvalidTransaction(err, key, value)
-			utils.Error(err, fmt.Sprintf("Received value: %s is not a valid JSON: %s\n", key, value))
+			utils.Error(err, fmt.Sprintf("Received value: %s is not a valid record: %s\n", key, value))
 			return false
 		}
 	} else if strings.HasPrefix(key, "table-gossip") {
 		addToCountMap("TotalGossipCount", key, value)
-		if err := json.Unmarshal(valueBytes, &types.Gossip{}); err == nil {
+		if _, err := types.ToGossipFromRecord(valueBytes); err == nil {
 			addToCountMap("goodGossipCount", key, value)
 			return true
 		} else {
 			addToCountMap("badGossipCount", key, value)
-			utils.Error(err, fmt.Sprintf("Received value: %s is not a valid JSON: %s\n", key, value))
+			utils.Error(err, fmt.Sprintf("Received value: %s is not a valid record: %s\n", key, value))
 			return false
 		}
 	} else if strings.HasPrefix(key, "table-rateLimit") {
@@ -136,6 +134,27 @@ func ValidateSync(keyBytes []byte, valueBytes []byte) bool {
 	return true
 }
 
+// ValidateSyncRecord - The checks of ValidateSync without printing or counting, rate limit records are matched on either
+// spelling of their prefix
+func ValidateSyncRecord(keyBytes []byte, valueBytes []byte) error {
+	key := string(keyBytes)
+	if strings.HasPrefix(key, "table-account") {
+		_, err := types.ToAccountFromRecord(valueBytes)
+		return err
+	} else if strings.HasPrefix(key, "table-transaction") {
+		_, err := types.ToTransactionFromRecord(valueBytes)
+		return err
+	} else if strings.HasPrefix(key, "table-gossip") {
+		_, err := types.ToGossipFromRecord(valueBytes)
+		return err
+	} else if strings.HasPrefix(strings.ToLower(key), "table-ratelimit") {
+		if !json.Valid(valueBytes) {
+			return fmt.Errorf("invalid JSON [key=%s]", key)
+		}
+	}
+	return nil
+}
+
 func HandleInvalidAccount(err error, key, value string) error {
 	if strings.Contains(value, "balance") {
 		addToCountMap("badAccountCount", key, value)
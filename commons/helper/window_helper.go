@@ -4,7 +4,7 @@ import (
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 	"time"
 )
 
@@ -3,7 +3,7 @@ package helper
 import (
 	"testing"
 	"github.com/dgraph-io/badger"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 
 	"os"
 	"github.com/dispatchlabs/disgo/commons/utils"
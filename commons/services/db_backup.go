@@ -0,0 +1,187 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/badger/protos"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// accountTablePrefix - keys digested by GetAccountStateDigest
+var accountTablePrefix = []byte("table-account-")
+
+// Backup - streams a consistent Badger backup of every entry newer than since and returns the version to pass as since for the next incremental backup
+func Backup(writer io.Writer, since uint64) (uint64, error) {
+	utils.Info(fmt.Sprintf("backing up DB [since=%d]", since))
+	version, err := GetDb().Backup(writer, since)
+	if err != nil {
+		utils.Error("unable to backup DB", err)
+		return 0, err
+	}
+	utils.Info(fmt.Sprintf("backed up DB [since=%d, version=%d]", since, version))
+	return version, nil
+}
+
+// Restore - loads a backup created by Backup, no other transactions may run while loading
+func Restore(reader io.Reader) error {
+	utils.Info("restoring DB...")
+	err := GetDb().Load(reader)
+	if err != nil {
+		utils.Error("unable to restore DB", err)
+		return err
+	}
+	return nil
+}
+
+// VerifyRestore - compares the restored accounts against the digest of the backups they were loaded from, accounts the
+// DB held before a forced restore are not compared
+func VerifyRestore(expectedDigest string, keys [][]byte) (string, error) {
+	digest, err := GetAccountStateDigestOf(keys)
+	if err != nil {
+		return "", err
+	}
+	if digest != expectedDigest {
+		return "", fmt.Errorf("account state digest mismatch after restore [expected=%s, actual=%s]", expectedDigest, digest)
+	}
+	return digest, nil
+}
+
+// IsDbEmpty
+func IsDbEmpty() bool {
+	txn := NewTxn(false)
+	defer txn.Discard()
+	iterator := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
+	defer iterator.Close()
+	iterator.Rewind()
+	return !iterator.Valid()
+}
+
+// GetAccountStateDigest - hex encoded SHA-256 over every account record (key and value) in key order
+func GetAccountStateDigest() (string, error) {
+	hash := sha256.New()
+	err := GetDb().View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		for iterator.Seek(accountTablePrefix); iterator.ValidForPrefix(accountTablePrefix); iterator.Next() {
+			value, err := iterator.Item().Value()
+			if err != nil {
+				return err
+			}
+			writeDigestEntry(hash, iterator.Item().Key(), value)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GetAccountStateDigestOf - GetAccountStateDigest of the account records at keys, in key order
+func GetAccountStateDigestOf(keys [][]byte) (string, error) {
+	hash := sha256.New()
+	err := GetDb().View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get(key)
+			if err == badger.ErrKeyNotFound {
+				return fmt.Errorf("account record %s is missing after restore", key)
+			}
+			if err != nil {
+				return err
+			}
+			value, err := item.Value()
+			if err != nil {
+				return err
+			}
+			writeDigestEntry(hash, key, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GetBackupAccountStateDigest - digest the account state a DB would hold after loading the given backups in order, and
+// the keys of the accounts in key order
+func GetBackupAccountStateDigest(readers ...io.Reader) (string, [][]byte, error) {
+	type versionedValue struct {
+		version uint64
+		value   []byte
+	}
+	accounts := map[string]*versionedValue{}
+	for _, reader := range readers {
+		bufferedReader := bufio.NewReader(reader)
+		for {
+			var size uint64
+			err := binary.Read(bufferedReader, binary.LittleEndian, &size)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return "", nil, err
+			}
+			buffer := make([]byte, size)
+			if _, err = io.ReadFull(bufferedReader, buffer); err != nil {
+				return "", nil, err
+			}
+			kvPair := &protos.KVPair{}
+			if err = kvPair.Unmarshal(buffer); err != nil {
+				return "", nil, err
+			}
+			if !bytes.HasPrefix(kvPair.Key, accountTablePrefix) {
+				continue
+			}
+			existing, ok := accounts[string(kvPair.Key)]
+			if ok && existing.version > kvPair.Version {
+				continue
+			}
+			accounts[string(kvPair.Key)] = &versionedValue{version: kvPair.Version, value: kvPair.Value}
+		}
+	}
+
+	keys := make([]string, 0, len(accounts))
+	for key := range accounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	accountKeys := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		writeDigestEntry(hash, []byte(key), accounts[key].value)
+		accountKeys = append(accountKeys, []byte(key))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), accountKeys, nil
+}
+
+// writeDigestEntry - length prefix key and value so adjacent entries cannot collide
+func writeDigestEntry(writer io.Writer, key, value []byte) {
+	binary.Write(writer, binary.LittleEndian, uint64(len(key)))
+	writer.Write(key)
+	binary.Write(writer, binary.LittleEndian, uint64(len(value)))
+	writer.Write(value)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBackupRestore(t *testing.T) {
+	deleteTestRecords(t, string(accountTablePrefix))
+	setTestRecords(t, map[string]string{
+		"table-account-a": "1",
+		"table-account-b": "2",
+		"key-other":       "x",
+	})
+	full := &bytes.Buffer{}
+	version, err := Backup(full, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fullDigest, err := GetAccountStateDigest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, _, err := GetBackupAccountStateDigest(bytes.NewReader(full.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != fullDigest {
+		t.Fatalf("backup digest %s does not match the DB digest %s", digest, fullDigest)
+	}
+
+	// The incremental backup overrides b
+	setTestRecords(t, map[string]string{
+		"table-account-b": "3",
+		"table-account-c": "4",
+	})
+	incremental := &bytes.Buffer{}
+	if _, err = Backup(incremental, version); err != nil {
+		t.Fatal(err)
+	}
+	expectedDigest, keys, err := GetBackupAccountStateDigest(bytes.NewReader(full.Bytes()), bytes.NewReader(incremental.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedDigest == fullDigest {
+		t.Fatal("incremental backup did not change the digest")
+	}
+	digest, err = GetAccountStateDigest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != expectedDigest {
+		t.Fatalf("digest of both backups %s does not match the DB digest %s", expectedDigest, digest)
+	}
+
+	withEmptyTestDb(t, func() {
+		if err = Restore(bytes.NewReader(full.Bytes())); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = VerifyRestore(expectedDigest, keys); err == nil {
+			t.Error("restore without the incremental backup was verified")
+		}
+		if err = Restore(bytes.NewReader(incremental.Bytes())); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = VerifyRestore(expectedDigest, keys); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// Forced over a DB holding other accounts
+	withEmptyTestDb(t, func() {
+		setTestRecords(t, map[string]string{"table-account-z": "5"})
+		for _, backup := range []*bytes.Buffer{full, incremental} {
+			if err = Restore(bytes.NewReader(backup.Bytes())); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err = VerifyRestore(expectedDigest, keys); err != nil {
+			t.Error(err)
+		}
+		deleteTestRecords(t, "table-account-c")
+		if _, err = VerifyRestore(expectedDigest, keys); err == nil {
+			t.Error("restore missing an account was verified")
+		}
+	})
+}
+
+func TestBackupDigestTruncated(t *testing.T) {
+	setTestRecords(t, map[string]string{"table-account-a": "1"})
+	backup := &bytes.Buffer{}
+	if _, err := Backup(backup, 0); err != nil {
+		t.Fatal(err)
+	}
+	truncated := backup.Bytes()[:backup.Len()-1]
+	if _, _, err := GetBackupAccountStateDigest(bytes.NewReader(truncated)); err == nil {
+		t.Error("truncated backup was digested")
+	}
+}
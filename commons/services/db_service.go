@@ -22,9 +22,10 @@ import (
 	badgerOptions "github.com/dgraph-io/badger/options"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 	"os"
 	"sync"
+	"time"
 )
 
 var dbServiceInstance *DbService
@@ -33,7 +34,7 @@ var dbServiceOnce sync.Once
 // GetDbService
 func GetDbService() *DbService {
 	dbServiceOnce.Do(func() {
-		dbServiceInstance = &DbService{running: false, kmutex: utils.NewKmutex(), cache: cache.New(types.CacheTTL, types.CacheTTL*2)}
+		dbServiceInstance = &DbService{running: false, kmutex: utils.NewKmutex(), cache: cache.New(types.CacheTTL, time.Minute, types.GetCacheNamespaces()...)}
 		dbServiceInstance.openDb()
 	})
 	return dbServiceInstance
@@ -98,6 +99,11 @@ func GetCache() *cache.Cache {
 	return GetDbService().cache
 }
 
+// GetCacheStats
+func GetCacheStats() []cache.Stats {
+	return GetDbService().cache.Stats()
+}
+
 // GetDb
 func GetDb() *badger.DB {
 	return GetDbService().db
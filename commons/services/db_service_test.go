@@ -0,0 +1,94 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/cache"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// TestMain - the package tests share a DB service on a temporary DB instead of ./db
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "disgo-services")
+	if err != nil {
+		panic(err)
+	}
+	dbServiceOnce.Do(func() {
+		db, err := openTestDb(dir)
+		if err != nil {
+			panic(err)
+		}
+		dbServiceInstance = &DbService{db: db, kmutex: utils.NewKmutex(), cache: cache.New(types.CacheTTL, time.Minute, types.GetCacheNamespaces()...)}
+	})
+	code := m.Run()
+	GetDb().Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// openTestDb
+func openTestDb(dir string) (*badger.DB, error) {
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	return badger.Open(opts)
+}
+
+// withEmptyTestDb - runs test against an empty DB in place of the shared one
+func withEmptyTestDb(t *testing.T, test func()) {
+	dir, err := ioutil.TempDir("", "disgo-services")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := openTestDb(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := dbServiceInstance.db
+	dbServiceInstance.db = db
+	defer func() {
+		dbServiceInstance.db = shared
+		db.Close()
+	}()
+	test()
+}
+
+// setTestRecords
+func setTestRecords(t *testing.T, records map[string]string) {
+	txn := NewTxn(true)
+	defer txn.Discard()
+	for key, value := range records {
+		if err := txn.Set([]byte(key), []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deleteTestRecords - removes every key with prefix
+func deleteTestRecords(t *testing.T, prefix string) {
+	txn := NewTxn(true)
+	defer txn.Discard()
+	iterator := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
+	var keys [][]byte
+	for iterator.Seek([]byte(prefix)); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+		keys = append(keys, iterator.Item().KeyCopy(nil))
+	}
+	iterator.Close()
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+}
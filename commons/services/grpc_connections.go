@@ -0,0 +1,232 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Every peer has one connection that all calls share. gRPC reconnects it with exponential backoff. The sweeper only closes
+// connections without calls in flight, those of peers that are no longer delegates or seeds once they were not called for a sweep
+// period and the others once they were not called for grpcConnectionIdle.
+const (
+	grpcKeepaliveTime         = 30 * time.Second
+	grpcKeepaliveTimeout      = 10 * time.Second
+	grpcKeepaliveMinTime      = 20 * time.Second // Servers refuse more frequent pings
+	grpcReconnectMaxBackoff   = 30 * time.Second
+	grpcConnectionIdle        = 30 * time.Minute
+	grpcConnectionSweepPeriod = time.Minute
+)
+
+var grpcConnections = map[string]*grpcConnection{}
+var grpcConnectionsMutex sync.Mutex
+var grpcConnectionsOnce sync.Once
+
+// grpcConnection - lastUsed and active are guarded by grpcConnectionsMutex
+type grpcConnection struct {
+	address  string
+	target   string
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+	active   int // Calls and streams in flight
+}
+
+// GetGrpcConnection - The shared connection to a peer, dialed on first use or when the peer moved. Callers must not close it.
+func GetGrpcConnection(address string, host string, port int64) (*grpc.ClientConn, error) {
+	grpcConnectionsOnce.Do(func() {
+		go grpcConnectionSweeper()
+	})
+	target := fmt.Sprintf("%s:%d", host, port)
+
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	connection, ok := grpcConnections[address]
+	if ok && connection.target == target && connection.conn.GetState() != connectivity.Shutdown {
+		connection.lastUsed = time.Now()
+		return connection.conn, nil
+	}
+	if ok {
+		connection.conn.Close()
+	}
+
+	connection = &grpcConnection{address: address, target: target, lastUsed: time.Now()}
+	options := append(getGrpcDialOptions(address, connection.trackUnary(signUnaryRequest), connection.trackStream(signStreamRequest)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: grpcKeepaliveTime, Timeout: grpcKeepaliveTimeout, PermitWithoutStream: true}),
+		grpc.WithBackoffMaxDelay(grpcReconnectMaxBackoff),
+	)
+	conn, err := grpc.Dial(target, options...)
+	if err != nil {
+		delete(grpcConnections, address)
+		return nil, err
+	}
+	connection.conn = conn
+	grpcConnections[address] = connection
+	go watchGrpcConnection(connection)
+	utils.Debug(fmt.Sprintf("connected to peer [address=%s, target=%s]", address, target))
+	return conn, nil
+}
+
+// CloseGrpcConnection - Closes the connection to a peer, the next call dials again
+func CloseGrpcConnection(address string) {
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	closeGrpcConnection(address)
+}
+
+// GetGrpcConnectionStates - Connectivity of each peer connection by address
+func GetGrpcConnectionStates() map[string]string {
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	states := make(map[string]string, len(grpcConnections))
+	for address, connection := range grpcConnections {
+		states[address] = connection.conn.GetState().String()
+	}
+	return states
+}
+
+// closeGrpcConnection - caller holds the lock
+func closeGrpcConnection(address string) {
+	connection, ok := grpcConnections[address]
+	if !ok {
+		return
+	}
+	delete(grpcConnections, address)
+	err := connection.conn.Close()
+	if err != nil {
+		utils.Debug(fmt.Sprintf("unable to close connection [address=%s]", address), err)
+	}
+}
+
+// trackUnary - Client interceptor, the connection is in use while a call is in flight
+func (this *grpcConnection) trackUnary(next grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, reply interface{}, clientConn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		this.begin()
+		defer this.end()
+		return next(ctx, method, request, reply, clientConn, invoker, opts...)
+	}
+}
+
+// trackStream - Client interceptor, the connection is in use until the stream fails, ends or its context is done
+func (this *grpcConnection) trackStream(next grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, clientConn *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		this.begin()
+		stream, err := next(ctx, desc, clientConn, method, streamer, opts...)
+		if err != nil {
+			this.end()
+			return nil, err
+		}
+		tracked := &trackedClientStream{ClientStream: stream, connection: this}
+		go func() {
+			<-stream.Context().Done()
+			tracked.finish()
+		}()
+		return tracked, nil
+	}
+}
+
+// begin
+func (this *grpcConnection) begin() {
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	this.active++
+	this.lastUsed = time.Now()
+}
+
+// end
+func (this *grpcConnection) end() {
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	this.active--
+	this.lastUsed = time.Now()
+}
+
+// trackedClientStream
+type trackedClientStream struct {
+	grpc.ClientStream
+	connection *grpcConnection
+	once       sync.Once
+}
+
+// RecvMsg - The stream ended when it returns an error, io.EOF included
+func (this *trackedClientStream) RecvMsg(message interface{}) error {
+	err := this.ClientStream.RecvMsg(message)
+	if err != nil {
+		this.finish()
+	}
+	return err
+}
+
+// finish
+func (this *trackedClientStream) finish() {
+	this.once.Do(this.connection.end)
+}
+
+// watchGrpcConnection - Logs connectivity changes until the connection is closed
+func watchGrpcConnection(connection *grpcConnection) {
+	state := connection.conn.GetState()
+	for connection.conn.WaitForStateChange(context.Background(), state) {
+		state = connection.conn.GetState()
+		switch state {
+		case connectivity.Shutdown:
+			return
+		case connectivity.TransientFailure:
+			utils.Debug(fmt.Sprintf("connection to peer failed, reconnecting [address=%s, target=%s]", connection.address, connection.target))
+		case connectivity.Ready:
+			utils.Debug(fmt.Sprintf("connection to peer ready [address=%s, target=%s]", connection.address, connection.target))
+		}
+	}
+}
+
+// grpcConnectionSweeper
+func grpcConnectionSweeper() {
+	ticker := time.NewTicker(grpcConnectionSweepPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		sweepGrpcConnections()
+	}
+}
+
+// sweepGrpcConnections - Closes the idle connections, sooner for peers that left the delegate set
+func sweepGrpcConnections() {
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	now := time.Now()
+	for address, connection := range grpcConnections {
+		idle := now.Sub(connection.lastUsed)
+		if connection.active > 0 || idle <= grpcConnectionSweepPeriod {
+			continue
+		}
+		if idle > grpcConnectionIdle {
+			utils.Debug(fmt.Sprintf("closing idle connection [address=%s]", address))
+			closeGrpcConnection(address)
+			continue
+		}
+		if !isNodeType(address, []string{types.TypeDelegate, types.TypeSeed}) {
+			utils.Debug(fmt.Sprintf("closing connection to a peer that left the delegates [address=%s]", address))
+			closeGrpcConnection(address)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// passUnary
+func passUnary(ctx context.Context, method string, request, reply interface{}, clientConn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(ctx, method, request, reply, clientConn, opts...)
+}
+
+// passStream
+func passStream(ctx context.Context, desc *grpc.StreamDesc, clientConn *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(ctx, desc, clientConn, method, opts...)
+}
+
+// testClientStream - ends after one message
+type testClientStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	received bool
+}
+
+// Context
+func (this *testClientStream) Context() context.Context {
+	return this.ctx
+}
+
+// RecvMsg
+func (this *testClientStream) RecvMsg(message interface{}) error {
+	if this.received {
+		return io.EOF
+	}
+	this.received = true
+	return nil
+}
+
+// addTestGrpcConnection - a connection to a peer that is not listening, last used idle ago
+func addTestGrpcConnection(t *testing.T, address string, idle time.Duration) *grpcConnection {
+	conn, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	connection := &grpcConnection{address: address, target: "127.0.0.1:1", conn: conn, lastUsed: time.Now().Add(-idle)}
+	grpcConnectionsMutex.Lock()
+	grpcConnections[address] = connection
+	grpcConnectionsMutex.Unlock()
+	return connection
+}
+
+// isGrpcConnectionOpen
+func isGrpcConnectionOpen(connection *grpcConnection) bool {
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	return grpcConnections[connection.address] == connection && connection.conn.GetState() != connectivity.Shutdown
+}
+
+// TestSweepGrpcConnections
+func TestSweepGrpcConnections(t *testing.T) {
+	defer deleteTestRecords(t, "table-delegate-set-")
+	delegate := newTestSigner().address
+	txn := NewTxn(true)
+	defer txn.Discard()
+	if err := (&types.DelegateSet{Epoch: 1, Delegates: []*types.Node{{Address: delegate}}}).Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		address  string
+		idle     time.Duration
+		expected bool
+	}{
+		{"recently used non delegate", newTestSigner().address, grpcConnectionSweepPeriod / 2, true},
+		{"idle non delegate", newTestSigner().address, 2 * grpcConnectionSweepPeriod, false},
+		{"idle delegate", delegate, 2 * grpcConnectionSweepPeriod, true},
+		{"long idle delegate", delegate, 2 * grpcConnectionIdle, false},
+	}
+	for _, test := range tests {
+		connection := addTestGrpcConnection(t, test.address, test.idle)
+		sweepGrpcConnections()
+		if isGrpcConnectionOpen(connection) != test.expected {
+			t.Errorf("%s: connection open is %t, expected %t", test.name, !test.expected, test.expected)
+		}
+		CloseGrpcConnection(test.address)
+	}
+}
+
+// setGrpcConnectionIdle
+func setGrpcConnectionIdle(connection *grpcConnection, idle time.Duration) {
+	grpcConnectionsMutex.Lock()
+	connection.lastUsed = time.Now().Add(-idle)
+	grpcConnectionsMutex.Unlock()
+}
+
+// TestSweepGrpcConnectionsInUse - connections with a call or stream in flight are never closed
+func TestSweepGrpcConnectionsInUse(t *testing.T) {
+	connection := addTestGrpcConnection(t, newTestSigner().address, 2*grpcConnectionIdle)
+	defer CloseGrpcConnection(connection.address)
+
+	called := make(chan bool)
+	release := make(chan bool)
+	done := make(chan error)
+	go func() {
+		done <- connection.trackUnary(passUnary)(context.Background(), "/test.Test/Open", nil, nil, connection.conn, func(ctx context.Context, method string, request, reply interface{}, clientConn *grpc.ClientConn, opts ...grpc.CallOption) error {
+			called <- true
+			<-release
+			return nil
+		})
+	}()
+	<-called
+	setGrpcConnectionIdle(connection, 2*grpcConnectionIdle)
+	sweepGrpcConnections()
+	if !isGrpcConnectionOpen(connection) {
+		t.Fatal("connection with a call in flight was closed")
+	}
+	release <- true
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := connection.trackStream(passStream)(context.Background(), &grpc.StreamDesc{}, connection.conn, "/test.Test/Stream", func(ctx context.Context, desc *grpc.StreamDesc, clientConn *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{ctx: context.Background()}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setGrpcConnectionIdle(connection, 2*grpcConnectionIdle)
+	sweepGrpcConnections()
+	if !isGrpcConnectionOpen(connection) {
+		t.Fatal("connection with an open stream was closed")
+	}
+	for stream.RecvMsg(nil) == nil {
+	}
+	setGrpcConnectionIdle(connection, 2*grpcConnectionIdle)
+	sweepGrpcConnections()
+	if isGrpcConnectionOpen(connection) {
+		t.Error("idle connection was not closed once the stream ended")
+	}
+}
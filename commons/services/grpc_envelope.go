@@ -0,0 +1,290 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Requests carry an envelope in their metadata, the signature is of the hash of the method, the hash of the request and the
+// time. Streams are signed without a request hash.
+const (
+	envelopeAddressHeader   = "dispatch-address"
+	envelopeTimeHeader      = "dispatch-time"
+	envelopeSignatureHeader = "dispatch-signature"
+	envelopeMaxAge          = 30 * time.Second
+)
+
+// envelopeSignatures - signatures seen within 2*envelopeMaxAge, a replayed signature is rejected
+var envelopeSignatures = newSignatureWindow(envelopeMaxAge)
+
+// signatureWindow - Signatures bucketed by the interval they were seen in. Buckets are only dropped once they are older than
+// two intervals, nothing is evicted for space, so a signature is remembered for as long as its request time is accepted
+type signatureWindow struct {
+	interval time.Duration
+	buckets  map[int64]map[string]bool
+	mutex    sync.Mutex
+}
+
+// newSignatureWindow
+func newSignatureWindow(interval time.Duration) *signatureWindow {
+	return &signatureWindow{interval: interval, buckets: map[int64]map[string]bool{}}
+}
+
+// record - False when the signature was already seen
+func (this *signatureWindow) record(signature string, now time.Time) bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	current := now.UnixNano() / int64(this.interval)
+	for bucket, signatures := range this.buckets {
+		if bucket < current-2 {
+			delete(this.buckets, bucket)
+			continue
+		}
+		if signatures[signature] {
+			return false
+		}
+	}
+	if this.buckets[current] == nil {
+		this.buckets[current] = map[string]bool{}
+	}
+	this.buckets[current][signature] = true
+	return true
+}
+
+// requestAddressKey
+type requestAddressKey struct{}
+
+// GetRequestAddress - The address that signed an incoming request, empty when unsigned
+func GetRequestAddress(ctx context.Context) string {
+	address, _ := ctx.Value(requestAddressKey{}).(string)
+	return address
+}
+
+// RestrictMethods - Only signed requests from nodes of one of the types may call the methods (full gRPC method names)
+func (this *GrpcService) RestrictMethods(nodeTypes []string, methods ...string) {
+	this.interceptorsMutex.Lock()
+	defer this.interceptorsMutex.Unlock()
+	for _, method := range methods {
+		this.restrictedMethods[method] = nodeTypes
+	}
+}
+
+// signUnaryRequest - Client interceptor
+func signUnaryRequest(ctx context.Context, method string, request, reply interface{}, clientConn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, err := signRequest(ctx, method, request)
+	if err != nil {
+		return err
+	}
+	return invoker(ctx, method, request, reply, clientConn, opts...)
+}
+
+// signStreamRequest - Client interceptor
+func signStreamRequest(ctx context.Context, desc *grpc.StreamDesc, clientConn *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, err := signRequest(ctx, method, nil)
+	if err != nil {
+		return nil, err
+	}
+	return streamer(ctx, desc, clientConn, method, opts...)
+}
+
+// signRequest
+func signRequest(ctx context.Context, method string, request interface{}) (context.Context, error) {
+	now := strconv.FormatInt(utils.ToMilliSeconds(time.Now()), 10)
+	signature, err := newEnvelopeSignature(types.GetKey(), method, request, now)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		envelopeAddressHeader, types.GetAccount().Address,
+		envelopeTimeHeader, now,
+		envelopeSignatureHeader, signature,
+	), nil
+}
+
+// newEnvelopeSignature
+func newEnvelopeSignature(privateKey string, method string, request interface{}, now string) (string, error) {
+	hash, err := newEnvelopeHash(method, request, now)
+	if err != nil {
+		return "", err
+	}
+	privateKeyBytes, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return "", err
+	}
+	signatureBytes, err := crypto.NewSignature(privateKeyBytes, hash)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(signatureBytes), nil
+}
+
+// verifyUnaryRequest - Server interceptor
+func (this *GrpcService) verifyUnaryRequest(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := this.verifyRequest(ctx, info.FullMethod, request)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// verifyStreamRequest - Server interceptor
+func (this *GrpcService) verifyStreamRequest(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := this.verifyRequest(stream.Context(), info.FullMethod, nil)
+	if err != nil {
+		return err
+	}
+	return handler(server, &envelopeServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// verifyRequest - Unsigned requests are only refused by restricted methods, a request that is signed must be signed correctly
+func (this *GrpcService) verifyRequest(ctx context.Context, method string, request interface{}) (context.Context, error) {
+	this.interceptorsMutex.RLock()
+	nodeTypes, restricted := this.restrictedMethods[method]
+	this.interceptorsMutex.RUnlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	address, now, signature := getHeader(md, envelopeAddressHeader), getHeader(md, envelopeTimeHeader), getHeader(md, envelopeSignatureHeader)
+	if signature == "" {
+		if restricted {
+			return nil, status.Error(codes.Unauthenticated, "unsigned request")
+		}
+		return ctx, nil
+	}
+
+	// Current?
+	milliseconds, err := strconv.ParseInt(now, 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid request time")
+	}
+	age := time.Since(utils.ToTimeFromMilliseconds(milliseconds))
+	if age > envelopeMaxAge || age < -envelopeMaxAge {
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("request time is %s off", age))
+	}
+
+	// Signed by address?
+	hash, err := newEnvelopeHash(method, request, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unable to decode signature")
+	}
+	publicKeyBytes, err := crypto.ToPublicKey(hash, signatureBytes)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unable to generate public key from hash and signature")
+	}
+	if hex.EncodeToString(crypto.ToAddress(publicKeyBytes)) != address || !crypto.VerifySignature(publicKeyBytes, hash, signatureBytes) {
+		return nil, status.Error(codes.Unauthenticated, "invalid request signature")
+	}
+	if peerAddress := GetPeerAddress(ctx); peerAddress != "" && peerAddress != address {
+		return nil, status.Error(codes.Unauthenticated, "request is not signed by the TLS peer")
+	}
+
+	// Allowed? Refused requests are not recorded so they cannot fill the window.
+	if restricted && !isNodeType(address, nodeTypes) {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("%s is restricted to %v", method, nodeTypes))
+	}
+
+	// Replayed?
+	if !envelopeSignatures.record(signature, time.Now()) {
+		return nil, status.Error(codes.Unauthenticated, "replayed request")
+	}
+	return context.WithValue(ctx, requestAddressKey{}, address), nil
+}
+
+// newEnvelopeHash
+func newEnvelopeHash(method string, request interface{}, now string) ([]byte, error) {
+	var body []byte
+	if message, ok := request.(proto.Message); ok {
+		var err error
+		body, err = proto.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+	bodyHash := crypto.NewHash(body)
+	hash := crypto.NewHash([]byte(method), bodyHash[:], []byte(now))
+	return hash[:], nil
+}
+
+// isNodeType - Seeds are configured, delegates are those of the latest persisted delegate set and other types are known from the
+// persisted nodes, cache entries can be evicted so they are not used
+func isNodeType(address string, nodeTypes []string) bool {
+	txn := NewTxn(false)
+	defer txn.Discard()
+	for _, nodeType := range nodeTypes {
+		switch nodeType {
+		case types.TypeSeed:
+			for _, seed := range types.GetConfig().Seeds {
+				if seed.Address == address {
+					return true
+				}
+			}
+		case types.TypeDelegate:
+			delegateSet, err := types.ToLatestDelegateSet(txn)
+			if err != nil {
+				continue
+			}
+			for _, delegate := range delegateSet.Delegates {
+				if delegate.Address == address {
+					return true
+				}
+			}
+		default:
+			if _, err := txn.Get([]byte(types.Node{Address: address, Type: nodeType}.TypeKey())); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getHeader
+func getHeader(md metadata.MD, name string) string {
+	values := md.Get(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// envelopeServerStream - Carries the verified address in the stream's context
+type envelopeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context
+func (this *envelopeServerStream) Context() context.Context {
+	return this.ctx
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	daposProto "github.com/dispatchlabs/disgo/dapos/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testOpenMethod       = "/test.Test/Open"
+	testRestrictedMethod = "/test.Test/Restricted"
+)
+
+// testSigner
+type testSigner struct {
+	address    string
+	privateKey string
+}
+
+// newTestSigner
+func newTestSigner() *testSigner {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	return &testSigner{address: hex.EncodeToString(crypto.ToAddress(publicKey)), privateKey: hex.EncodeToString(privateKey)}
+}
+
+// sign - an incoming context with the envelope of a request sent at time
+func (this *testSigner) sign(t *testing.T, method string, request interface{}, time time.Time) context.Context {
+	now := strconv.FormatInt(utils.ToMilliSeconds(time), 10)
+	signature, err := newEnvelopeSignature(this.privateKey, method, request, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(envelopeAddressHeader, this.address, envelopeTimeHeader, now, envelopeSignatureHeader, signature))
+}
+
+// withTlsPeer - the context of a request over TLS from a certificate bound to address
+func withTlsPeer(ctx context.Context, address string) context.Context {
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: address}}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}}}})
+}
+
+// newTestGrpcService
+func newTestGrpcService() *GrpcService {
+	service := &GrpcService{restrictedMethods: map[string][]string{}}
+	service.RestrictMethods([]string{types.TypeDelegate}, testRestrictedMethod)
+	return service
+}
+
+// TestVerifyRequest
+func TestVerifyRequest(t *testing.T) {
+	service := newTestGrpcService()
+	signer := newTestSigner()
+	other := newTestSigner()
+	request := &daposProto.Account{Address: other.address}
+
+	ctx, err := service.verifyRequest(signer.sign(t, testOpenMethod, nil, time.Now()), testOpenMethod, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GetRequestAddress(ctx) != signer.address {
+		t.Errorf("verified request address is %q, expected %s", GetRequestAddress(ctx), signer.address)
+	}
+	if _, err = service.verifyRequest(context.Background(), testOpenMethod, nil); err != nil {
+		t.Errorf("unsigned request to an open method was refused: %v", err)
+	}
+
+	badSignature := signer.sign(t, testOpenMethod, nil, time.Now())
+	md, _ := metadata.FromIncomingContext(badSignature)
+	signature := []byte(md.Get(envelopeSignatureHeader)[0])
+	signature[10] ^= 1
+	md.Set(envelopeSignatureHeader, string(signature))
+
+	wrongAddress := signer.sign(t, testOpenMethod, nil, time.Now())
+	md, _ = metadata.FromIncomingContext(wrongAddress)
+	md.Set(envelopeAddressHeader, other.address)
+
+	replayed := signer.sign(t, testOpenMethod, nil, time.Now())
+	if _, err = service.verifyRequest(replayed, testOpenMethod, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		request  interface{}
+		expected codes.Code
+	}{
+		{"bad signature", badSignature, testOpenMethod, nil, codes.Unauthenticated},
+		{"wrong address", wrongAddress, testOpenMethod, nil, codes.Unauthenticated},
+		{"stale time", signer.sign(t, testOpenMethod, nil, time.Now().Add(-2*envelopeMaxAge)), testOpenMethod, nil, codes.Unauthenticated},
+		{"future time", signer.sign(t, testOpenMethod, nil, time.Now().Add(2*envelopeMaxAge)), testOpenMethod, nil, codes.Unauthenticated},
+		{"replay", replayed, testOpenMethod, nil, codes.Unauthenticated},
+		{"other method", signer.sign(t, testOpenMethod, nil, time.Now()), testRestrictedMethod, nil, codes.Unauthenticated},
+		{"other request", signer.sign(t, testOpenMethod, nil, time.Now()), testOpenMethod, request, codes.Unauthenticated},
+		{"unsigned restricted", context.Background(), testRestrictedMethod, nil, codes.Unauthenticated},
+		{"TLS peer mismatch", withTlsPeer(signer.sign(t, testOpenMethod, nil, time.Now()), other.address), testOpenMethod, nil, codes.Unauthenticated},
+		{"not a delegate", signer.sign(t, testRestrictedMethod, nil, time.Now()), testRestrictedMethod, nil, codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		_, err := service.verifyRequest(test.ctx, test.method, test.request)
+		if status.Code(err) != test.expected {
+			t.Errorf("%s: verifyRequest returned %v, expected %s", test.name, err, test.expected)
+		}
+	}
+
+	if _, err = service.verifyRequest(withTlsPeer(signer.sign(t, testOpenMethod, nil, time.Now()), signer.address), testOpenMethod, nil); err != nil {
+		t.Errorf("request signed by the TLS peer was refused: %v", err)
+	}
+}
+
+// TestVerifyRequestDelegates - restricted methods check the persisted delegate set, not the cache
+func TestVerifyRequestDelegates(t *testing.T) {
+	defer deleteTestRecords(t, "table-delegate-set-")
+	service := newTestGrpcService()
+	delegate := newTestSigner()
+	cached := newTestSigner()
+
+	// Cached as a delegate but not in the delegate set.
+	node := &types.Node{Address: cached.address, Type: types.TypeDelegate}
+	node.Cache(GetCache())
+	defer GetCache().Delete(node.Key())
+	defer GetCache().Delete(node.TypeKey())
+
+	txn := NewTxn(true)
+	defer txn.Discard()
+	delegateSet := &types.DelegateSet{Epoch: 1, Delegates: []*types.Node{{Address: delegate.address}}}
+	if err := delegateSet.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.verifyRequest(delegate.sign(t, testRestrictedMethod, nil, time.Now()), testRestrictedMethod, nil); err != nil {
+		t.Errorf("request from a delegate of the persisted set was refused: %v", err)
+	}
+	if _, err := service.verifyRequest(cached.sign(t, testRestrictedMethod, nil, time.Now()), testRestrictedMethod, nil); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("request from a node only cached as a delegate returned %v, expected %s", err, codes.PermissionDenied)
+	}
+}
+
+// TestRefusedRequestNotRecorded - a request refused by a restricted method does not take up its signature
+func TestRefusedRequestNotRecorded(t *testing.T) {
+	service := newTestGrpcService()
+	ctx := newTestSigner().sign(t, testRestrictedMethod, nil, time.Now())
+	if _, err := service.verifyRequest(ctx, testRestrictedMethod, nil); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("request from a non-delegate returned %v", err)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !envelopeSignatures.record(md.Get(envelopeSignatureHeader)[0], time.Now()) {
+		t.Error("signature of a refused request was recorded")
+	}
+}
+
+// TestSignatureWindow - signatures are remembered for at least two intervals and then dropped
+func TestSignatureWindow(t *testing.T) {
+	window := newSignatureWindow(envelopeMaxAge)
+	start := time.Unix(0, 0).Add(1000 * envelopeMaxAge)
+	if !window.record("signature", start) {
+		t.Fatal("new signature was refused")
+	}
+	for _, elapsed := range []time.Duration{0, envelopeMaxAge, 2*envelopeMaxAge + envelopeMaxAge/2} {
+		if window.record("signature", start.Add(elapsed)) {
+			t.Errorf("signature replayed after %s was recorded", elapsed)
+		}
+	}
+	if !window.record("signature", start.Add(3*envelopeMaxAge)) {
+		t.Error("signature was not dropped after three intervals")
+	}
+	if len(window.buckets) != 1 {
+		t.Errorf("%d buckets were kept", len(window.buckets))
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcLimits - Protection of public gRPC methods, they share the per client IP rate limit and body size of the public HTTP API.
+// The server accepts large messages for node sync, so the size of a public request is checked once it is read
+type GrpcLimits struct {
+	prefix       string
+	limiter      *rateLimiter
+	maxBodyBytes int64
+}
+
+// NewGrpcLimits - Limits the methods whose full name starts with prefix, a zero limit disables that protection
+func NewGrpcLimits(prefix string, limits *types.HttpLimits) *GrpcLimits {
+	this := &GrpcLimits{prefix: prefix, maxBodyBytes: limits.MaxBodyBytes}
+	if limits.RequestsPerSecond > 0 {
+		this.limiter = newRateLimiter(limits.RequestsPerSecond, limits.Burst)
+	}
+	return this
+}
+
+// UnaryInterceptor
+func (this *GrpcLimits) UnaryInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, this.prefix) {
+		return handler(ctx, request)
+	}
+	err := this.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = this.checkSize(request)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// StreamInterceptor - Opening a stream takes a token, each received message is size checked
+func (this *GrpcLimits) StreamInterceptor(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, this.prefix) {
+		return handler(server, stream)
+	}
+	err := this.allow(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(server, &limitedServerStream{ServerStream: stream, limits: this})
+}
+
+// allow
+func (this *GrpcLimits) allow(ctx context.Context) error {
+	if this.limiter == nil {
+		return nil
+	}
+	ok, retry := this.limiter.allow(getPeerIp(ctx))
+	if !ok {
+		return status.Error(codes.ResourceExhausted, fmt.Sprintf("too many requests, retry in %s", retry))
+	}
+	return nil
+}
+
+// checkSize
+func (this *GrpcLimits) checkSize(message interface{}) error {
+	if this.maxBodyBytes <= 0 {
+		return nil
+	}
+	if message, ok := message.(proto.Message); ok && int64(proto.Size(message)) > this.maxBodyBytes {
+		return status.Error(codes.ResourceExhausted, fmt.Sprintf("request is larger than %d bytes", this.maxBodyBytes))
+	}
+	return nil
+}
+
+// getPeerIp
+func getPeerIp(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// limitedServerStream
+type limitedServerStream struct {
+	grpc.ServerStream
+	limits *GrpcLimits
+}
+
+// RecvMsg
+func (this *limitedServerStream) RecvMsg(message interface{}) error {
+	err := this.ServerStream.RecvMsg(message)
+	if err != nil {
+		return err
+	}
+	return this.limits.checkSize(message)
+}
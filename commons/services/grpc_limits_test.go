@@ -0,0 +1,53 @@
+package services
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	daposProto "github.com/dispatchlabs/disgo/dapos/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// withTestPeer
+func withTestPeer(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1975}})
+}
+
+// TestGrpcLimits - public methods are rate limited per IP and size checked, other methods are not
+func TestGrpcLimits(t *testing.T) {
+	limits := NewGrpcLimits("/proto.ClientGrpc/", &types.HttpLimits{RequestsPerSecond: 0.001, Burst: 2, MaxBodyBytes: 64})
+	handler := func(ctx context.Context, request interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	public := &grpc.UnaryServerInfo{FullMethod: "/proto.ClientGrpc/GetAccountGrpc"}
+	private := &grpc.UnaryServerInfo{FullMethod: "/proto.DAPoSGrpc/GossipGrpc"}
+	small := &daposProto.Account{Address: "address"}
+	large := &daposProto.Account{Address: strings.Repeat("a", 65)}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		info    *grpc.UnaryServerInfo
+		request interface{}
+		code    codes.Code
+	}{
+		{"first", withTestPeer("10.0.0.1"), public, small, codes.OK},
+		{"too large", withTestPeer("10.0.0.2"), public, large, codes.ResourceExhausted},
+		{"second", withTestPeer("10.0.0.1"), public, small, codes.OK},
+		{"rate limited", withTestPeer("10.0.0.1"), public, small, codes.ResourceExhausted},
+		{"other IP", withTestPeer("10.0.0.3"), public, small, codes.OK},
+		{"node method", withTestPeer("10.0.0.1"), private, large, codes.OK},
+	}
+	for _, test := range tests {
+		_, err := limits.UnaryInterceptor(test.ctx, test.request, test.info, handler)
+		if status.Code(err) != test.code {
+			t.Errorf("%s: returned %v, expected %s", test.name, err, test.code)
+		}
+	}
+}
@@ -25,13 +25,9 @@ import (
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"golang.org/x/net/context"
-
-	"github.com/processout/grpc-go-pool"
-	"time"
-	"github.com/pkg/errors"
-	"github.com/patrickmn/go-cache"
 )
 
 var grpcServiceInstance *GrpcService
@@ -41,16 +37,71 @@ var grpcServiceOnce sync.Once
 func GetGrpcService() *GrpcService {
 	grpcServiceOnce.Do(func() {
 		opts := grpc.ServerOption(grpc.MaxRecvMsgSize(1024 * 1024 * 1024))
-		grpcServiceInstance = &GrpcService{Port: int(types.GetConfig().GrpcEndpoint.Port), Server: grpc.NewServer(opts), running: false}
+		grpcServiceInstance = &GrpcService{Port: int(types.GetConfig().GrpcEndpoint.Port), restrictedMethods: map[string][]string{}, running: false}
+		keepalivePolicy := grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: grpcKeepaliveMinTime, PermitWithoutStream: true})
+		options := append([]grpc.ServerOption{opts, keepalivePolicy, grpc.UnaryInterceptor(grpcServiceInstance.interceptUnary), grpc.StreamInterceptor(grpcServiceInstance.interceptStream)}, getGrpcServerOptions()...)
+		grpcServiceInstance.Server = grpc.NewServer(options...)
 	})
 	return grpcServiceInstance
 }
 
 // GrpcService
 type GrpcService struct {
-	Port    int
-	Server  *grpc.Server
-	running bool
+	Port               int
+	Server             *grpc.Server
+	running            bool
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	restrictedMethods  map[string][]string // Node types by full method name
+	interceptorsMutex  sync.RWMutex
+}
+
+// AddUnaryInterceptor - Interceptors run in the order they are added, services add theirs in WithGrpc
+func (this *GrpcService) AddUnaryInterceptor(interceptor grpc.UnaryServerInterceptor) {
+	this.interceptorsMutex.Lock()
+	defer this.interceptorsMutex.Unlock()
+	this.unaryInterceptors = append(this.unaryInterceptors, interceptor)
+}
+
+// AddStreamInterceptor - Interceptors run in the order they are added, services add theirs in WithGrpc
+func (this *GrpcService) AddStreamInterceptor(interceptor grpc.StreamServerInterceptor) {
+	this.interceptorsMutex.Lock()
+	defer this.interceptorsMutex.Unlock()
+	this.streamInterceptors = append(this.streamInterceptors, interceptor)
+}
+
+// interceptUnary - Chains the added unary interceptors
+func (this *GrpcService) interceptUnary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	this.interceptorsMutex.RLock()
+	interceptors := this.unaryInterceptors
+	this.interceptorsMutex.RUnlock()
+	var next func(i int) grpc.UnaryHandler
+	next = func(i int) grpc.UnaryHandler {
+		if i == len(interceptors) {
+			return handler
+		}
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			return interceptors[i](ctx, request, info, next(i+1))
+		}
+	}
+	return next(0)(ctx, request)
+}
+
+// interceptStream - Chains the added stream interceptors
+func (this *GrpcService) interceptStream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	this.interceptorsMutex.RLock()
+	interceptors := this.streamInterceptors
+	this.interceptorsMutex.RUnlock()
+	var next func(i int) grpc.StreamHandler
+	next = func(i int) grpc.StreamHandler {
+		if i == len(interceptors) {
+			return handler
+		}
+		return func(server interface{}, stream grpc.ServerStream) error {
+			return interceptors[i](server, stream, info, next(i+1))
+		}
+	}
+	return next(0)(server, stream)
 }
 
 // IsRunning
@@ -61,6 +112,10 @@ func (this *GrpcService) IsRunning() bool {
 // Go
 func (this *GrpcService) Go() {
 	this.running = true
+
+	// Requests are verified after the interceptors services added, those refuse banned peers before any signature is checked.
+	this.AddUnaryInterceptor(this.verifyUnaryRequest)
+	this.AddStreamInterceptor(this.verifyStreamRequest)
 	listener, error := net.Listen("tcp", ":"+strconv.Itoa(this.Port))
 	if error != nil {
 		utils.Fatal(fmt.Sprintf("failed to listen: %v", error))
@@ -79,43 +134,3 @@ func (this *GrpcService) Go() {
 		this.running = false
 	}
 }
-
-
-func GetGrpcConnection(address string, host string, port int64) (*grpc.ClientConn, error) {
-
-	value, ok := GetCache().Get(fmt.Sprintf("dapos-grpc-pool-%s", address))
-	// IF not found then setup one
-	if !ok {
-		setupConnectionPoolForPeer(address, host, port)
-	}
-	value, ok = GetCache().Get(fmt.Sprintf("dapos-grpc-pool-%s", address))
-	if !ok {
-		return nil, errors.New(fmt.Sprintf("unable to find GRPC pool for this delegate [address=%s]", address))
-	}
-	pool := value.(*grpcpool.Pool)
-	clientConn, err := pool.Get(context.Background())
-	if err != nil {
-		utils.Error("Client connection error ", err)
-		return nil, err
-	}
-	defer clientConn.Close()
-	return clientConn.ClientConn, nil
-
-}
-
-func setupConnectionPoolForPeer(address string, host string, port int64) {
-	factory := func() (*grpc.ClientConn, error) {
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", host, port), grpc.WithInsecure())
-
-		if err != nil {
-			utils.Error("Failed to start gRPC connection: %v", err)
-		}
-		utils.Debug("Connected to node at", host, port)
-		return conn, err
-	}
-	pool, err := grpcpool.New(factory, 5, 5, time.Second* 5)
-	if err != nil {
-		utils.Error(err.Error())
-	}
-	GetCache().Set(fmt.Sprintf("dapos-grpc-pool-%s", address), pool, cache.NoExpiration)
-}
@@ -0,0 +1,188 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var grpcTlsCertificate tls.Certificate
+var grpcTlsError error
+var grpcTlsOnce sync.Once
+
+// IsGrpcTlsEnabled
+func IsGrpcTlsEnabled() bool {
+	return types.GetConfig().GrpcTls != nil && types.GetConfig().GrpcTls.Enabled
+}
+
+// GetGrpcDialOptions - Signs every request, over plaintext or mutual TLS expecting the peer's certificate to be bound to address
+func GetGrpcDialOptions(address string) []grpc.DialOption {
+	return getGrpcDialOptions(address, signUnaryRequest, signStreamRequest)
+}
+
+// getGrpcDialOptions - the interceptors must sign requests
+func getGrpcDialOptions(address string, unary grpc.UnaryClientInterceptor, stream grpc.StreamClientInterceptor) []grpc.DialOption {
+	options := []grpc.DialOption{grpc.WithUnaryInterceptor(unary), grpc.WithStreamInterceptor(stream)}
+	if !IsGrpcTlsEnabled() {
+		return append(options, grpc.WithInsecure())
+	}
+	certificate, err := getGrpcTlsCertificate()
+	if err != nil {
+		utils.Fatal("unable to load gRPC TLS certificate", err)
+	}
+
+	// Peers are identified by the address their self-signed certificate is bound to, not by a certificate authority.
+	return append(options, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		Certificates:          []tls.Certificate{certificate},
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyPeerCertificate(address),
+	})))
+}
+
+// GetPeerAddress - The address a TLS peer's certificate is bound to, empty without TLS
+func GetPeerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return ""
+	}
+	return tlsInfo.State.PeerCertificates[0].Subject.CommonName
+}
+
+// getGrpcServerOptions - Requires peers to present a certificate bound to an address when TLS is enabled
+func getGrpcServerOptions() []grpc.ServerOption {
+	if !IsGrpcTlsEnabled() {
+		return nil
+	}
+	certificate, err := getGrpcTlsCertificate()
+	if err != nil {
+		utils.Fatal("unable to load gRPC TLS certificate", err)
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates:          []tls.Certificate{certificate},
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: verifyPeerCertificate(""),
+	}))}
+}
+
+// verifyPeerCertificate - Any bound address when address is empty
+func verifyPeerCertificate(address string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCertificates [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(rawCertificates) == 0 {
+			return errors.New("peer presented no certificate")
+		}
+		certificate, err := x509.ParseCertificate(rawCertificates[0])
+		if err != nil {
+			return err
+		}
+		boundAddress, err := crypto.ToNodeCertificateAddress(certificate)
+		if err != nil {
+			return err
+		}
+		if address != "" && boundAddress != address {
+			return errors.Errorf("peer certificate is bound to %s, expected %s", boundAddress, address)
+		}
+		return nil
+	}
+}
+
+// getGrpcTlsCertificate - Loads this node's certificate, generating it when missing or bound to another address
+func getGrpcTlsCertificate() (tls.Certificate, error) {
+	grpcTlsOnce.Do(func() {
+		certificateFile, keyFile := getGrpcTlsFiles()
+		if utils.Exists(certificateFile) && utils.Exists(keyFile) {
+			grpcTlsCertificate, grpcTlsError = loadGrpcTlsCertificate(certificateFile, keyFile)
+			if grpcTlsError == nil {
+				return
+			}
+			utils.Warn(fmt.Sprintf("regenerating gRPC TLS certificate %s", certificateFile), grpcTlsError)
+		}
+		privateKey, err := hex.DecodeString(types.GetKey())
+		if err != nil {
+			grpcTlsError = err
+			return
+		}
+		certificatePem, keyPem, err := crypto.NewNodeCertificate(privateKey, types.GetAccount().Address)
+		if err != nil {
+			grpcTlsError = err
+			return
+		}
+		err = ioutil.WriteFile(certificateFile, certificatePem, 0644)
+		if err == nil {
+			err = ioutil.WriteFile(keyFile, keyPem, 0600)
+		}
+		if err != nil {
+			grpcTlsError = err
+			return
+		}
+		utils.Info(fmt.Sprintf("generated gRPC TLS certificate %s [address=%s]", certificateFile, types.GetAccount().Address))
+		grpcTlsCertificate, grpcTlsError = loadGrpcTlsCertificate(certificateFile, keyFile)
+	})
+	return grpcTlsCertificate, grpcTlsError
+}
+
+// loadGrpcTlsCertificate - The certificate must be bound to this node's address
+func loadGrpcTlsCertificate(certificateFile string, keyFile string) (tls.Certificate, error) {
+	certificate, err := tls.LoadX509KeyPair(certificateFile, keyFile)
+	if err != nil {
+		return certificate, err
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return certificate, err
+	}
+	address, err := crypto.ToNodeCertificateAddress(leaf)
+	if err != nil {
+		return certificate, err
+	}
+	if address != types.GetAccount().Address {
+		return certificate, errors.Errorf("certificate is bound to %s, not this node", address)
+	}
+	certificate.Leaf = leaf
+	return certificate, nil
+}
+
+// getGrpcTlsFiles
+func getGrpcTlsFiles() (string, string) {
+	certificateFile := types.GetConfig().GrpcTls.CertificateFile
+	if certificateFile == "" {
+		certificateFile = utils.GetConfigDir() + string(os.PathSeparator) + "grpc.crt"
+	}
+	keyFile := types.GetConfig().GrpcTls.KeyFile
+	if keyFile == "" {
+		keyFile = utils.GetConfigDir() + string(os.PathSeparator) + "grpc.key"
+	}
+	return certificateFile, keyFile
+}
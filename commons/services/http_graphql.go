@@ -0,0 +1,667 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/pkg/errors"
+)
+
+// GraphQL query limits, a query may nest connections so the records it reads are bounded as well as its depth.
+const (
+	defaultGraphQLPageSize = 10
+	maxGraphQLPageSize     = 100
+	maxGraphQLDepth        = 15
+	maxGraphQLReads        = 10000
+)
+
+var graphQLSchema graphql.Schema
+var graphQLSchemaErr error
+var graphQLSchemaOnce sync.Once
+
+// graphQLQuery - Body of a POST, or the query parameters of a GET
+type graphQLQuery struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphQLRequestKey - Context key of the graphQLRequest
+type graphQLRequestKey struct{}
+
+// graphQLRequest - Every resolver of a query reads from the same transaction
+type graphQLRequest struct {
+	txn   *badger.Txn
+	reads int
+}
+
+// read - Counts a record read by a resolver
+func (this *graphQLRequest) read() error {
+	this.reads++
+	if this.reads > maxGraphQLReads {
+		return errors.Errorf("query reads more than %d records", maxGraphQLReads)
+	}
+	return nil
+}
+
+// getGraphQLHandler - Queries are sent as JSON with POST, or as query parameters with GET
+func getGraphQLHandler() http.Handler {
+	return http.HandlerFunc(serveGraphQL)
+}
+
+// serveGraphQL
+func serveGraphQL(responseWriter http.ResponseWriter, request *http.Request) {
+	responseWriter.Header().Set("content-type", "application/json")
+	query := graphQLQuery{}
+	if request.Method == http.MethodGet {
+		query.Query = request.URL.Query().Get("query")
+		query.OperationName = request.URL.Query().Get("operationName")
+		variables := request.URL.Query().Get("variables")
+		if variables != "" {
+			err := json.Unmarshal([]byte(variables), &query.Variables)
+			if err != nil {
+				writeGraphQLError(responseWriter, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	} else {
+		err := json.NewDecoder(request.Body).Decode(&query)
+		if err != nil {
+			writeGraphQLError(responseWriter, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	schema, err := getGraphQLSchema()
+	if err != nil {
+		utils.Error("unable to build GraphQL schema", err)
+		writeGraphQLError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Too deep? Syntax errors are reported by the execution
+	document, err := parser.Parse(parser.ParseParams{Source: query.Query})
+	if err == nil {
+		err = checkGraphQLDepth(document)
+		if err != nil {
+			writeGraphQLError(responseWriter, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	txn := NewTxn(false)
+	defer txn.Discard()
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query.Query,
+		VariableValues: query.Variables,
+		OperationName:  query.OperationName,
+		Context:        context.WithValue(request.Context(), graphQLRequestKey{}, &graphQLRequest{txn: txn}),
+	})
+	payload, err := json.Marshal(result)
+	if err != nil {
+		utils.Error("unable to marshal GraphQL result", err)
+		writeGraphQLError(responseWriter, http.StatusInternalServerError, err.Error())
+		return
+	}
+	responseWriter.Write(payload)
+}
+
+// writeGraphQLError - Errors outside of the execution of a query
+func writeGraphQLError(responseWriter http.ResponseWriter, httpStatus int, message string) {
+	payload, _ := json.Marshal(map[string]interface{}{"errors": []map[string]string{{"message": message}}})
+	responseWriter.WriteHeader(httpStatus)
+	responseWriter.Write(payload)
+}
+
+// checkGraphQLDepth - Fragments are counted where they are spread. Cyclic fragments are refused here since they overflow
+// the stack of the GraphQL validation.
+func checkGraphQLDepth(document *ast.Document) error {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	depths := map[string]int{}
+	for _, definition := range document.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			depth, err := selectionSetDepth(operation.SelectionSet, fragments, depths)
+			if err != nil {
+				return err
+			}
+			if depth > maxGraphQLDepth {
+				return errors.Errorf("query is deeper than %d fields", maxGraphQLDepth)
+			}
+		}
+	}
+	return nil
+}
+
+// selectionSetDepth - depths memoizes fragments, a fragment being measured is -1
+func selectionSetDepth(selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, depths map[string]int) (int, error) {
+	if selectionSet == nil {
+		return 0, nil
+	}
+	depth := 0
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fieldDepth, err := selectionSetDepth(selection.SelectionSet, fragments, depths)
+			if err != nil {
+				return 0, err
+			}
+			depth = maxInt(depth, 1+fieldDepth)
+		case *ast.InlineFragment:
+			fragmentDepth, err := selectionSetDepth(selection.SelectionSet, fragments, depths)
+			if err != nil {
+				return 0, err
+			}
+			depth = maxInt(depth, fragmentDepth)
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			name := selection.Name.Value
+			fragmentDepth, ok := depths[name]
+			if fragmentDepth == -1 {
+				return 0, errors.Errorf("fragment %s spreads itself", name)
+			}
+			if !ok {
+				fragment, found := fragments[name]
+				if !found {
+					continue
+				}
+				depths[name] = -1
+				var err error
+				fragmentDepth, err = selectionSetDepth(fragment.SelectionSet, fragments, depths)
+				if err != nil {
+					return 0, err
+				}
+				depths[name] = fragmentDepth
+			}
+			depth = maxInt(depth, fragmentDepth)
+		}
+	}
+	return depth, nil
+}
+
+// maxInt
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// getGraphQLSchema
+func getGraphQLSchema() (graphql.Schema, error) {
+	graphQLSchemaOnce.Do(func() {
+		graphQLSchema, graphQLSchemaErr = newGraphQLSchema()
+	})
+	return graphQLSchema, graphQLSchemaErr
+}
+
+// newGraphQLSchema - Types refer to each other so their fields are thunks
+func newGraphQLSchema() (graphql.Schema, error) {
+	var accountType, transactionType, receiptType, gossipType, rumorType, nodeType *graphql.Object
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+	endpointType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Endpoint",
+		Fields: graphql.Fields{
+			"host": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"port": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	versionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Version",
+		Fields: graphql.Fields{
+			"version":   &graphql.Field{Type: graphql.String},
+			"buildTime": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	nodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"address":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"type":            &graphql.Field{Type: graphql.String},
+			"status":          &graphql.Field{Type: graphql.String},
+			"statusTime":      &graphql.Field{Type: graphql.DateTime},
+			"grpcEndpoint":    &graphql.Field{Type: endpointType},
+			"httpEndpoint":    &graphql.Field{Type: endpointType},
+			"version":         &graphql.Field{Type: versionType},
+			"protocolVersion": &graphql.Field{Type: graphql.Int},
+			"capabilities":    &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+
+	rumorType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Rumor",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"hash":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"address":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"transactionHash": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"time": &graphql.Field{Type: graphql.DateTime, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return utils.ToTimeFromMilliseconds(p.Source.(*types.Rumor).Time), nil
+				}},
+				"signature": &graphql.Field{Type: graphql.String},
+				"node": &graphql.Field{Type: nodeType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveNode(p, p.Source.(*types.Rumor).Address)
+				}},
+			}
+		}),
+	})
+
+	gossipType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Gossip",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"transaction": &graphql.Field{Type: graphql.NewNonNull(transactionType), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return &p.Source.(*types.Gossip).Transaction, nil
+				}},
+				"rumors": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(rumorType))), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gossip := p.Source.(*types.Gossip)
+					rumors := make([]*types.Rumor, 0, len(gossip.Rumors))
+					for i := range gossip.Rumors {
+						rumors = append(rumors, &gossip.Rumors[i])
+					}
+					return rumors, nil
+				}},
+			}
+		}),
+	})
+
+	receiptType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Receipt",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"transactionHash":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"status":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"humanReadableStatus": &graphql.Field{Type: graphql.String},
+				"contractAddress":     &graphql.Field{Type: graphql.String},
+				"contractResult": &graphql.Field{Type: graphql.String, Description: "JSON array of the values returned by a contract", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					receipt := p.Source.(*types.Receipt)
+					if receipt.ContractResult == nil {
+						return nil, nil
+					}
+					contractResult, err := json.Marshal(receipt.ContractResult)
+					if err != nil {
+						return nil, err
+					}
+					return string(contractResult), nil
+				}},
+				"created": &graphql.Field{Type: graphql.DateTime},
+				"transaction": &graphql.Field{Type: transactionType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveTransaction(p, p.Source.(*types.Receipt).TransactionHash)
+				}},
+			}
+		}),
+	})
+
+	transactionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"hash": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"type": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*types.Transaction).Type), nil
+				}},
+				"from": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"to":   &graphql.Field{Type: graphql.String},
+				"value": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return types.AmountString(p.Source.(*types.Transaction).Value), nil
+				}},
+				"code":   &graphql.Field{Type: graphql.String},
+				"abi":    &graphql.Field{Type: graphql.String},
+				"method": &graphql.Field{Type: graphql.String},
+				"params": &graphql.Field{Type: graphql.String},
+				"time": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return utils.ToTimeFromMilliseconds(p.Source.(*types.Transaction).Time), nil
+				}},
+				"signature": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"hertz": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return strconv.FormatUint(p.Source.(*types.Transaction).Hertz, 10), nil
+				}},
+				"fromName": &graphql.Field{Type: graphql.String},
+				"toName":   &graphql.Field{Type: graphql.String},
+				"fromAccount": &graphql.Field{Type: accountType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveAccount(p, p.Source.(*types.Transaction).From)
+				}},
+				"toAccount": &graphql.Field{Type: accountType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveAccount(p, p.Source.(*types.Transaction).To)
+				}},
+				"receipt": &graphql.Field{Type: receiptType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveReceipt(p, p.Source.(*types.Transaction).Hash)
+				}},
+				"gossip": &graphql.Field{Type: gossipType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveGossip(p, p.Source.(*types.Transaction).Hash)
+				}},
+			}
+		}),
+	})
+	transactionConnectionType := newGraphQLConnection("Transaction", transactionType, pageInfoType)
+
+	accountType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Account",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"address": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"name":    &graphql.Field{Type: graphql.String},
+				"balance": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return types.AmountString(p.Source.(*types.Account).Balance), nil
+				}},
+				"hertzAvailable": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					account := p.Source.(*types.Account)
+					available, err := types.CheckMinimumAvailable(getGraphQLRequest(p).txn, GetCache(), account.Address, account.Balance)
+					if err != nil {
+						return nil, err
+					}
+					return types.AmountString(available), nil
+				}},
+				"transactionHash": &graphql.Field{Type: graphql.String, Description: "Deployment of a smart contract account"},
+				"contract": &graphql.Field{Type: transactionType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					account := p.Source.(*types.Account)
+					if account.TransactionHash == "" {
+						return nil, nil
+					}
+					return resolveTransaction(p, account.TransactionHash)
+				}},
+				"created": &graphql.Field{Type: graphql.DateTime},
+				"updated": &graphql.Field{Type: graphql.DateTime},
+				"sentTransactions": &graphql.Field{Type: graphql.NewNonNull(transactionConnectionType), Args: graphQLConnectionArgs(), Description: "Most recent first", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					prefix := fmt.Sprintf("key-transaction-from-%s-", p.Source.(*types.Account).Address)
+					return resolveGraphQLConnection(p, prefix, true, loadTransactionByKey)
+				}},
+				"receivedTransactions": &graphql.Field{Type: graphql.NewNonNull(transactionConnectionType), Args: graphQLConnectionArgs(), Description: "Most recent first", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					prefix := fmt.Sprintf("key-transaction-to-%s-", p.Source.(*types.Account).Address)
+					return resolveGraphQLConnection(p, prefix, true, loadTransactionByKey)
+				}},
+			}
+		}),
+	})
+
+	hashArgs := graphql.FieldConfigArgument{"hash": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}}
+	addressArgs := graphql.FieldConfigArgument{"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}}
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"account": &graphql.Field{Type: accountType, Args: addressArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveAccount(p, p.Args["address"].(string))
+			}},
+			"accounts": &graphql.Field{Type: graphql.NewNonNull(newGraphQLConnection("Account", accountType, pageInfoType)), Args: graphQLConnectionArgs(), Description: "By address", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveGraphQLConnection(p, "table-account-", false, func(txn *badger.Txn, value []byte) (interface{}, error) {
+					return types.ToAccountFromRecord(value)
+				})
+			}},
+			"transaction": &graphql.Field{Type: transactionType, Args: hashArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveTransaction(p, p.Args["hash"].(string))
+			}},
+			"transactions": &graphql.Field{Type: graphql.NewNonNull(transactionConnectionType), Args: graphQLConnectionArgs(), Description: "Most recent first", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveGraphQLConnection(p, "key-transaction-time-", true, loadTransactionByKey)
+			}},
+			"receipt": &graphql.Field{Type: receiptType, Args: hashArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveReceipt(p, p.Args["hash"].(string))
+			}},
+			"gossip": &graphql.Field{Type: gossipType, Args: hashArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveGossip(p, p.Args["hash"].(string))
+			}},
+			"gossips": &graphql.Field{Type: graphql.NewNonNull(newGraphQLConnection("Gossip", gossipType, pageInfoType)), Args: graphQLConnectionArgs(), Description: "By transaction hash", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveGraphQLConnection(p, "table-gossip-", false, func(txn *badger.Txn, value []byte) (interface{}, error) {
+					return types.ToGossipFromRecord(value)
+				})
+			}},
+			"node": &graphql.Field{Type: nodeType, Args: addressArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveNode(p, p.Args["address"].(string))
+			}},
+			"delegates": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(nodeType))), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				delegates, err := types.ToNodesByTypeFromCache(GetCache(), types.TypeDelegate)
+				if err != nil {
+					return nil, err
+				}
+				sort.Slice(delegates, func(i, j int) bool { return delegates[i].Address < delegates[j].Address })
+				return delegates, nil
+			}},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// newGraphQLConnection - Relay style connection of name
+func newGraphQLConnection(name string, nodeType *graphql.Object, pageInfoType *graphql.Object) *graphql.Object {
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(nodeType)},
+		},
+	})
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Connection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+}
+
+// graphQLConnectionArgs - after is the endCursor of the previous page
+func graphQLConnectionArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultGraphQLPageSize},
+		"after": &graphql.ArgumentConfig{Type: graphql.String},
+	}
+}
+
+// resolveGraphQLConnection - Keyset page of the records under prefix, a cursor is the key of a record so pages do not shift
+// as records are added. load reads the record from the value of a key.
+func resolveGraphQLConnection(p graphql.ResolveParams, prefix string, reverse bool, load func(txn *badger.Txn, value []byte) (interface{}, error)) (interface{}, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 1 || first > maxGraphQLPageSize {
+		return nil, errors.Errorf("first must be between 1 and %d", maxGraphQLPageSize)
+	}
+	var afterKey []byte
+	if after, ok := p.Args["after"].(string); ok && after != "" {
+		var err error
+		afterKey, err = base64.RawURLEncoding.DecodeString(after)
+		if err != nil || !strings.HasPrefix(string(afterKey), prefix) {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+
+	request := getGraphQLRequest(p)
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	opts.Reverse = reverse
+	iterator := request.txn.NewIterator(opts)
+	defer iterator.Close()
+	seek := []byte(prefix)
+	if reverse {
+		seek = append(seek, 0xff)
+	}
+	if afterKey != nil {
+		seek = afterKey
+	}
+	edges := make([]map[string]interface{}, 0, first)
+	hasNextPage := false
+	for iterator.Seek(seek); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+		item := iterator.Item()
+		if afterKey != nil && bytes.Equal(item.Key(), afterKey) {
+			continue
+		}
+		if len(edges) == first {
+			hasNextPage = true
+			break
+		}
+		err := request.read()
+		if err != nil {
+			return nil, err
+		}
+		value, err := item.Value()
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		node, err := load(request.txn, value)
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		edges = append(edges, map[string]interface{}{"cursor": base64.RawURLEncoding.EncodeToString(item.KeyCopy(nil)), "node": node})
+	}
+	pageInfo := map[string]interface{}{"hasNextPage": hasNextPage, "endCursor": nil}
+	if len(edges) > 0 {
+		pageInfo["endCursor"] = edges[len(edges)-1]["cursor"]
+	}
+	return map[string]interface{}{"edges": edges, "pageInfo": pageInfo}, nil
+}
+
+// getGraphQLRequest
+func getGraphQLRequest(p graphql.ResolveParams) *graphQLRequest {
+	return p.Context.Value(graphQLRequestKey{}).(*graphQLRequest)
+}
+
+// loadTransactionByKey - The value of a transaction index key is the key of the transaction
+func loadTransactionByKey(txn *badger.Txn, value []byte) (interface{}, error) {
+	return types.ToTransactionByKey(txn, value)
+}
+
+// resolveAccount - Nil when there is no account at address
+func resolveAccount(p graphql.ResolveParams, address string) (interface{}, error) {
+	request := getGraphQLRequest(p)
+	if address == "" {
+		return nil, nil
+	}
+	err := request.read()
+	if err != nil {
+		return nil, err
+	}
+	account, err := types.ToAccountByAddress(request.txn, address)
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// resolveTransaction - Transactions that are not persisted yet are read from the cache
+func resolveTransaction(p graphql.ResolveParams, hash string) (interface{}, error) {
+	request := getGraphQLRequest(p)
+	err := request.read()
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := types.ToTransactionByHash(request.txn, hash)
+	if err == badger.ErrKeyNotFound {
+		transaction, err = types.ToTransactionFromCache(GetCache(), hash)
+		if err == types.ErrNotFound {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// resolveReceipt - Receipts are cached until they are final
+func resolveReceipt(p graphql.ResolveParams, hash string) (interface{}, error) {
+	request := getGraphQLRequest(p)
+	err := request.read()
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := types.ToReceiptFromCache(GetCache(), hash)
+	if err == nil {
+		return receipt, nil
+	}
+	receipt, err = types.ToReceiptFromKey(request.txn, []byte(fmt.Sprintf("table-receipt-%s", hash)))
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// resolveGossip - Gossips are cached while they are gathering rumors
+func resolveGossip(p graphql.ResolveParams, hash string) (interface{}, error) {
+	request := getGraphQLRequest(p)
+	err := request.read()
+	if err != nil {
+		return nil, err
+	}
+	gossip, err := types.ToGossipFromCache(GetCache(), hash)
+	if err == nil {
+		return gossip, nil
+	}
+	gossip, err = types.ToGossipByTransactionHash(request.txn, hash)
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gossip, nil
+}
+
+// resolveNode - Nodes known to this node
+func resolveNode(p graphql.ResolveParams, address string) (interface{}, error) {
+	request := getGraphQLRequest(p)
+	err := request.read()
+	if err != nil {
+		return nil, err
+	}
+	node, err := types.ToNodeFromCache(GetCache(), address)
+	if err == nil {
+		return node, nil
+	}
+	node, err = types.ToNodeByAddress(request.txn, address)
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
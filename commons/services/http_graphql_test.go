@@ -0,0 +1,212 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// testGraphQLResult
+type testGraphQLResult struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// testGraphQLConnection
+type testGraphQLConnection struct {
+	Edges []struct {
+		Cursor string          `json:"cursor"`
+		Node   json.RawMessage `json:"node"`
+	} `json:"edges"`
+	PageInfo struct {
+		HasNextPage bool    `json:"hasNextPage"`
+		EndCursor   *string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// postGraphQL - returns the HTTP status and the result
+func postGraphQL(t *testing.T, query string, variables map[string]interface{}) (int, testGraphQLResult) {
+	body, err := json.Marshal(graphQLQuery{Query: query, Variables: variables})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	serveGraphQL(recorder, httptest.NewRequest("POST", "/v1/graphql", strings.NewReader(string(body))))
+	result := testGraphQLResult{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("%s: %v", recorder.Body.String(), err)
+	}
+	return recorder.Code, result
+}
+
+// nestedGraphQLQuery - a query depth fields deep
+func nestedGraphQLQuery(depth int) string {
+	return strings.Repeat("{ a ", depth) + strings.Repeat("}", depth)
+}
+
+// TestCheckGraphQLDepth
+func TestCheckGraphQLDepth(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		ok    bool
+	}{
+		{"at the limit", nestedGraphQLQuery(maxGraphQLDepth), true},
+		{"too deep", nestedGraphQLQuery(maxGraphQLDepth + 1), false},
+		{"inline fragment", "{ ... on Query " + nestedGraphQLQuery(maxGraphQLDepth) + " }", true},
+		{"fragment counted where spread", "{ a { ...F } } fragment F on A " + nestedGraphQLQuery(maxGraphQLDepth), false},
+		{"fragment spread twice", "{ a { ...F } b { ...F } } fragment F on A { c }", true},
+		{"unknown fragment", "{ a { ...F } }", true},
+		{"fragment spreading itself", "{ ...F } fragment F on Query { a { ...F } }", false},
+		{"fragment cycle", "{ ...F } fragment F on Query { a { ...G } } fragment G on A { b { ...F } }", false},
+	}
+	for _, test := range tests {
+		document, err := parser.Parse(parser.ParseParams{Source: test.query})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if err = checkGraphQLDepth(document); (err == nil) != test.ok {
+			t.Errorf("%s: returned %v", test.name, err)
+		}
+	}
+}
+
+// TestServeGraphQLRefused - queries that are too deep or cyclic are refused before they are validated
+func TestServeGraphQLRefused(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"too deep", "{ transaction(hash: \"h\") " + strings.Repeat("{ receipt { transaction ", 8) + "{ hash }" + strings.Repeat("} }", 8) + " }"},
+		{"fragment cycle", "{ ...F } fragment F on Query { transaction(hash: \"h\") { ...G } } fragment G on Transaction { receipt { transaction { ...G } } }"},
+	}
+	for _, test := range tests {
+		code, result := postGraphQL(t, test.query, nil)
+		if code != http.StatusBadRequest || len(result.Errors) != 1 {
+			t.Errorf("%s: returned %d %v", test.name, code, result.Errors)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	serveGraphQL(recorder, httptest.NewRequest("GET", "/v1/graphql?query="+url.QueryEscape("{ delegates { address } }")+"&variables=%7B", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("invalid variables returned %d", recorder.Code)
+	}
+}
+
+// TestGraphQLFirst
+func TestGraphQLFirst(t *testing.T) {
+	withEmptyTestDb(t, func() {
+		tests := []struct {
+			first int
+			ok    bool
+		}{
+			{0, false},
+			{-1, false},
+			{1, true},
+			{maxGraphQLPageSize, true},
+			{maxGraphQLPageSize + 1, false},
+		}
+		for _, test := range tests {
+			code, result := postGraphQL(t, "query($first: Int) { accounts(first: $first) { edges { cursor } } }", map[string]interface{}{"first": test.first})
+			if code != http.StatusOK || (len(result.Errors) == 0) != test.ok {
+				t.Errorf("first %d returned %d %v", test.first, code, result.Errors)
+			}
+		}
+	})
+}
+
+// pageGraphQL - follows endCursor from the first page, returns the nodes field of each edge
+func pageGraphQL(t *testing.T, query string, connection func(data map[string]json.RawMessage) json.RawMessage, field string, first int) [][]string {
+	var pages [][]string
+	var after interface{}
+	for {
+		_, result := postGraphQL(t, query, map[string]interface{}{"first": first, "after": after})
+		if len(result.Errors) > 0 {
+			t.Fatal(result.Errors)
+		}
+		page := testGraphQLConnection{}
+		if err := json.Unmarshal(connection(result.Data), &page); err != nil {
+			t.Fatal(err)
+		}
+		var nodes []string
+		for _, edge := range page.Edges {
+			node := map[string]interface{}{}
+			json.Unmarshal(edge.Node, &node)
+			nodes = append(nodes, fmt.Sprint(node[field]))
+		}
+		pages = append(pages, nodes)
+		if !page.PageInfo.HasNextPage {
+			return pages
+		}
+		if page.PageInfo.EndCursor == nil || len(pages) > 10 {
+			t.Fatal("paging does not end")
+		}
+		after = *page.PageInfo.EndCursor
+	}
+}
+
+// TestGraphQLPaging - pages are in key order, forwards by address and most recent first by time
+func TestGraphQLPaging(t *testing.T) {
+	withEmptyTestDb(t, func() {
+		txn := NewTxn(true)
+		defer txn.Discard()
+		for i := 0; i < 5; i++ {
+			account := &types.Account{Address: fmt.Sprintf("account-%d", i), Balance: big.NewInt(int64(i)), HertzAvailable: big.NewInt(0)}
+			if err := account.Persist(txn); err != nil {
+				t.Fatal(err)
+			}
+			transaction := &types.Transaction{Hash: fmt.Sprintf("hash-%d", i), From: "account-0", To: "account-1", Value: big.NewInt(1), Time: int64(1000 + i)}
+			if err := transaction.Persist(txn); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := txn.Commit(nil); err != nil {
+			t.Fatal(err)
+		}
+
+		accounts := pageGraphQL(t, "query($first: Int, $after: String) { accounts(first: $first, after: $after) { edges { cursor node { address } } pageInfo { hasNextPage endCursor } } }", func(data map[string]json.RawMessage) json.RawMessage {
+			return data["accounts"]
+		}, "address", 2)
+		if fmt.Sprint(accounts) != "[[account-0 account-1] [account-2 account-3] [account-4]]" {
+			t.Errorf("accounts paged as %v", accounts)
+		}
+
+		sent := pageGraphQL(t, "query($first: Int, $after: String) { account(address: \"account-0\") { sentTransactions(first: $first, after: $after) { edges { cursor node { hash } } pageInfo { hasNextPage endCursor } } } }", func(data map[string]json.RawMessage) json.RawMessage {
+			account := map[string]json.RawMessage{}
+			json.Unmarshal(data["account"], &account)
+			return account["sentTransactions"]
+		}, "hash", 3)
+		if fmt.Sprint(sent) != "[[hash-4 hash-3 hash-2] [hash-1 hash-0]]" {
+			t.Errorf("sent transactions paged as %v", sent)
+		}
+
+		otherCursor := base64.RawURLEncoding.EncodeToString([]byte("key-transaction-time-"))
+		_, result := postGraphQL(t, "query($after: String) { accounts(after: $after) { edges { cursor } } }", map[string]interface{}{"after": otherCursor})
+		if len(result.Errors) != 1 || result.Errors[0].Message != "invalid cursor" {
+			t.Errorf("cursor of another connection returned %v", result.Errors)
+		}
+	})
+}
+
+// TestGraphQLReads - a query reading more records than maxGraphQLReads fails
+func TestGraphQLReads(t *testing.T) {
+	request := &graphQLRequest{reads: maxGraphQLReads - 1}
+	if err := request.read(); err != nil {
+		t.Fatal(err)
+	}
+	if err := request.read(); err == nil {
+		t.Error("read over the limit succeeded")
+	}
+}
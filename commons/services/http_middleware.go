@@ -0,0 +1,198 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/rs/cors"
+)
+
+// tokenBucketIdle - buckets of clients that have not called for this long are dropped
+const tokenBucketIdle = 10 * time.Minute
+
+// tokenBucket
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter - A token bucket per client IP
+type rateLimiter struct {
+	rate    float64
+	burst   float64
+	buckets map[string]*tokenBucket
+	swept   time.Time
+	mutex   sync.Mutex
+}
+
+// newRateLimiter
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		swept:   time.Now(),
+	}
+}
+
+// allow - Takes a token from the client's bucket, otherwise returns how long until one is available
+func (this *rateLimiter) allow(client string) (bool, time.Duration) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	now := time.Now()
+	if now.Sub(this.swept) > tokenBucketIdle {
+		for key, bucket := range this.buckets {
+			if now.Sub(bucket.updated) > tokenBucketIdle {
+				delete(this.buckets, key)
+			}
+		}
+		this.swept = now
+	}
+	bucket, ok := this.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: this.burst, updated: now}
+		this.buckets[client] = bucket
+	}
+	bucket.tokens = math.Min(this.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*this.rate)
+	bucket.updated = now
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / this.rate * float64(time.Second))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// withPublicProtection - Wraps the public API handler per the configured limits, outermost first: CORS, rate limit, body
+// size, timeout and panic recovery
+func withPublicProtection(handler http.Handler, limits *types.HttpLimits) http.Handler {
+	handler = withRecovery(handler)
+	if limits.TimeoutSeconds > 0 {
+		handler = withTimeout(handler, time.Duration(limits.TimeoutSeconds)*time.Second)
+	}
+	if limits.MaxBodyBytes > 0 {
+		handler = withMaxBody(handler, limits.MaxBodyBytes)
+	}
+	if limits.RequestsPerSecond > 0 {
+		handler = withRateLimit(handler, newRateLimiter(limits.RequestsPerSecond, limits.Burst))
+	}
+	return withCors(handler, limits.AllowedOrigins)
+}
+
+// withCors - Credentials are only allowed when the origins are listed, browsers refuse them with a wildcard
+func withCors(handler http.Handler, origins []string) http.Handler {
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+	credentials := true
+	for _, origin := range origins {
+		if origin == "*" {
+			credentials = false
+		}
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins:   origins,
+		AllowCredentials: credentials,
+	}).Handler(handler)
+}
+
+// withRateLimit
+func withRateLimit(handler http.Handler, limiter *rateLimiter) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		client := getClientIp(request)
+		ok, retry := limiter.allow(client)
+		if !ok {
+			seconds := int(math.Ceil(retry.Seconds()))
+			responseWriter.Header().Set("Retry-After", strconv.Itoa(seconds))
+			utils.Debug(fmt.Sprintf("rate limited HTTP request [client=%s, url=%s]", client, request.URL.Path))
+			writeError(responseWriter, http.StatusTooManyRequests, types.StatusTooManyRequests, fmt.Sprintf("Too many requests, retry in %d seconds.", seconds))
+			return
+		}
+		handler.ServeHTTP(responseWriter, request)
+	})
+}
+
+// withMaxBody - Declared lengths are rejected up front, otherwise reading past the limit fails
+func withMaxBody(handler http.Handler, maxBytes int64) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.ContentLength > maxBytes {
+			writeError(responseWriter, http.StatusRequestEntityTooLarge, types.StatusRequestTooLarge, fmt.Sprintf("Request body exceeds %d bytes.", maxBytes))
+			return
+		}
+		if request.Body != nil {
+			request.Body = http.MaxBytesReader(responseWriter, request.Body, maxBytes)
+		}
+		handler.ServeHTTP(responseWriter, request)
+	})
+}
+
+// withTimeout - Subscriptions are long lived WebSockets that need the underlying connection, so only that route is not timed out
+func withTimeout(handler http.Handler, timeout time.Duration) http.Handler {
+	timeoutHandler := http.TimeoutHandler(handler, timeout, types.NewResponseWithStatus(types.StatusRequestTimedOut, "The request timed out.").String())
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == subscriptionPath && strings.EqualFold(request.Header.Get("Upgrade"), "websocket") {
+			handler.ServeHTTP(responseWriter, request)
+			return
+		}
+		timeoutHandler.ServeHTTP(responseWriter, request)
+	})
+}
+
+// withRecovery - A panicking handler answers StatusInternalError instead of dropping the connection
+func withRecovery(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		defer func() {
+			if r := recover(); r != nil {
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				utils.Error(fmt.Sprintf("panic serving HTTP [url=%s, error=%v]\n%s", request.URL.Path, r, debug.Stack()))
+				writeError(responseWriter, http.StatusInternalServerError, types.StatusInternalError, "An internal error occurred.")
+			}
+		}()
+		handler.ServeHTTP(responseWriter, request)
+	})
+}
+
+// writeError
+func writeError(responseWriter http.ResponseWriter, code int, status string, humanReadableStatus string) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(code)
+	responseWriter.Write([]byte(types.NewResponseWithStatus(status, humanReadableStatus).String()))
+}
+
+// getClientIp - The connection's address, forwarding headers can be set by the client so they are not trusted
+func getClientIp(request *http.Request) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return request.RemoteAddr
+	}
+	return host
+}
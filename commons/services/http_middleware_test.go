@@ -0,0 +1,185 @@
+package services
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// serveTest
+func serveTest(handler http.Handler, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// checkError
+func checkError(t *testing.T, name string, recorder *httptest.ResponseRecorder, code int, status string) {
+	if recorder.Code != code {
+		t.Errorf("%s: returned %d, expected %d", name, recorder.Code, code)
+	}
+	if !strings.Contains(recorder.Body.String(), status) {
+		t.Errorf("%s: body %q does not contain %s", name, recorder.Body.String(), status)
+	}
+}
+
+// TestWithRateLimit
+func TestWithRateLimit(t *testing.T) {
+	ok := http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {})
+	handler := withPublicProtection(ok, &types.HttpLimits{RequestsPerSecond: 1, Burst: 2})
+	request := func(remoteAddr string) *http.Request {
+		request := httptest.NewRequest("GET", "/v1/test", nil)
+		request.RemoteAddr = remoteAddr
+		request.Header.Set("X-Forwarded-For", "10.0.0.9")
+		return request
+	}
+
+	for i := 0; i < 2; i++ {
+		if recorder := serveTest(handler, request("10.0.0.1:1000")); recorder.Code != http.StatusOK {
+			t.Fatalf("request %d within the burst returned %d", i, recorder.Code)
+		}
+	}
+	recorder := serveTest(handler, request("10.0.0.1:1001"))
+	checkError(t, "over the burst", recorder, http.StatusTooManyRequests, types.StatusTooManyRequests)
+	if recorder.Header().Get("Retry-After") != "1" {
+		t.Errorf("Retry-After is %q, expected 1", recorder.Header().Get("Retry-After"))
+	}
+	if recorder := serveTest(handler, request("10.0.0.2:1000")); recorder.Code != http.StatusOK {
+		t.Errorf("other client returned %d, expected %d", recorder.Code, http.StatusOK)
+	}
+}
+
+// TestRateLimiterRefill
+func TestRateLimiterRefill(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+	if ok, _ := limiter.allow("client"); !ok {
+		t.Fatal("first request was limited")
+	}
+	if ok, retry := limiter.allow("client"); ok || retry <= 0 || retry > time.Second {
+		t.Fatalf("second request allowed %t, retry in %s", ok, retry)
+	}
+	limiter.buckets["client"].updated = time.Now().Add(-time.Second)
+	if ok, _ := limiter.allow("client"); !ok {
+		t.Error("request was limited after the bucket refilled")
+	}
+
+	limiter.buckets["idle"] = &tokenBucket{updated: time.Now().Add(-2 * tokenBucketIdle)}
+	limiter.swept = time.Now().Add(-2 * tokenBucketIdle)
+	limiter.allow("client")
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Error("idle bucket was not dropped")
+	}
+}
+
+// TestWithMaxBody
+func TestWithMaxBody(t *testing.T) {
+	var readErr error
+	read := http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		_, readErr = ioutil.ReadAll(request.Body)
+	})
+	handler := withPublicProtection(read, &types.HttpLimits{MaxBodyBytes: 8})
+
+	recorder := serveTest(handler, httptest.NewRequest("POST", "/v1/test", strings.NewReader("0123456789")))
+	checkError(t, "declared length over the limit", recorder, http.StatusRequestEntityTooLarge, types.StatusRequestTooLarge)
+
+	request := httptest.NewRequest("POST", "/v1/test", ioutil.NopCloser(strings.NewReader("0123456789")))
+	request.ContentLength = -1
+	serveTest(handler, request)
+	if readErr == nil {
+		t.Error("reading an undeclared body over the limit succeeded")
+	}
+
+	request = httptest.NewRequest("POST", "/v1/test", strings.NewReader("01234567"))
+	if recorder := serveTest(handler, request); recorder.Code != http.StatusOK || readErr != nil {
+		t.Errorf("body within the limit returned %d, read error %v", recorder.Code, readErr)
+	}
+}
+
+// TestWithTimeout
+func TestWithTimeout(t *testing.T) {
+	slow := http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		if _, ok := responseWriter.(http.Hijacker); !ok {
+			responseWriter.WriteHeader(http.StatusNotImplemented)
+		}
+	})
+	handler := withTimeout(slow, 10*time.Millisecond)
+
+	recorder := serveTest(handler, httptest.NewRequest("GET", "/v1/test", nil))
+	checkError(t, "slow request", recorder, http.StatusServiceUnavailable, types.StatusRequestTimedOut)
+
+	// Only the subscription route is exempt, an upgrade header elsewhere is timed out.
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{subscriptionPath, http.StatusOK},
+		{"/v1/test", http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		request, _ := http.NewRequest("GET", server.URL+test.path, nil)
+		request.Header.Set("Connection", "Upgrade")
+		request.Header.Set("Upgrade", "websocket")
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != test.expected {
+			t.Errorf("%s: WebSocket upgrade returned %d, expected %d", test.path, response.StatusCode, test.expected)
+		}
+	}
+}
+
+// TestWithRecovery
+func TestWithRecovery(t *testing.T) {
+	handler := withRecovery(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		panic("test")
+	}))
+	recorder := serveTest(handler, httptest.NewRequest("GET", "/v1/test", nil))
+	checkError(t, "panicking handler", recorder, http.StatusInternalServerError, types.StatusInternalError)
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recovered %v, expected http.ErrAbortHandler to be passed on", r)
+		}
+	}()
+	withRecovery(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		panic(http.ErrAbortHandler)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/test", nil))
+}
+
+// TestWithCors
+func TestWithCors(t *testing.T) {
+	ok := http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {})
+	tests := []struct {
+		name        string
+		origins     []string
+		origin      string
+		allowed     string
+		credentials string
+	}{
+		{"listed origin", []string{"https://wallet.example"}, "https://wallet.example", "https://wallet.example", "true"},
+		{"unlisted origin", []string{"https://wallet.example"}, "https://other.example", "", ""},
+		{"wildcard", []string{"*"}, "https://other.example", "*", ""},
+		{"default", nil, "https://other.example", "*", ""},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest("GET", "/v1/test", nil)
+		request.Header.Set("Origin", test.origin)
+		recorder := serveTest(withPublicProtection(ok, &types.HttpLimits{AllowedOrigins: test.origins}), request)
+		if allowed := recorder.Header().Get("Access-Control-Allow-Origin"); allowed != test.allowed {
+			t.Errorf("%s: Access-Control-Allow-Origin is %q, expected %q", test.name, allowed, test.allowed)
+		}
+		if credentials := recorder.Header().Get("Access-Control-Allow-Credentials"); credentials != test.credentials {
+			t.Errorf("%s: Access-Control-Allow-Credentials is %q, expected %q", test.name, credentials, test.credentials)
+		}
+	}
+}
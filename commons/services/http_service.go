@@ -19,6 +19,7 @@ package services
 import (
 	"net/http"
 	"sync"
+	"time"
 
 	"fmt"
 
@@ -39,7 +40,9 @@ func GetHttpService() *HttpService {
 			PrivateApiPort:    types.GetConfig().LocalHttpApiPort,
 			running:           false,
 			router:            mux.NewRouter(),
+			localRouter:       mux.NewRouter(),
 		}
+		httpServiceInstance.localRouter.NotFoundHandler = httpServiceInstance.router
 	})
 	return httpServiceInstance
 }
@@ -49,12 +52,18 @@ func GetHttpRouter() *mux.Router {
 	return GetHttpService().router
 }
 
+// GetLocalHttpRouter - Routes only served on 127.0.0.1:LocalHttpApiPort, the public routes are served there too
+func GetLocalHttpRouter() *mux.Router {
+	return GetHttpService().localRouter
+}
+
 // HttpService
 type HttpService struct {
 	PublicApiEndpoint types.Endpoint
 	PrivateApiPort    int
 	running           bool
 	router            *mux.Router
+	localRouter       *mux.Router
 }
 
 // IsRunning
@@ -69,17 +78,21 @@ func (this *HttpService) Go() {
 	wg.Add(1)
 	wg.Add(1)
 
+	this.router.Handle(subscriptionPath, getSubscriptionHandler()).Methods("GET")
+	this.router.Handle("/v1/graphql", getGraphQLHandler()).Methods("GET", "POST")
+
 	go func() {
 		this.running = true
 		listen := fmt.Sprintf("%s:%d", "", this.PublicApiEndpoint.Port) // FIX: commented "this.Endpoint.Host" for prod release
 		utils.Info("listening on http://" + listen)
-		cors := cors.New(cors.Options{
-			AllowedOrigins:   []string{"*"},
-			AllowCredentials: true,
-		})
-		handler := cors.Handler(this.router)
+		server := &http.Server{
+			Addr:              listen,
+			Handler:           withPublicProtection(this.router, types.GetConfig().HttpLimits),
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+		}
 
-		err := http.ListenAndServe(listen, handler)
+		err := server.ListenAndServe()
 
 		// QUESTION: this line is never reached
 		utils.Error("unable to listen/serve HTTP [error=" + err.Error() + "]")
@@ -94,7 +107,7 @@ func (this *HttpService) Go() {
 			AllowedOrigins:   []string{"*"},
 			AllowCredentials: true,
 		})
-		handler := cors.Handler(this.router)
+		handler := cors.Handler(withRecovery(this.localRouter))
 
 		err := http.ListenAndServe(listen, handler)
 
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalHttpRouter(t *testing.T) {
+	ok := func(responseWriter http.ResponseWriter, request *http.Request) {}
+	GetLocalHttpRouter().HandleFunc("/v1/local/test", ok).Methods("GET")
+	GetHttpRouter().HandleFunc("/v1/test", ok).Methods("GET")
+
+	tests := []struct {
+		handler http.Handler
+		path    string
+		code    int
+	}{
+		{GetHttpRouter(), "/v1/local/test", http.StatusNotFound},
+		{GetHttpRouter(), "/v1/test", http.StatusOK},
+		{GetLocalHttpRouter(), "/v1/local/test", http.StatusOK},
+		{GetLocalHttpRouter(), "/v1/test", http.StatusOK},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		test.handler.ServeHTTP(recorder, httptest.NewRequest("GET", test.path, nil))
+		if recorder.Code != test.code {
+			t.Errorf("GET %s returned %d, expected %d", test.path, recorder.Code, test.code)
+		}
+	}
+}
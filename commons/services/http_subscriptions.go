@@ -0,0 +1,306 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"golang.org/x/net/websocket"
+)
+
+// Topics clients subscribe to over the WebSocket endpoint.
+const (
+	subscriptionPath    = "/v1/subscribe"
+	TransactionsTopic   = "transactions" // Every transaction this delegate executes
+	receiptTopicPrefix  = "receipt/"
+	addressTopicPrefix  = "address/"
+	maxTopicLength      = 128
+	maxSubscriptions    = 100
+	subscriberQueueSize = 256
+	subscriberMaxFrame  = 4096
+	subscriberWriteWait = 10 * time.Second
+)
+
+// Subscription event types.
+const (
+	ReceiptEvent     = "receipt"
+	TransactionEvent = "transaction"
+)
+
+var subscriptions = &subscriptionHub{topics: map[string]map[*subscriber]bool{}}
+
+// ReceiptTopic - Status changes of a transaction's receipt
+func ReceiptTopic(hash string) string {
+	return receiptTopicPrefix + hash
+}
+
+// AddressTopic - Executed transactions sent from or to an address
+func AddressTopic(address string) string {
+	return addressTopicPrefix + address
+}
+
+// SubscriptionRequest - Sent by clients, action is subscribe or unsubscribe
+type SubscriptionRequest struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+}
+
+// SubscriptionEvent - Pushed to the clients subscribed to topic
+type SubscriptionEvent struct {
+	Topic   string          `json:"topic"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data"`
+	Created time.Time       `json:"created"`
+}
+
+// subscriber - A WebSocket connection, events are queued and dropped connections are unsubscribed
+type subscriber struct {
+	connection *websocket.Conn
+	queue      chan []byte
+	topics     map[string]bool
+	closeOnce  sync.Once
+	done       chan struct{}
+}
+
+// subscriptionHub
+type subscriptionHub struct {
+	topics map[string]map[*subscriber]bool
+	mutex  sync.RWMutex
+}
+
+// PublishSubscriptionEvent - Pushes data to the clients subscribed to topic, it is only marshalled when there are any
+func PublishSubscriptionEvent(topic string, eventType string, data interface{}) {
+	subscriptions.mutex.RLock()
+	subscribers := make([]*subscriber, 0, len(subscriptions.topics[topic]))
+	for client := range subscriptions.topics[topic] {
+		subscribers = append(subscribers, client)
+	}
+	subscriptions.mutex.RUnlock()
+	if len(subscribers) == 0 {
+		return
+	}
+	bytes, err := newSubscriptionEvent(topic, eventType, data)
+	if err != nil {
+		utils.Error(fmt.Sprintf("unable to marshal %s event [topic=%s]", eventType, topic), err)
+		return
+	}
+	for _, client := range subscribers {
+		client.send(bytes)
+	}
+}
+
+// newSubscriptionEvent
+func newSubscriptionEvent(topic string, eventType string, data interface{}) ([]byte, error) {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(SubscriptionEvent{Topic: topic, Type: eventType, Data: bytes, Created: time.Now()})
+}
+
+// subscribe
+func (this *subscriptionHub) subscribe(client *subscriber, topic string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.topics[topic] == nil {
+		this.topics[topic] = map[*subscriber]bool{}
+	}
+	this.topics[topic][client] = true
+	client.topics[topic] = true
+}
+
+// unsubscribe
+func (this *subscriptionHub) unsubscribe(client *subscriber, topic string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	delete(this.topics[topic], client)
+	if len(this.topics[topic]) == 0 {
+		delete(this.topics, topic)
+	}
+	delete(client.topics, topic)
+}
+
+// unsubscribeAll
+func (this *subscriptionHub) unsubscribeAll(client *subscriber) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	for topic := range client.topics {
+		delete(this.topics[topic], client)
+		if len(this.topics[topic]) == 0 {
+			delete(this.topics, topic)
+		}
+	}
+	client.topics = map[string]bool{}
+}
+
+// getSubscriptionHandler - WebSocket endpoint, browsers may only connect from the allowed CORS origins
+func getSubscriptionHandler() http.Handler {
+	return websocket.Server{
+		Handshake: checkSubscriptionOrigin,
+		Handler:   serveSubscriber,
+	}
+}
+
+// checkSubscriptionOrigin - Clients other than browsers send no origin
+func checkSubscriptionOrigin(config *websocket.Config, request *http.Request) error {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	for _, allowed := range types.GetConfig().HttpLimits.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
+
+// serveSubscriber - Reads subscription requests until the client disconnects
+func serveSubscriber(connection *websocket.Conn) {
+	connection.MaxPayloadBytes = subscriberMaxFrame
+	client := &subscriber{
+		connection: connection,
+		queue:      make(chan []byte, subscriberQueueSize),
+		topics:     map[string]bool{},
+		done:       make(chan struct{}),
+	}
+	defer client.close()
+	go client.write()
+
+	for {
+		request := SubscriptionRequest{}
+		err := websocket.JSON.Receive(connection, &request)
+		if err != nil {
+			if isJsonError(err) {
+				client.reply(types.NewResponseWithStatus(types.StatusJsonParseError, err.Error()))
+				continue
+			}
+			return
+		}
+		client.handle(request)
+	}
+}
+
+// handle
+func (this *subscriber) handle(request SubscriptionRequest) {
+	if !isValidTopic(request.Topic) {
+		this.reply(types.NewResponseWithStatus(types.StatusInvalidSubscription, fmt.Sprintf("unknown topic %s", request.Topic)))
+		return
+	}
+	switch request.Action {
+	case "subscribe":
+		subscriptions.mutex.RLock()
+		count := len(this.topics)
+		subscriptions.mutex.RUnlock()
+		if count >= maxSubscriptions {
+			this.reply(types.NewResponseWithStatus(types.StatusInvalidSubscription, fmt.Sprintf("at most %d topics may be subscribed", maxSubscriptions)))
+			return
+		}
+		subscriptions.subscribe(this, request.Topic)
+		this.reply(types.NewResponseWithStatus(types.StatusOk, "subscribed "+request.Topic))
+
+		// The receipt may have changed before the client subscribed.
+		if strings.HasPrefix(request.Topic, receiptTopicPrefix) {
+			receipt, err := types.ToReceiptFromCache(GetCache(), strings.TrimPrefix(request.Topic, receiptTopicPrefix))
+			if err == nil {
+				bytes, err := newSubscriptionEvent(request.Topic, ReceiptEvent, receipt)
+				if err == nil {
+					this.send(bytes)
+				}
+			}
+		}
+	case "unsubscribe":
+		subscriptions.unsubscribe(this, request.Topic)
+		this.reply(types.NewResponseWithStatus(types.StatusOk, "unsubscribed "+request.Topic))
+	default:
+		this.reply(types.NewResponseWithStatus(types.StatusInvalidSubscription, fmt.Sprintf("unknown action %s", request.Action)))
+	}
+}
+
+// reply
+func (this *subscriber) reply(response *types.Response) {
+	this.send([]byte(response.String()))
+}
+
+// send - A client that does not keep up with its events is disconnected
+func (this *subscriber) send(bytes []byte) {
+	select {
+	case <-this.done:
+	case this.queue <- bytes:
+	default:
+		utils.Warn(fmt.Sprintf("dropping slow WebSocket subscriber %s", this.connection.Request().RemoteAddr))
+		this.close()
+	}
+}
+
+// write
+func (this *subscriber) write() {
+	for {
+		select {
+		case <-this.done:
+			return
+		case bytes := <-this.queue:
+			this.connection.SetWriteDeadline(time.Now().Add(subscriberWriteWait))
+			err := websocket.Message.Send(this.connection, string(bytes))
+			if err != nil {
+				this.close()
+				return
+			}
+		}
+	}
+}
+
+// close
+func (this *subscriber) close() {
+	this.closeOnce.Do(func() {
+		subscriptions.unsubscribeAll(this)
+		close(this.done)
+		this.connection.Close()
+	})
+}
+
+// isValidTopic
+func isValidTopic(topic string) bool {
+	if topic == TransactionsTopic {
+		return true
+	}
+	if len(topic) > maxTopicLength {
+		return false
+	}
+	for _, prefix := range []string{receiptTopicPrefix, addressTopicPrefix} {
+		if strings.HasPrefix(topic, prefix) && len(topic) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJsonError - The frame was read but is not a subscription request
+func isJsonError(err error) bool {
+	switch err.(type) {
+	case *json.SyntaxError, *json.UnmarshalTypeError:
+		return true
+	}
+	return false
+}
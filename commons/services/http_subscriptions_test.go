@@ -0,0 +1,226 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"golang.org/x/net/websocket"
+)
+
+// testSubscriptionMessage - a reply or an event
+type testSubscriptionMessage struct {
+	Status string          `json:"status"`
+	Topic  string          `json:"topic"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// dialTestSubscriptions
+func dialTestSubscriptions(t *testing.T) (*websocket.Conn, func()) {
+	server := httptest.NewServer(getSubscriptionHandler())
+	connection, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return connection, func() {
+		connection.Close()
+		server.Close()
+	}
+}
+
+// sendSubscriptionRequest - sends a request and returns the reply
+func sendSubscriptionRequest(t *testing.T, connection *websocket.Conn, message string) testSubscriptionMessage {
+	if err := websocket.Message.Send(connection, message); err != nil {
+		t.Fatal(err)
+	}
+	return receiveSubscriptionMessage(t, connection)
+}
+
+// receiveSubscriptionMessage
+func receiveSubscriptionMessage(t *testing.T, connection *websocket.Conn) testSubscriptionMessage {
+	connection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	message := testSubscriptionMessage{}
+	if err := websocket.JSON.Receive(connection, &message); err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+// subscribeRequest
+func subscribeRequest(action, topic string) string {
+	return fmt.Sprintf(`{"action":%q,"topic":%q}`, action, topic)
+}
+
+// TestIsValidTopic
+func TestIsValidTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		valid bool
+	}{
+		{TransactionsTopic, true},
+		{ReceiptTopic("hash"), true},
+		{AddressTopic("address"), true},
+		{receiptTopicPrefix, false},
+		{addressTopicPrefix, false},
+		{"blocks", false},
+		{"", false},
+		{AddressTopic(strings.Repeat("a", maxTopicLength)), false},
+	}
+	for _, test := range tests {
+		if isValidTopic(test.topic) != test.valid {
+			t.Errorf("isValidTopic(%q) is %t, expected %t", test.topic, !test.valid, test.valid)
+		}
+	}
+}
+
+// TestSubscriptions
+func TestSubscriptions(t *testing.T) {
+	connection, closeConnection := dialTestSubscriptions(t)
+	defer closeConnection()
+
+	replies := []struct {
+		message string
+		status  string
+	}{
+		{subscribeRequest("subscribe", "blocks"), types.StatusInvalidSubscription},
+		{subscribeRequest("publish", TransactionsTopic), types.StatusInvalidSubscription},
+		{`{"action":1}`, types.StatusJsonParseError},
+		{subscribeRequest("subscribe", TransactionsTopic), types.StatusOk},
+	}
+	for _, reply := range replies {
+		if message := sendSubscriptionRequest(t, connection, reply.message); message.Status != reply.status {
+			t.Errorf("%s replied %s, expected %s", reply.message, message.Status, reply.status)
+		}
+	}
+
+	PublishSubscriptionEvent(AddressTopic("other"), TransactionEvent, "other")
+	PublishSubscriptionEvent(TransactionsTopic, TransactionEvent, map[string]string{"hash": "hash"})
+	event := receiveSubscriptionMessage(t, connection)
+	if event.Topic != TransactionsTopic || event.Type != TransactionEvent || string(event.Data) != `{"hash":"hash"}` {
+		t.Errorf("received %+v", event)
+	}
+
+	if message := sendSubscriptionRequest(t, connection, subscribeRequest("unsubscribe", TransactionsTopic)); message.Status != types.StatusOk {
+		t.Errorf("unsubscribe replied %s", message.Status)
+	}
+	subscriptions.mutex.RLock()
+	subscribed := len(subscriptions.topics[TransactionsTopic])
+	subscriptions.mutex.RUnlock()
+	if subscribed != 0 {
+		t.Errorf("%d subscribers left after unsubscribing", subscribed)
+	}
+}
+
+// TestSubscribeReceipt - a receipt that changed before subscribing is sent right away
+func TestSubscribeReceipt(t *testing.T) {
+	connection, closeConnection := dialTestSubscriptions(t)
+	defer closeConnection()
+	receipt := &types.Receipt{TransactionHash: "subscribed-receipt", Status: types.StatusOk}
+	receipt.Cache(GetCache())
+	defer GetCache().Delete(receipt.Key())
+
+	if message := sendSubscriptionRequest(t, connection, subscribeRequest("subscribe", ReceiptTopic(receipt.TransactionHash))); message.Status != types.StatusOk {
+		t.Fatalf("subscribe replied %s", message.Status)
+	}
+	event := receiveSubscriptionMessage(t, connection)
+	if event.Topic != ReceiptTopic(receipt.TransactionHash) || event.Type != ReceiptEvent || !strings.Contains(string(event.Data), receipt.TransactionHash) {
+		t.Errorf("received %+v", event)
+	}
+}
+
+// TestMaxSubscriptions
+func TestMaxSubscriptions(t *testing.T) {
+	connection, closeConnection := dialTestSubscriptions(t)
+	defer closeConnection()
+	for i := 0; i < maxSubscriptions; i++ {
+		if message := sendSubscriptionRequest(t, connection, subscribeRequest("subscribe", AddressTopic(fmt.Sprint(i)))); message.Status != types.StatusOk {
+			t.Fatalf("subscription %d replied %s", i, message.Status)
+		}
+	}
+	if message := sendSubscriptionRequest(t, connection, subscribeRequest("subscribe", TransactionsTopic)); message.Status != types.StatusInvalidSubscription {
+		t.Errorf("subscription over the limit replied %s", message.Status)
+	}
+	if message := sendSubscriptionRequest(t, connection, subscribeRequest("unsubscribe", AddressTopic("0"))); message.Status != types.StatusOk {
+		t.Fatalf("unsubscribe replied %s", message.Status)
+	}
+	if message := sendSubscriptionRequest(t, connection, subscribeRequest("subscribe", TransactionsTopic)); message.Status != types.StatusOk {
+		t.Errorf("subscription after unsubscribing replied %s", message.Status)
+	}
+}
+
+// TestSlowSubscriber - a subscriber whose queue is full is disconnected and unsubscribed
+func TestSlowSubscriber(t *testing.T) {
+	connections := make(chan *websocket.Conn)
+	closed := make(chan bool)
+	server := httptest.NewServer(websocket.Handler(func(connection *websocket.Conn) {
+		connections <- connection
+		<-closed
+	}))
+	defer server.Close()
+	defer close(closed)
+	clientConnection, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientConnection.Close()
+
+	client := &subscriber{connection: <-connections, queue: make(chan []byte, 1), topics: map[string]bool{}, done: make(chan struct{})}
+	subscriptions.subscribe(client, AddressTopic("slow"))
+	PublishSubscriptionEvent(AddressTopic("slow"), TransactionEvent, "first")
+	select {
+	case <-client.done:
+		t.Fatal("subscriber dropped with room in its queue")
+	default:
+	}
+	PublishSubscriptionEvent(AddressTopic("slow"), TransactionEvent, "second")
+	select {
+	case <-client.done:
+	default:
+		t.Fatal("slow subscriber was not dropped")
+	}
+	subscriptions.mutex.RLock()
+	_, ok := subscriptions.topics[AddressTopic("slow")]
+	subscriptions.mutex.RUnlock()
+	if ok || len(client.topics) != 0 {
+		t.Error("slow subscriber is still subscribed")
+	}
+	clientConnection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var message string
+	if err := websocket.Message.Receive(clientConnection, &message); err == nil {
+		t.Errorf("received %s from a dropped subscriber", message)
+	}
+}
+
+// TestCheckSubscriptionOrigin
+func TestCheckSubscriptionOrigin(t *testing.T) {
+	limits := types.GetConfig().HttpLimits
+	allowed := limits.AllowedOrigins
+	limits.AllowedOrigins = []string{"https://wallet.example"}
+	defer func() {
+		limits.AllowedOrigins = allowed
+	}()
+
+	tests := []struct {
+		origin string
+		ok     bool
+	}{
+		{"", true},
+		{"https://wallet.example", true},
+		{"https://other.example", false},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest("GET", "/v1/subscriptions", nil)
+		if test.origin != "" {
+			request.Header.Set("Origin", test.origin)
+		}
+		if err := checkSubscriptionOrigin(nil, request); (err == nil) != test.ok {
+			t.Errorf("origin %q returned %v", test.origin, err)
+		}
+	}
+}
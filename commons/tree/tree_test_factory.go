@@ -2,6 +2,7 @@ package tree
 
 import (
 	"encoding/hex"
+	"math/big"
 	"time"
 
 	"github.com/dispatchlabs/disgo/commons/crypto"
@@ -68,7 +69,7 @@ func mockTransaction() *types.Transaction {
 		key.GetPrivateKeyString(),
 		key.Address,
 		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
-		1,
+		big.NewInt(1),
 		0,
 		time.Now().UnixNano(),
 	)
@@ -25,11 +25,10 @@ import (
 	"strings"
 	"sync"
 	"time"
-	"strconv"
 
 	"math/big"
 
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/crypto"
@@ -47,7 +46,7 @@ type Account struct {
 	PrivateKey      string
 	Name            string
 	Balance         *big.Int
-	HertzAvailable  uint64
+	HertzAvailable  *big.Int
 	TransactionHash string // Smart contract
 	Updated         time.Time
 	Created         time.Time
@@ -83,7 +82,11 @@ func (this *Account) Cache(cache *cache.Cache, time_optional ...time.Duration) {
 
 //Persist
 func (this *Account) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	record, err := this.ToRecord()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), record)
 	if err != nil {
 		return err
 	}
@@ -121,21 +124,18 @@ func (this *Account) UnmarshalJSON(bytes []byte) error {
 		this.Name = jsonMap["name"].(string)
 	}
 	if jsonMap["balance"] != nil {
-		var bFloat float64
-		balance, ok1 := jsonMap["balance"].(string)
-		if !ok1 {
-			var ok2 bool
-			bFloat, ok2 = jsonMap["balance"].(float64)
-			if !ok2 {
-				return errors.Errorf("value for field 'balance' must be a string")
-			}
-		} else {
-			bFloat, err = strconv.ParseFloat(balance, 64)
-			if err != nil {
-			  return errors.Errorf("value for field 'balance' must be convertable to an integer")
-			}
+		// Decode the raw balance, jsonMap numbers are float64 and would lose precision.
+		var amounts struct {
+			Balance json.RawMessage `json:"balance"`
+		}
+		err = json.Unmarshal(bytes, &amounts)
+		if err != nil {
+			return err
+		}
+		this.Balance, err = ToAmountFromJson(amounts.Balance)
+		if err != nil {
+			return errors.Errorf("value for field 'balance' must be convertable to an integer")
 		}
-		this.Balance = big.NewInt(int64(bFloat))
 	}
 	if jsonMap["transactionHash"] != nil {
 		this.TransactionHash = jsonMap["transactionHash"].(string)
@@ -185,8 +185,8 @@ func (this Account) MarshalJSON() ([]byte, error) {
 		Address:         this.Address,
 		PrivateKey:      this.PrivateKey,
 		Name:            this.Name,
-		Balance:         this.Balance.String(),
-		HertzAvailable:	 strconv.FormatUint(this.HertzAvailable, 10),
+		Balance:         AmountString(this.Balance),
+		HertzAvailable:	 AmountString(this.HertzAvailable),
 		TransactionHash: this.TransactionHash,
 		Updated:         this.Updated,
 		Created:         this.Created,
@@ -253,7 +253,7 @@ func ToAccountByAddress(txn *badger.Txn, address string) (*Account, error) {
 	if err != nil {
 		return nil, err
 	}
-	account, err := ToAccountFromJson(value)
+	account, err := ToAccountFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -342,7 +342,7 @@ func AccountPaging(txn *badger.Txn, startingHash string, page, pageSize int) ([]
 				utils.Error(err)
 				continue
 			}
-			Account, err := ToAccountFromJson(value)
+			Account, err := ToAccountFromRecord(value)
 			if err != nil {
 				utils.Error(err)
 				continue
@@ -21,7 +21,7 @@ import (
 	"reflect"
 	"testing"
 	"time"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
 )
@@ -73,6 +73,26 @@ func TestToAccountFromJsonBalanceAsNumber(t *testing.T) {
 	testAccountStruct(t, account)
 }
 
+//TestToAccountFromJsonBalanceBeyondInt64
+func TestToAccountFromJsonBalanceBeyondInt64(t *testing.T) {
+	defer destruct()
+	var testAccountByteLargeBalance = []byte("{\"address\":\"99022124e110f5a9567a334a2017bdbd41c475e3\",\"balance\":\"123456789012345678901234567890\"}")
+	account, err := ToAccountFromJson(testAccountByteLargeBalance)
+	if err != nil {
+		t.Fatalf("ToAccountFromJson returning error: %s", err)
+	}
+	if account.Balance.String() != "123456789012345678901234567890" {
+		t.Errorf("ToAccountFromJson returning invalid balance: %s", account.Balance)
+	}
+	testAccount, err := ToAccountFromJson([]byte(account.String()))
+	if err != nil {
+		t.Fatalf("ToAccountFromJson returning error: %s", err)
+	}
+	if testAccount.Balance.Cmp(account.Balance) != 0 {
+		t.Errorf("balance did not survive a JSON round trip: %s", testAccount.Balance)
+	}
+}
+
 //TestAccountCache
 func TestAccountCache(t *testing.T) {
 	defer destruct()
@@ -0,0 +1,80 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Amounts are parsed from untrusted input, they are bounded before they are converted.
+const (
+	maxAmountLength = 128
+	maxAmountBits   = 256
+)
+
+// ToAmount - parses a decimal amount, legacy float encodings (e.g. "1e+06" or "1000.0") are accepted when they hold an
+// integer. Amounts of more than maxAmountBits bits are refused.
+func ToAmount(value string) (*big.Int, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > maxAmountLength {
+		return nil, errors.Errorf("amount must be at most %d characters", maxAmountLength)
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if ok {
+		if amount.BitLen() > maxAmountBits {
+			return nil, errors.Errorf("amount %s exceeds %d bits", value, maxAmountBits)
+		}
+		return amount, nil
+	}
+	amountFloat, _, err := big.ParseFloat(value, 10, maxAmountBits, big.ToNearestEven)
+	if err != nil || amountFloat.IsInf() || !amountFloat.IsInt() {
+		return nil, errors.Errorf("amount %s must be convertable to an integer", value)
+	}
+	if amountFloat.MantExp(nil) > maxAmountBits {
+		return nil, errors.Errorf("amount %s exceeds %d bits", value, maxAmountBits)
+	}
+	amount, _ = amountFloat.Int(nil)
+	return amount, nil
+}
+
+// ToAmountFromJson - parses an amount encoded either as a decimal string or as a (legacy) JSON number
+func ToAmountFromJson(raw json.RawMessage) (*big.Int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if raw[0] == '"' {
+		var value string
+		err := json.Unmarshal(raw, &value)
+		if err != nil {
+			return nil, err
+		}
+		return ToAmount(value)
+	}
+	return ToAmount(string(raw))
+}
+
+// AmountString - decimal encoding of amount, nil is encoded as zero
+func AmountString(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	return amount.String()
+}
@@ -0,0 +1,60 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestToAmount
+func TestToAmount(t *testing.T) {
+	maxAmount := "115792089237316195423570985008687907853269984665640564039457584007913129639935" // 2^256 - 1
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"0", "0"},
+		{" 1000 ", "1000"},
+		{"1e+06", "1000000"},
+		{"1000.0", "1000"},
+		{maxAmount, maxAmount},
+		{"1.5", ""},
+		{"abc", ""},
+		{"1e600000000", ""},
+		{"1e78", ""},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639936", ""},
+		{strings.Repeat("1", maxAmountLength+1), ""},
+	}
+	for _, test := range tests {
+		start := time.Now()
+		amount, err := ToAmount(test.value)
+		if time.Since(start) > time.Second {
+			t.Errorf("ToAmount(%.20s) took %s", test.value, time.Since(start))
+		}
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("ToAmount(%.20s) returned %s", test.value, amount)
+			}
+			continue
+		}
+		if err != nil || amount.String() != test.expected {
+			t.Errorf("ToAmount(%.20s) returned %v %v, expected %s", test.value, amount, err, test.expected)
+		}
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"time"
 	"fmt"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 )
 
 // Authentication
@@ -235,3 +235,68 @@ func NewAuthentication() (*Authentication, error) {
 
 	return authenticate, nil
 }
+
+// NewNodeHash - Hash of the time and the fields of the node being authenticated, so the authentication cannot be replayed for another
+// endpoint
+func (this Authentication) NewNodeHash(node *Node) (string, error) {
+	capabilities := node.Capabilities
+	if len(capabilities) == 0 {
+		capabilities = nil
+	}
+	bytes, err := json.Marshal(struct {
+		Time            int64     `json:"time"`
+		Address         string    `json:"address"`
+		GrpcEndpoint    *Endpoint `json:"grpcEndpoint"`
+		HttpEndpoint    *Endpoint `json:"httpEndpoint"`
+		ProtocolVersion int64     `json:"protocolVersion"`
+		Capabilities    []string  `json:"capabilities"`
+	}{this.Time, node.Address, node.GrpcEndpoint, node.HttpEndpoint, node.ProtocolVersion, capabilities})
+	if err != nil {
+		return "", err
+	}
+	hash := crypto.NewHash(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// VerifyNode - The hash must be of node's fields, the time no more than maxAge (ms) apart from now and the signature node's
+func (this Authentication) VerifyNode(node *Node, maxAge int64) error {
+	hash, err := this.NewNodeHash(node)
+	if err != nil || hash != this.Hash {
+		return errors.New("invalid hash")
+	}
+	age := utils.ToMilliSeconds(time.Now()) - this.Time
+	if age > maxAge || age < -maxAge {
+		return errors.New("authentication timed out")
+	}
+	address, err := this.GetDerivedAddress()
+	if err != nil {
+		return err
+	}
+	if address != node.Address {
+		return errors.New("node address does not match the computed address from hash and signature")
+	}
+	return nil
+}
+
+// NewNodeAuthentication - Authenticates this node as node
+func NewNodeAuthentication(node *Node) (*Authentication, error) {
+	if node.Address != GetAccount().Address {
+		return nil, errors.New("node address does not match this node's address")
+	}
+	return NewNodeAuthenticationWithKey(GetKey(), node)
+}
+
+// NewNodeAuthenticationWithKey
+func NewNodeAuthenticationWithKey(privateKey string, node *Node) (*Authentication, error) {
+	authenticate := &Authentication{Time: utils.ToMilliSeconds(time.Now())}
+	var err error
+	authenticate.Hash, err = authenticate.NewNodeHash(node)
+	if err != nil {
+		return nil, err
+	}
+	authenticate.Signature, err = authenticate.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return authenticate, nil
+}
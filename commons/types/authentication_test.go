@@ -0,0 +1,78 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+)
+
+// testMockAuthenticatedNode
+func testMockAuthenticatedNode(t *testing.T) (*Node, string) {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	node := &Node{
+		Address:         hex.EncodeToString(crypto.ToAddress(publicKey)),
+		GrpcEndpoint:    &Endpoint{Host: "127.0.0.1", Port: 1973},
+		HttpEndpoint:    &Endpoint{Host: "127.0.0.1", Port: 1975},
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    []string{"state-sync"},
+	}
+	return node, hex.EncodeToString(privateKey)
+}
+
+// TestAuthenticationVerifyNode
+func TestAuthenticationVerifyNode(t *testing.T) {
+	node, privateKey := testMockAuthenticatedNode(t)
+	authentication, err := NewNodeAuthenticationWithKey(privateKey, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = authentication.VerifyNode(node, 30000); err != nil {
+		t.Fatalf("authentication of its own node failed: %v", err)
+	}
+
+	// Replayed for another endpoint or capability.
+	moved := *node
+	moved.GrpcEndpoint = &Endpoint{Host: "10.0.0.1", Port: 1973}
+	if authentication.VerifyNode(&moved, 30000) == nil {
+		t.Error("authentication replayed for another grpc endpoint was accepted")
+	}
+	changed := *node
+	changed.Capabilities = nil
+	if authentication.VerifyNode(&changed, 30000) == nil {
+		t.Error("authentication replayed with other capabilities was accepted")
+	}
+
+	// Claimed by another address.
+	other, _ := testMockAuthenticatedNode(t)
+	impostor := *node
+	impostor.Address = other.Address
+	if authentication.VerifyNode(&impostor, 30000) == nil {
+		t.Error("authentication of another address was accepted")
+	}
+
+	// Stale.
+	stale := *authentication
+	stale.Time -= 60000
+	stale.Hash, _ = stale.NewNodeHash(node)
+	stale.Signature, _ = stale.NewSignature(privateKey)
+	if stale.VerifyNode(node, 30000) == nil {
+		t.Error("stale authentication was accepted")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"github.com/dispatchlabs/disgo/commons/cache"
+)
+
+// Cache namespaces
+const (
+	CacheNamespaceAccounts        = "accounts"
+	CacheNamespaceTransactions    = "transactions"
+	CacheNamespaceReceipts        = "receipts"
+	CacheNamespaceGossips         = "gossips"
+	CacheNamespaceRumors          = "rumors"
+	CacheNamespaceRateLimits      = "rateLimits"
+	CacheNamespaceAuthentications = "authentications"
+	CacheNamespaceNodes           = "nodes"
+	CacheNamespacePages           = "pages"
+)
+
+// GetCacheNamespaces - the namespaces of the domain types' cache keys, config cacheSizes overrides their maximum entries except for rate limits
+func GetCacheNamespaces() []cache.Namespace {
+	namespaces := []cache.Namespace{
+		{Name: CacheNamespaceAccounts, Prefixes: []string{"table-account-"}, MaxEntries: 10000, TTL: AccountTTL},
+		{Name: CacheNamespaceTransactions, Prefixes: []string{"table-transaction-"}, MaxEntries: 10000, TTL: TransactionCacheTTL},
+		{Name: CacheNamespaceReceipts, Prefixes: []string{"table-receipt-"}, MaxEntries: 10000, TTL: ReceiptCacheTTL},
+		{Name: CacheNamespaceGossips, Prefixes: []string{"table-gossip-"}, MaxEntries: 10000, TTL: GossipCacheTTL},
+		{Name: CacheNamespaceRumors, Prefixes: []string{"cache-rumor-"}, MaxEntries: 10000, TTL: GossipCacheTTL},
+		{Name: CacheNamespaceRateLimits, Prefixes: []string{"table-ratelimit-"}, TTL: RateLimitAverageTTL}, // Unbounded, held transactions are only in the cache
+		{Name: CacheNamespaceAuthentications, Prefixes: []string{"table-authentication-"}, MaxEntries: 1000, TTL: AuthenticationCacheTTL},
+		{Name: CacheNamespaceNodes, Prefixes: []string{"table-node-", "key-node-type-"}, MaxEntries: 2000},
+		{Name: CacheNamespacePages, Prefixes: []string{"Page-"}, MaxEntries: 1000, TTL: PageTTL},
+		{Name: cache.DefaultNamespace, MaxEntries: 10000, TTL: CacheTTL},
+	}
+	for i := range namespaces {
+		if namespaces[i].Name == CacheNamespaceRateLimits {
+			continue
+		}
+		if size, ok := GetConfig().CacheSizes[namespaces[i].Name]; ok {
+			namespaces[i].MaxEntries = size
+		}
+	}
+	return namespaces
+}
@@ -49,6 +49,21 @@ type Config struct {
 	IsBookkeeper      bool        `json:"isBookkeeper"`
 	KeyLocation 	  string	  `json:"keyLocation"`
 	RateLimits        *RateLimits `json:"rateLimits"`
+	CacheSizes        map[string]int `json:"cacheSizes,omitempty"` // Overrides the maximum entries of a cache namespace by name
+	StateSync         bool        `json:"stateSync,omitempty"` // Initial sync downloads account and contract state at a checkpoint instead of replaying all history
+	PeerBanSeconds    int         `json:"peerBanSeconds,omitempty"` // How long a peer with too low a reputation is refused, 600 when not set
+	MinProtocolVersion *int64     `json:"minProtocolVersion,omitempty"` // Peers below are not delegates, MinProtocolVersion when not set
+	GrpcTls           *GrpcTls    `json:"grpcTls,omitempty"` // Mutual TLS between nodes, plaintext when not set
+	HttpLimits        *HttpLimits `json:"httpLimits,omitempty"`
+	EthChainId        int64       `json:"ethChainId,omitempty"` // Reported to Ethereum tooling by the JSON-RPC API, EthChainIdDefault when not set
+}
+
+// GrpcTls - Every node of a network must agree on Enabled. The certificate is bound to this node's address and generated on
+// first boot when the files do not exist, they default to grpc.crt and grpc.key in the config directory.
+type GrpcTls struct {
+	Enabled         bool   `json:"enabled"`
+	CertificateFile string `json:"certificateFile,omitempty"`
+	KeyFile         string `json:"keyFile,omitempty"`
 }
 
 // String - Implement the `fmt.Stringer` interface
@@ -98,6 +113,9 @@ func GetConfig() *Config {
 			if configInstance.RateLimits == nil {
 				configInstance.RateLimits = RateLimitsDefaults
 			}
+			if configInstance.HttpLimits == nil {
+				configInstance.HttpLimits = HttpLimitsDefaults
+			}
 			utils.Info(fmt.Sprintf("loaded config file %s", configFileName))
 		} else {
 			configInstance = GetDefaultConfig()
@@ -161,6 +179,7 @@ func GetDefaultConfig() *Config {
 		IsBookkeeper: true,
 		KeyLocation: utils.GetConfigDir() + string(os.PathSeparator) + "myDisgoKey.json",
 		RateLimits:   RateLimitsDefaults,
+		HttpLimits:   HttpLimitsDefaults,
 	}
 }
 
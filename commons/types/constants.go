@@ -25,6 +25,9 @@ import (
 const (
 	HertzMultiplier = 1
 )
+
+// EthChainIdDefault - Chain id of the Ethereum JSON-RPC API when Config.EthChainId is not set
+const EthChainIdDefault = 3333
 //TODO: I think we need to convert these timouts and their calculations in code to nano seconds
 // Timouts -- currently calculated in milliseconds.
 const (
@@ -56,10 +59,16 @@ const (
 	StatusUnavailableFeature           = "UnavailableFeature"
 	StatusNodeUnavailable              = "NodeUnavailable"
 	StatusCouldNotReachConsensus       = "CouldNotReachConsensus"
+	StatusSynchronizing                = "Synchronizing"
+	StatusTooManyRequests              = "TooManyRequests"
+	StatusRequestTooLarge              = "RequestTooLarge"
+	StatusRequestTimedOut              = "RequestTimedOut"
+	StatusInvalidSubscription          = "InvalidSubscription"
 )
 
 const (
-	StatusNotDelegateAsHumanReadable = "This node is not a delegate. Please select a delegate node."
+	StatusNotDelegateAsHumanReadable   = "This node is not a delegate. Please select a delegate node."
+	StatusSynchronizingAsHumanReadable = "This delegate is still synchronizing its ledger. Please select another delegate node."
 )
 
 // Types
@@ -98,4 +107,5 @@ var (
 	ErrInvalidRequestPageSize = errors.New("invalid request Page Size")
 	ErrInvalidRequestStartingHash = errors.New("invalid request Starting Hash")
 	ErrInvalidRequestHash     = errors.New("invalid request Hash")
+	ErrUnknownRecordVersion   = errors.New("unknown record version")
 )
@@ -0,0 +1,213 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// delegateSetPrefix - accepted delegate sets are kept by epoch and seed
+const delegateSetPrefix = "table-delegate-set-"
+
+// DelegateSet - A delegate list signed by a seed, a node only accepts sets newer than the one it has. Seeds count epochs
+// independently, sets with the same epoch are ordered by seed address
+type DelegateSet struct {
+	Epoch     int64   `json:"epoch"`
+	Time      int64   `json:"time"`
+	Seed      string  `json:"seed"` // Address of the seed that signed the set
+	Delegates []*Node `json:"delegates"`
+	Hash      string  `json:"hash"`
+	Signature string  `json:"signature"`
+}
+
+// delegateSetEntry - the signed fields of a delegate
+type delegateSetEntry struct {
+	Address         string    `json:"address"`
+	GrpcEndpoint    *Endpoint `json:"grpcEndpoint"`
+	HttpEndpoint    *Endpoint `json:"httpEndpoint"`
+	Type            string    `json:"type"`
+	ProtocolVersion int64     `json:"protocolVersion"`
+}
+
+// NewDelegateSet - Creates and signs a delegate set, the delegates are sorted by address
+func NewDelegateSet(privateKey string, seed string, epoch int64, delegates []*Node) (*DelegateSet, error) {
+	sorted := append([]*Node{}, delegates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Address < sorted[j].Address })
+	this := &DelegateSet{
+		Epoch:     epoch,
+		Time:      utils.ToMilliSeconds(time.Now()),
+		Seed:      seed,
+		Delegates: sorted,
+	}
+	var err error
+	this.Hash, err = this.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	this.Signature, err = signSeedHash(privateKey, this.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return this, nil
+}
+
+// NewHash - Hash of the epoch, time, seed and the signed fields of each delegate
+func (this DelegateSet) NewHash() (string, error) {
+	bytes, err := json.Marshal(struct {
+		Epoch     int64              `json:"epoch"`
+		Time      int64              `json:"time"`
+		Seed      string             `json:"seed"`
+		Delegates []delegateSetEntry `json:"delegates"`
+	}{this.Epoch, this.Time, this.Seed, this.entries()})
+	if err != nil {
+		return "", err
+	}
+	hash := crypto.NewHash(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Verify - The set must be signed by one of the seeds
+func (this DelegateSet) Verify(seeds []string) error {
+	hash, err := this.NewHash()
+	if err != nil {
+		return err
+	}
+	if this.Hash != "" && hash != this.Hash {
+		return errors.New("invalid delegate set hash")
+	}
+	err = verifySeedSignature(seeds, this.Seed, this.Hash, this.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid delegate set")
+	}
+	return nil
+}
+
+// HasSameDelegates - Whether both sets list the same signed delegate fields
+func (this DelegateSet) HasSameDelegates(other *DelegateSet) bool {
+	if other == nil || len(this.Delegates) != len(other.Delegates) {
+		return false
+	}
+	a, err := json.Marshal(this.entries())
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(other.entries())
+	if err != nil {
+		return false
+	}
+	return string(a) == string(b)
+}
+
+// IsNewerThan - Ordered by epoch then seed address so every node settles on the same set when two seeds sign an epoch
+func (this DelegateSet) IsNewerThan(other *DelegateSet) bool {
+	if other == nil {
+		return true
+	}
+	if this.Epoch != other.Epoch {
+		return this.Epoch > other.Epoch
+	}
+	return this.Seed > other.Seed
+}
+
+// entries - sorted by address so the hash does not depend on the order delegates were listed
+func (this DelegateSet) entries() []delegateSetEntry {
+	entries := make([]delegateSetEntry, 0, len(this.Delegates))
+	for _, delegate := range this.Delegates {
+		entries = append(entries, delegateSetEntry{
+			Address:         delegate.Address,
+			GrpcEndpoint:    delegate.GrpcEndpoint,
+			HttpEndpoint:    delegate.HttpEndpoint,
+			Type:            delegate.Type,
+			ProtocolVersion: delegate.ProtocolVersion,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Address < entries[j].Address })
+	return entries
+}
+
+// Key - zero padded so history iterates in epoch then seed order, sets of the same epoch signed by different seeds are kept apart
+func (this DelegateSet) Key() string {
+	return fmt.Sprintf("%s%020d-%s", delegateSetPrefix, this.Epoch, this.Seed)
+}
+
+// Persist
+func (this *DelegateSet) Persist(txn *badger.Txn) error {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		return err
+	}
+	return txn.Set([]byte(this.Key()), bytes)
+}
+
+// String
+func (this DelegateSet) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal delegate set", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// ToDelegateSets - Accepted delegate sets, newest first
+func ToDelegateSets(txn *badger.Txn, limit int) ([]*DelegateSet, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = true
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte(delegateSetPrefix)
+	sets := make([]*DelegateSet, 0)
+
+	// Reverse iteration seeks to the last key at or before the seek key.
+	for iterator.Seek(append(append([]byte{}, prefix...), 0xff)); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			return nil, err
+		}
+		set := &DelegateSet{}
+		err = json.Unmarshal(value, set)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+		if limit > 0 && len(sets) == limit {
+			break
+		}
+	}
+	return sets, nil
+}
+
+// ToLatestDelegateSet - The newest accepted delegate set, ErrNotFound without one
+func ToLatestDelegateSet(txn *badger.Txn) (*DelegateSet, error) {
+	sets, err := ToDelegateSets(txn, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return nil, ErrNotFound
+	}
+	return sets[0], nil
+}
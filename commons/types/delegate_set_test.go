@@ -0,0 +1,128 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+)
+
+// newTestDelegateSet
+func newTestDelegateSet(t *testing.T, epoch int64) (*DelegateSet, string) {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	seed := hex.EncodeToString(crypto.ToAddress(publicKey))
+	delegates := []*Node{
+		{Address: "b", GrpcEndpoint: &Endpoint{Host: "127.0.0.1", Port: 1974}, Type: TypeDelegate},
+		{Address: "a", GrpcEndpoint: &Endpoint{Host: "127.0.0.1", Port: 1973}, Type: TypeDelegate},
+	}
+	delegateSet, err := NewDelegateSet(hex.EncodeToString(privateKey), seed, epoch, delegates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delegateSet, seed
+}
+
+// TestDelegateSetVerify
+func TestDelegateSetVerify(t *testing.T) {
+	delegateSet, seed := newTestDelegateSet(t, 1)
+	if delegateSet.Delegates[0].Address != "a" {
+		t.Error("delegates are not sorted by address")
+	}
+	if err := delegateSet.Verify([]string{seed}); err != nil {
+		t.Error(err)
+	}
+	if err := delegateSet.Verify([]string{"not-a-seed"}); err == nil {
+		t.Error("delegate set signed by a non-seed was verified")
+	}
+
+	tampered := *delegateSet
+	tampered.Epoch = 2
+	if err := tampered.Verify([]string{seed}); err == nil {
+		t.Error("delegate set with a tampered epoch was verified")
+	}
+
+	unsigned := *delegateSet
+	unsigned.Signature = ""
+	if err := unsigned.Verify([]string{seed}); err == nil {
+		t.Error("unsigned delegate set was verified")
+	}
+}
+
+// TestDelegateSetHasSameDelegates
+func TestDelegateSetHasSameDelegates(t *testing.T) {
+	delegateSet, _ := newTestDelegateSet(t, 1)
+	reversed := &DelegateSet{Delegates: []*Node{delegateSet.Delegates[1], delegateSet.Delegates[0]}}
+	if !delegateSet.HasSameDelegates(reversed) {
+		t.Error("delegate order should not matter")
+	}
+	if delegateSet.HasSameDelegates(&DelegateSet{Delegates: delegateSet.Delegates[:1]}) {
+		t.Error("different delegates reported as the same")
+	}
+}
+
+// TestDelegateSetIsNewerThan - ordered by epoch then seed
+func TestDelegateSetIsNewerThan(t *testing.T) {
+	tests := []struct {
+		set   DelegateSet
+		other *DelegateSet
+		newer bool
+	}{
+		{DelegateSet{Epoch: 1, Seed: "a"}, nil, true},
+		{DelegateSet{Epoch: 2, Seed: "a"}, &DelegateSet{Epoch: 1, Seed: "b"}, true},
+		{DelegateSet{Epoch: 1, Seed: "b"}, &DelegateSet{Epoch: 2, Seed: "a"}, false},
+		{DelegateSet{Epoch: 2, Seed: "b"}, &DelegateSet{Epoch: 2, Seed: "a"}, true},
+		{DelegateSet{Epoch: 2, Seed: "a"}, &DelegateSet{Epoch: 2, Seed: "b"}, false},
+		{DelegateSet{Epoch: 2, Seed: "a"}, &DelegateSet{Epoch: 2, Seed: "a"}, false},
+	}
+	for _, test := range tests {
+		if test.set.IsNewerThan(test.other) != test.newer {
+			t.Errorf("%d/%s newer than %+v should be %t", test.set.Epoch, test.set.Seed, test.other, test.newer)
+		}
+	}
+}
+
+// TestDelegateSetHistory
+func TestDelegateSetHistory(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	for _, epoch := range []int64{1, 10, 2, 2} {
+		delegateSet, _ := newTestDelegateSet(t, epoch)
+		if err := delegateSet.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	latest, err := ToLatestDelegateSet(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Epoch != 10 {
+		t.Errorf("expected latest epoch 10, got %d", latest.Epoch)
+	}
+	delegateSets, err := ToDelegateSets(txn, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delegateSets) != 4 || delegateSets[1].Epoch != 2 || delegateSets[2].Epoch != 2 || delegateSets[3].Epoch != 1 {
+		t.Fatal("delegate sets are not ordered newest first")
+	}
+	if !delegateSets[1].IsNewerThan(delegateSets[2]) {
+		t.Error("delegate sets of the same epoch are not ordered by seed")
+	}
+}
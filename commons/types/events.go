@@ -7,6 +7,7 @@ type events struct {
 	DisGoverServiceInitFinished string
 	DAPoSServiceInitFinished    string
 	DVMServiceInitFinished      string
+	ReleaseStaged               string
 }
 
 var (
@@ -17,5 +18,6 @@ var (
 		DisGoverServiceInitFinished: "DisGoverServiceInitFinished",
 		DAPoSServiceInitFinished:    "DAPoSServiceInitFinished",
 		DVMServiceInitFinished:      "DVMServiceInitFinished",
+		ReleaseStaged:               "ReleaseStaged",
 	}
 )
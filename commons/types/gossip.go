@@ -21,7 +21,7 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 )
 
 // Gossip
@@ -73,7 +73,11 @@ func (this *Gossip) Cache(cache *cache.Cache) {
 
 // Persist
 func (this *Gossip) Persist(txn *badger.Txn) error{
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	record, err := this.ToRecord()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), record)
 	if err != nil {
 		return err
 	}
@@ -132,6 +136,27 @@ func (this Gossip) ValidRumors() int {
 	return validRumors
 }
 
+// QuorumRumors - verified rumors about this transaction from distinct delegates
+func (this Gossip) QuorumRumors(delegateAddresses []string) int {
+	delegates := make(map[string]bool, len(delegateAddresses))
+	for _, address := range delegateAddresses {
+		delegates[address] = true
+	}
+	counted := map[string]bool{}
+	for _, rumor := range this.Rumors {
+		if !delegates[rumor.Address] || counted[rumor.Address] || rumor.TransactionHash != this.Transaction.Hash || !rumor.Verify() {
+			continue
+		}
+		counted[rumor.Address] = true
+	}
+	return len(counted)
+}
+
+// HasQuorum - verified rumors from at least 2/3 of the delegates
+func (this Gossip) HasQuorum(delegateAddresses []string) bool {
+	return len(delegateAddresses) > 0 && float32(this.QuorumRumors(delegateAddresses)) >= float32(len(delegateAddresses))*2/3
+}
+
 // Refresh
 func (this *Gossip) Refresh(txn *badger.Txn) error {
 	item, err := txn.Get([]byte(this.Key()))
@@ -142,7 +167,7 @@ func (this *Gossip) Refresh(txn *badger.Txn) error {
 	if err != nil {
 		return err
 	}
-	gossip, err := ToGossipFromJson(value)
+	gossip, err := ToGossipFromRecord(value)
 	if err != nil {
 		return err
 	}
@@ -197,7 +222,7 @@ func ToGossipByKey(txn *badger.Txn, key []byte) (*Gossip, error) {
 	if err != nil {
 		return nil, err
 	}
-	gossip, err := ToGossipFromJson(value)
+	gossip, err := ToGossipFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +239,7 @@ func ToGossipByTransactionHash(txn *badger.Txn, transactionHash string) (*Gossip
 	if err != nil {
 		return nil, err
 	}
-	gossip, err := ToGossipFromJson(value)
+	gossip, err := ToGossipFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +273,7 @@ func GossipPaging(page int,txn *badger.Txn) ([]*Gossip, error){
 				utils.Error(err)
 				continue
 			}
-			Gossip, err := ToGossipFromJson(value)
+			Gossip, err := ToGossipFromRecord(value)
 			if err != nil {
 				utils.Error(err)
 				continue
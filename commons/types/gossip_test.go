@@ -17,9 +17,12 @@
 package types
 
 import (
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
 )
 
 
@@ -168,3 +171,30 @@ func testGossipStruct(t *testing.T, gossip *Gossip, tx *Transaction) {
 		t.Errorf("gossip.String() returning invalid value.\nG: %s\nE: %s", gossip.String(), s)
 	}
 }
+
+//TestGossipHasQuorum
+func TestGossipHasQuorum(t *testing.T) {
+	gossip, tx := testMockNewGossip(t)
+	delegates := make([]string, 0)
+	keys := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		publicKey, privateKey := crypto.GenerateKeyPair()
+		delegates = append(delegates, hex.EncodeToString(crypto.ToAddress(publicKey)))
+		keys = append(keys, hex.EncodeToString(privateKey))
+	}
+
+	gossip.Rumors = append(gossip.Rumors, *NewRumor(keys[0], delegates[0], tx.Hash), *NewRumor(keys[0], delegates[0], tx.Hash))
+	if gossip.HasQuorum(delegates) {
+		t.Error("duplicate rumors from one delegate reached quorum")
+	}
+	forged := *NewRumor(keys[1], delegates[1], tx.Hash)
+	forged.Address = delegates[2]
+	gossip.Rumors = append(gossip.Rumors, forged, *testMockRumor())
+	if gossip.QuorumRumors(delegates) != 1 {
+		t.Errorf("gossip.QuorumRumors returning invalid value.\nGot: %d\nExpected: %d", gossip.QuorumRumors(delegates), 1)
+	}
+	gossip.Rumors = append(gossip.Rumors, *NewRumor(keys[1], delegates[1], tx.Hash))
+	if !gossip.HasQuorum(delegates) {
+		t.Error("rumors from 2/3 of the delegates did not reach quorum")
+	}
+}
@@ -0,0 +1,35 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+// HttpLimitsDefaults - Used when Config.HttpLimits is not set
+var HttpLimitsDefaults = &HttpLimits{
+	RequestsPerSecond: 20,
+	Burst:             40,
+	MaxBodyBytes:      1024 * 1024,
+	TimeoutSeconds:    30,
+	AllowedOrigins:    []string{"*"},
+}
+
+// HttpLimits - Protection of the public HTTP API, a zero value disables that protection
+type HttpLimits struct {
+	RequestsPerSecond float64  `json:"requestsPerSecond"` // Per client IP, refilling a bucket of Burst requests
+	Burst             int      `json:"burst"`
+	MaxBodyBytes      int64    `json:"maxBodyBytes"`
+	TimeoutSeconds    int      `json:"timeoutSeconds"`
+	AllowedOrigins    []string `json:"allowedOrigins"` // CORS origins, credentials are only allowed without "*"
+}
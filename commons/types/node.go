@@ -21,7 +21,7 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 	"strings"
 	"time"
 )
@@ -36,6 +36,23 @@ type Node struct {
 	Status       string    `json:"status,omitempty"`
 	StatusTime   time.Time `json:"statusTime,omitempty"`
 	Version      *Version  `json:"version,omitempty"`
+	ProtocolVersion int64    `json:"protocolVersion"`
+	Capabilities    []string `json:"capabilities,omitempty"`
+}
+
+// IsCompatible - Whether the node speaks at least the minimum protocol version
+func (this Node) IsCompatible() bool {
+	return IsCompatibleProtocol(this.ProtocolVersion)
+}
+
+// HasCapability
+func (this Node) HasCapability(capability string) bool {
+	for _, value := range this.Capabilities {
+		if value == capability {
+			return true
+		}
+	}
+	return false
 }
 
 func (this Node) IsAvailable() bool {
@@ -66,7 +83,11 @@ func (this *Node) Cache(c *cache.Cache) {
 
 //Persist
 func (this *Node) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	record, err := this.ToRecord()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), record)
 	if err != nil {
 		return err
 	}
@@ -138,7 +159,7 @@ func ToNodeByKey(txn *badger.Txn, key []byte) (*Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	node, err := ToNodeFromJson(value)
+	node, err := ToNodeFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +176,7 @@ func ToNodeByAddress(txn *badger.Txn, address string) (*Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	node, err := ToNodeFromJson(value)
+	node, err := ToNodeFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
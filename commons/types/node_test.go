@@ -137,3 +137,22 @@ func TestNodeSet(t *testing.T) {
 		t.Error("Node not equal to testNode")
 	}
 }
+
+//TestNodeIsCompatible
+func TestNodeIsCompatible(t *testing.T) {
+	node := &Node{Address: "123", ProtocolVersion: ProtocolVersion, Capabilities: GetCapabilities()}
+	if !node.IsCompatible() {
+		t.Error("node with the current protocol version is not compatible")
+	}
+	if !node.HasCapability(CapabilityAntiEntropy) {
+		t.Error("node is missing a capability it announced")
+	}
+	if node.HasCapability("unknown") {
+		t.Error("node has a capability it did not announce")
+	}
+
+	legacy := &Node{Address: "456"}
+	if legacy.IsCompatible() {
+		t.Error("node without a protocol version is compatible")
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 	"math"
+	"math/big"
 	"time"
 )
 
@@ -161,16 +162,17 @@ func getAccountRateLimitKey(address string) string {
 	return fmt.Sprintf("table-ratelimit-account%s", address)
 }
 
-func CheckMinimumAvailable(txn *badger.Txn, cache *cache.Cache, address string, balance uint64) (uint64, error) {
+func CheckMinimumAvailable(txn *badger.Txn, cache *cache.Cache, address string, balance *big.Int) (*big.Int, error) {
 	totalDeduction, err := CalculateLockedAmount(txn, cache, address)
 	if err != nil {
-		return uint64(0), err
+		return big.NewInt(0), err
 	}
 	utils.Debug("Total Hertz Deduction from account = ", totalDeduction)
-	if totalDeduction > balance {
-		return uint64(0), nil
+	available := new(big.Int).SetUint64(totalDeduction)
+	if balance == nil || available.Cmp(balance) > 0 {
+		return big.NewInt(0), nil
 	}
-	available := balance - totalDeduction
+	available.Sub(balance, available)
 	return available, nil
 }
 
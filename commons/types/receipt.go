@@ -23,7 +23,7 @@ import (
 
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 )
 
 // Receipt
@@ -52,7 +52,11 @@ func (this *Receipt) Cache(cache *cache.Cache, time_optional ...time.Duration) {
 
 // Persist
 func (this *Receipt) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	record, err := this.ToRecord()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), record)
 	if err != nil {
 		return err
 	}
@@ -157,7 +161,12 @@ func (this *Receipt) SetInternalErrorWithNewTransaction(db *badger.DB, err error
 	defer txn.Discard()
 	this.Status = StatusInternalError
 	this.HumanReadableStatus = err.Error()
-	err = txn.SetWithTTL([]byte(this.Key()), []byte(this.String()), ReceiptCacheTTL)
+	record, err := this.ToRecord()
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.SetWithTTL([]byte(this.Key()), record, ReceiptCacheTTL)
 	if err != nil {
 		utils.Error(err)
 	}
@@ -172,7 +181,12 @@ func (this *Receipt) SetStatusWithNewTransaction(db *badger.DB, status string) {
 	txn := db.NewTransaction(true)
 	defer txn.Discard()
 	this.Status = status
-	err := txn.SetWithTTL([]byte(this.Key()), []byte(this.String()), ReceiptCacheTTL)
+	record, err := this.ToRecord()
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.SetWithTTL([]byte(this.Key()), record, ReceiptCacheTTL)
 	if err != nil {
 		utils.Error(err)
 	}
@@ -228,7 +242,7 @@ func ToReceiptFromKey(txn *badger.Txn, key []byte) (*Receipt, error) {
 	if err != nil {
 		return nil, err
 	}
-	receipt, err := ToReceiptFromJson(value)
+	receipt, err := ToReceiptFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
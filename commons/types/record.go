@@ -0,0 +1,360 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+	daposProto "github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Persisted records are a version byte followed by the protobuf encoded record, records written before versioning are JSON objects.
+const (
+	RecordVersionProto byte = 1
+	recordLegacyJson   byte = '{'
+)
+
+// IsLegacyRecord - true for records persisted as JSON
+func IsLegacyRecord(value []byte) bool {
+	return len(value) > 0 && value[0] == recordLegacyJson
+}
+
+// marshalRecord
+func marshalRecord(message proto.Message) ([]byte, error) {
+	bytes, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{RecordVersionProto}, bytes...), nil
+}
+
+// unmarshalRecord - decodes a versioned record into message, legacy JSON records are decoded into legacy instead
+func unmarshalRecord(value []byte, message proto.Message, legacy interface{}) (bool, error) {
+	if len(value) == 0 {
+		return false, errors.Wrap(ErrUnknownRecordVersion, "empty record")
+	}
+	switch value[0] {
+	case recordLegacyJson:
+		return true, json.Unmarshal(value, legacy)
+	case RecordVersionProto:
+		return false, proto.Unmarshal(value[1:], message)
+	}
+	return false, errors.Wrapf(ErrUnknownRecordVersion, "version %d", value[0])
+}
+
+// ToRecord - receipt and gossip are transient and persisted under their own keys
+func (this Transaction) ToRecord() ([]byte, error) {
+	return marshalRecord(this.toProtoRecord())
+}
+
+// toProtoRecord
+func (this Transaction) toProtoRecord() *daposProto.Transaction {
+	return &daposProto.Transaction{
+		Hash:      this.Hash,
+		Type:      int32(this.Type),
+		From:      this.From,
+		To:        this.To,
+		Amount:    this.valueString(),
+		Code:      this.Code,
+		Abi:       this.Abi,
+		Method:    this.Method,
+		Params:    this.Params,
+		Time:      this.Time,
+		Signature: this.Signature,
+		Hertz:     this.Hertz,
+		FromName:  this.FromName,
+		ToName:    this.ToName,
+	}
+}
+
+// ToTransactionFromRecord
+func ToTransactionFromRecord(value []byte) (*Transaction, error) {
+	transaction := &Transaction{}
+	record := &daposProto.Transaction{}
+	legacy, err := unmarshalRecord(value, record, transaction)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		return transaction, nil
+	}
+	err = transaction.fromProtoRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// fromProtoRecord
+func (this *Transaction) fromProtoRecord(record *daposProto.Transaction) error {
+	if record.Amount != "" {
+		value, err := ToAmount(record.Amount)
+		if err != nil {
+			return err
+		}
+		this.Value = value
+	} else {
+		this.Value = big.NewInt(record.Value)
+	}
+	this.Hash = record.Hash
+	this.Type = byte(record.Type)
+	this.From = record.From
+	this.To = record.To
+	this.Code = record.Code
+	this.Abi = record.Abi
+	this.Method = record.Method
+	this.Params = record.Params
+	this.Time = record.Time
+	this.Signature = record.Signature
+	this.Hertz = record.Hertz
+	this.FromName = record.FromName
+	this.ToName = record.ToName
+	return nil
+}
+
+// ToRecord - hertz available is recalculated on read and not persisted
+func (this Account) ToRecord() ([]byte, error) {
+	return marshalRecord(&daposProto.Account{
+		Address:         this.Address,
+		PrivateKey:      this.PrivateKey,
+		Name:            this.Name,
+		Balance:         AmountString(this.Balance),
+		TransactionHash: this.TransactionHash,
+		Created:         toRecordMilliseconds(this.Created),
+		Updated:         toRecordMilliseconds(this.Updated),
+		Nonce:           this.Nonce,
+	})
+}
+
+// ToAccountFromRecord
+func ToAccountFromRecord(value []byte) (*Account, error) {
+	account := &Account{}
+	record := &daposProto.Account{}
+	legacy, err := unmarshalRecord(value, record, account)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		return account, nil
+	}
+	balance, err := ToAmount(record.Balance)
+	if err != nil {
+		return nil, err
+	}
+	account.Address = record.Address
+	account.PrivateKey = record.PrivateKey
+	account.Name = record.Name
+	account.Balance = balance
+	account.TransactionHash = record.TransactionHash
+	account.Created = toTimeFromRecordMilliseconds(record.Created)
+	account.Updated = toTimeFromRecordMilliseconds(record.Updated)
+	account.Nonce = record.Nonce
+	return account, nil
+}
+
+// ToRecord
+func (this Receipt) ToRecord() ([]byte, error) {
+	var contractResult []byte
+	if this.ContractResult != nil {
+		var err error
+		contractResult, err = json.Marshal(this.ContractResult)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return marshalRecord(&daposProto.Receipt{
+		TransactionHash:     this.TransactionHash,
+		Status:              this.Status,
+		HumanReadableStatus: this.HumanReadableStatus,
+		ContractAddress:     this.ContractAddress,
+		ContractResult:      contractResult,
+		Created:             toRecordNanoseconds(this.Created),
+	})
+}
+
+// ToReceiptFromRecord
+func ToReceiptFromRecord(value []byte) (*Receipt, error) {
+	receipt := &Receipt{}
+	record := &daposProto.Receipt{}
+	legacy, err := unmarshalRecord(value, record, receipt)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		return receipt, nil
+	}
+	if len(record.ContractResult) > 0 {
+		err = json.Unmarshal(record.ContractResult, &receipt.ContractResult)
+		if err != nil {
+			return nil, err
+		}
+	}
+	receipt.TransactionHash = record.TransactionHash
+	receipt.Status = record.Status
+	receipt.HumanReadableStatus = record.HumanReadableStatus
+	receipt.ContractAddress = record.ContractAddress
+	receipt.Created = toTimeFromRecordNanoseconds(record.Created)
+	return receipt, nil
+}
+
+// ToRecord
+func (this Gossip) ToRecord() ([]byte, error) {
+	rumors := make([]*daposProto.Rumor, 0, len(this.Rumors))
+	for _, rumor := range this.Rumors {
+		rumors = append(rumors, &daposProto.Rumor{
+			Hash:            rumor.Hash,
+			Address:         rumor.Address,
+			TransactionHash: rumor.TransactionHash,
+			Time:            rumor.Time,
+			Signature:       rumor.Signature,
+		})
+	}
+	return marshalRecord(&daposProto.Gossip{
+		TxHash:      this.Transaction.Hash,
+		Rumors:      rumors,
+		Transaction: this.Transaction.toProtoRecord(),
+	})
+}
+
+// ToGossipFromRecord
+func ToGossipFromRecord(value []byte) (*Gossip, error) {
+	gossip := &Gossip{}
+	record := &daposProto.Gossip{}
+	legacy, err := unmarshalRecord(value, record, gossip)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		return gossip, nil
+	}
+	if record.Transaction != nil {
+		err = gossip.Transaction.fromProtoRecord(record.Transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	gossip.Transaction.Hash = record.TxHash
+	gossip.Rumors = make([]Rumor, 0, len(record.Rumors))
+	for _, rumor := range record.Rumors {
+		gossip.Rumors = append(gossip.Rumors, Rumor{
+			Hash:            rumor.Hash,
+			Address:         rumor.Address,
+			TransactionHash: rumor.TransactionHash,
+			Time:            rumor.Time,
+			Signature:       rumor.Signature,
+		})
+	}
+	return gossip, nil
+}
+
+// ToRecord
+func (this Node) ToRecord() ([]byte, error) {
+	record := &daposProto.Node{
+		Address:          this.Address,
+		GrpcEndpoint:     toProtoEndpoint(this.GrpcEndpoint),
+		HttpEndpoint:     toProtoEndpoint(this.HttpEndpoint),
+		LocalHttpApiPort: this.LocalHttpApiPort,
+		Type:             this.Type,
+		Status:           this.Status,
+		StatusTime:       toRecordNanoseconds(this.StatusTime),
+		ProtocolVersion:  this.ProtocolVersion,
+		Capabilities:     this.Capabilities,
+	}
+	if this.Version != nil {
+		record.Version = &daposProto.Version{Version: this.Version.Version, BuildTime: this.Version.BuildTime}
+	}
+	return marshalRecord(record)
+}
+
+// ToNodeFromRecord
+func ToNodeFromRecord(value []byte) (*Node, error) {
+	node := &Node{}
+	record := &daposProto.Node{}
+	legacy, err := unmarshalRecord(value, record, node)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		return node, nil
+	}
+	node.Address = record.Address
+	node.GrpcEndpoint = toDomainEndpoint(record.GrpcEndpoint)
+	node.HttpEndpoint = toDomainEndpoint(record.HttpEndpoint)
+	node.LocalHttpApiPort = record.LocalHttpApiPort
+	node.Type = record.Type
+	node.Status = record.Status
+	node.StatusTime = toTimeFromRecordNanoseconds(record.StatusTime)
+	node.ProtocolVersion = record.ProtocolVersion
+	node.Capabilities = record.Capabilities
+	if record.Version != nil {
+		node.Version = &Version{Version: record.Version.Version, BuildTime: record.Version.BuildTime}
+	}
+	return node, nil
+}
+
+// toProtoEndpoint
+func toProtoEndpoint(endpoint *Endpoint) *daposProto.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	return &daposProto.Endpoint{Host: endpoint.Host, Port: endpoint.Port}
+}
+
+// toDomainEndpoint
+func toDomainEndpoint(endpoint *daposProto.Endpoint) *Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	return &Endpoint{Host: endpoint.Host, Port: endpoint.Port}
+}
+
+// toRecordMilliseconds - zero time is encoded as zero, UnixNano is undefined before 1678
+func toRecordMilliseconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return utils.ToMilliSeconds(t)
+}
+
+// toTimeFromRecordMilliseconds
+func toTimeFromRecordMilliseconds(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return utils.ToTimeFromMilliseconds(ms).UTC()
+}
+
+// toRecordNanoseconds
+func toRecordNanoseconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// toTimeFromRecordNanoseconds
+func toTimeFromRecordNanoseconds(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
@@ -0,0 +1,168 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	daposProto "github.com/dispatchlabs/disgo/dapos/proto"
+)
+
+// TestTransactionRecord
+func TestTransactionRecord(t *testing.T) {
+	tx := testMockTransaction(t)
+	record, err := tx.ToRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record[0] != RecordVersionProto || IsLegacyRecord(record) {
+		t.Fatalf("record is not versioned: %x", record[0])
+	}
+	if len(record) >= len(tx.String()) {
+		t.Errorf("record (%d bytes) is not smaller than JSON (%d bytes)", len(record), len(tx.String()))
+	}
+	testTx, err := ToTransactionFromRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testTx, tx) == false {
+		t.Errorf("ToTransactionFromRecord returning invalid transaction.\nGot: %s\nExpected: %s", testTx, tx)
+	}
+
+	// Legacy JSON records are read as before.
+	testTx, err = ToTransactionFromRecord([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testTx, tx) == false {
+		t.Errorf("ToTransactionFromRecord returning invalid legacy transaction.\nGot: %s\nExpected: %s", testTx, tx)
+	}
+
+	// Records from peers that only set value are read from value.
+	record, err = marshalRecord(&daposProto.Transaction{Hash: tx.Hash, Value: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTx, err = ToTransactionFromRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.Value.Int64() != 42 {
+		t.Errorf("ToTransactionFromRecord returning invalid value: %s", testTx.Value)
+	}
+}
+
+// TestAccountRecord
+func TestAccountRecord(t *testing.T) {
+	account, err := ToAccountFromJson(testAccountByte)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := account.ToRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccount, err := ToAccountFromRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testAccount, account) == false {
+		t.Errorf("ToAccountFromRecord returning invalid account.\nGot: %s\nExpected: %s", testAccount, account)
+	}
+	testAccount, err = ToAccountFromRecord(testAccountByte)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testAccount, account) == false {
+		t.Errorf("ToAccountFromRecord returning invalid legacy account.\nGot: %s\nExpected: %s", testAccount, account)
+	}
+}
+
+// TestReceiptRecord
+func TestReceiptRecord(t *testing.T) {
+	receipt, err := ToReceiptFromJson(testReceiptByte)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt.ContractResult = []interface{}{"result", float64(1)}
+	record, err := receipt.ToRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testReceipt, err := ToReceiptFromRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testReceipt, receipt) == false {
+		t.Errorf("ToReceiptFromRecord returning invalid receipt.\nGot: %s\nExpected: %s", testReceipt, receipt)
+	}
+}
+
+// TestGossipRecord
+func TestGossipRecord(t *testing.T) {
+	gossip, _ := testMockNewGossip(t)
+	gossip.Rumors = append(gossip.Rumors, Rumor{Hash: "hash", Address: "address", TransactionHash: gossip.Transaction.Hash, Time: 1, Signature: "signature"})
+	record, err := gossip.ToRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGossip, err := ToGossipFromRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testGossip, gossip) == false {
+		t.Errorf("ToGossipFromRecord returning invalid gossip.\nGot: %s\nExpected: %s", testGossip, gossip)
+	}
+}
+
+// TestNodeRecord
+func TestNodeRecord(t *testing.T) {
+	node := &Node{
+		Address:         "123",
+		GrpcEndpoint:    &Endpoint{Host: "127.0.0.1", Port: 1973},
+		HttpEndpoint:    &Endpoint{Host: "127.0.0.1", Port: 1975},
+		Type:            TypeDelegate,
+		StatusTime:      time.Unix(0, 1531148645000000123).UTC(),
+		Version:         &Version{Version: "1.0.0", BuildTime: "now"},
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    GetCapabilities(),
+	}
+	record, err := node.ToRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNode, err := ToNodeFromRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testNode, node) == false {
+		t.Errorf("ToNodeFromRecord returning invalid node.\nGot: %s\nExpected: %s", testNode, node)
+	}
+	if !testNode.IsCompatible() || !testNode.HasCapability(CapabilityStateSync) {
+		t.Error("persisted node is no longer compatible")
+	}
+}
+
+// TestUnknownRecordVersion
+func TestUnknownRecordVersion(t *testing.T) {
+	_, err := ToAccountFromRecord([]byte{0xff, 0x01})
+	if err == nil {
+		t.Error("ToAccountFromRecord accepted an unknown record version")
+	}
+}
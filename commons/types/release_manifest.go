@@ -0,0 +1,261 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// Releases are staged under ReleaseDirectory/<version> by the node that receives them, ReleaseStagedFile names the version
+// the next boot installs. A version that had to be rolled back is marked with ReleaseFailedFile and never staged again.
+const (
+	ReleaseDirectory    = "release"
+	ReleaseManifestFile = "manifest.json"
+	ReleaseStagedFile   = "staged"
+	ReleaseFailedFile   = "failed"
+	ReleaseBinary       = "disgo" // Artifact that replaces the running executable, other artifacts are copied next to it
+)
+
+// ReleaseArtifact - A file of a release
+type ReleaseArtifact struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+// ReleaseManifest - A software release signed by a seed, Rollout is the percentage of nodes that install it
+type ReleaseManifest struct {
+	Version   string             `json:"version"`
+	Time      int64              `json:"time"`
+	Seed      string             `json:"seed"`
+	Rollout   int64              `json:"rollout"`
+	Artifacts []*ReleaseArtifact `json:"artifacts"`
+	Hash      string             `json:"hash"`
+	Signature string             `json:"signature"`
+}
+
+// NewReleaseManifest - Creates and signs a manifest for the artifacts, keyed by artifact name
+func NewReleaseManifest(privateKey string, seed string, version string, rollout int64, artifacts map[string][]byte) (*ReleaseManifest, error) {
+	if version == "" || version != filepath.Base(version) || version == "." || version == ".." {
+		return nil, errors.Errorf("invalid release version %q", version)
+	}
+	if rollout <= 0 || rollout > 100 {
+		return nil, errors.Errorf("invalid rollout %d, must be between 1 and 100", rollout)
+	}
+	if _, ok := artifacts[ReleaseBinary]; !ok {
+		return nil, errors.Errorf("release has no %s artifact", ReleaseBinary)
+	}
+	this := &ReleaseManifest{
+		Version: version,
+		Time:    utils.ToMilliSeconds(time.Now()),
+		Seed:    seed,
+		Rollout: rollout,
+	}
+	for name, data := range artifacts {
+		hash := crypto.NewHash(data)
+		this.Artifacts = append(this.Artifacts, &ReleaseArtifact{Name: name, Hash: hex.EncodeToString(hash[:]), Size: int64(len(data))})
+	}
+	sort.Slice(this.Artifacts, func(i, j int) bool { return this.Artifacts[i].Name < this.Artifacts[j].Name })
+	var err error
+	this.Hash, err = this.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	this.Signature, err = signSeedHash(privateKey, this.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return this, nil
+}
+
+// NewHash - Hash of the version, time, seed, rollout and artifacts
+func (this ReleaseManifest) NewHash() (string, error) {
+	bytes, err := json.Marshal(struct {
+		Version   string             `json:"version"`
+		Time      int64              `json:"time"`
+		Seed      string             `json:"seed"`
+		Rollout   int64              `json:"rollout"`
+		Artifacts []*ReleaseArtifact `json:"artifacts"`
+	}{this.Version, this.Time, this.Seed, this.Rollout, this.Artifacts})
+	if err != nil {
+		return "", err
+	}
+	hash := crypto.NewHash(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Verify - The manifest must be signed by one of the seeds
+func (this ReleaseManifest) Verify(seeds []string) error {
+	hash, err := this.NewHash()
+	if err != nil {
+		return err
+	}
+	if this.Hash != "" && hash != this.Hash {
+		return errors.New("invalid release manifest hash")
+	}
+	err = verifySeedSignature(seeds, this.Seed, this.Hash, this.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid release manifest")
+	}
+	if this.Version == "" || this.Version != filepath.Base(this.Version) || this.Version == "." || this.Version == ".." {
+		return errors.Errorf("invalid release version %q", this.Version)
+	}
+	for _, artifact := range this.Artifacts {
+		if artifact.Name != filepath.Base(artifact.Name) || artifact.Name == "." || artifact.Name == ".." || artifact.Name == ReleaseManifestFile || artifact.Name == ReleaseFailedFile {
+			return errors.Errorf("invalid artifact name %s", artifact.Name)
+		}
+	}
+	return nil
+}
+
+// VerifyArtifact - The data must match the size and hash in the manifest
+func (this ReleaseManifest) VerifyArtifact(name string, data []byte) error {
+	for _, artifact := range this.Artifacts {
+		if artifact.Name != name {
+			continue
+		}
+		hash := crypto.NewHash(data)
+		if int64(len(data)) != artifact.Size || hex.EncodeToString(hash[:]) != artifact.Hash {
+			return errors.Errorf("artifact %s does not match the release manifest", name)
+		}
+		return nil
+	}
+	return errors.Errorf("artifact %s is not in the release manifest", name)
+}
+
+// InRollout - Whether a node falls in the rollout percentage, the same nodes are picked for a version as the rollout grows
+func (this ReleaseManifest) InRollout(address string) bool {
+	hash := crypto.NewHash([]byte(this.Version + address))
+	return int64(binary.BigEndian.Uint64(hash[:8])%100) < this.Rollout
+}
+
+// GetReleaseDirectory - Where the artifacts of a version are staged
+func (this ReleaseManifest) GetReleaseDirectory() string {
+	return filepath.Join(ReleaseDirectory, this.Version)
+}
+
+// String
+func (this ReleaseManifest) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal release manifest", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// ToReleaseManifestFromFile
+func ToReleaseManifestFromFile(fileName string) (*ReleaseManifest, error) {
+	bytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ReleaseManifest{}
+	err = json.Unmarshal(bytes, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// VerifyStagedRelease - Verifies the manifest and every artifact staged in the release directory
+func VerifyStagedRelease(directory string, seeds []string) (*ReleaseManifest, error) {
+	manifest, err := ToReleaseManifestFromFile(filepath.Join(directory, ReleaseManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	err = manifest.Verify(seeds)
+	if err != nil {
+		return nil, err
+	}
+	for _, artifact := range manifest.Artifacts {
+		data, err := ioutil.ReadFile(filepath.Join(directory, artifact.Name))
+		if err != nil {
+			return nil, err
+		}
+		err = manifest.VerifyArtifact(artifact.Name, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// StageRelease - Writes the manifest and artifacts to the release directory of its version and marks it for the next boot
+func StageRelease(manifest *ReleaseManifest, artifacts map[string][]byte) (string, error) {
+	if IsFailedRelease(manifest.Version) {
+		return "", errors.Errorf("release %s was rolled back", manifest.Version)
+	}
+	directory := manifest.GetReleaseDirectory()
+	err := os.RemoveAll(directory)
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(directory, 0755)
+	if err != nil {
+		return "", err
+	}
+	for _, artifact := range manifest.Artifacts {
+		data, ok := artifacts[artifact.Name]
+		if !ok {
+			return "", errors.Errorf("artifact %s is missing", artifact.Name)
+		}
+		err = manifest.VerifyArtifact(artifact.Name, data)
+		if err != nil {
+			return "", err
+		}
+		err = ioutil.WriteFile(filepath.Join(directory, artifact.Name), data, 0755)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = ioutil.WriteFile(filepath.Join(directory, ReleaseManifestFile), []byte(manifest.String()), 0644)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(filepath.Join(ReleaseDirectory, ReleaseStagedFile), []byte(manifest.Version), 0644)
+	if err != nil {
+		return "", err
+	}
+	return directory, nil
+}
+
+// GetStagedRelease - The version staged for the next boot, empty without one
+func GetStagedRelease() string {
+	bytes, err := ioutil.ReadFile(filepath.Join(ReleaseDirectory, ReleaseStagedFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(bytes))
+}
+
+// IsFailedRelease - Whether the version was rolled back
+func IsFailedRelease(version string) bool {
+	return utils.Exists(filepath.Join(ReleaseDirectory, version, ReleaseFailedFile))
+}
@@ -0,0 +1,99 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+)
+
+// newTestReleaseManifest
+func newTestReleaseManifest(t *testing.T, rollout int64) (*ReleaseManifest, string) {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	seed := hex.EncodeToString(crypto.ToAddress(publicKey))
+	artifacts := map[string][]byte{ReleaseBinary: []byte("binary"), "config.json": []byte("{}")}
+	manifest, err := NewReleaseManifest(hex.EncodeToString(privateKey), seed, "1.2.0", rollout, artifacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return manifest, seed
+}
+
+// TestReleaseManifestVerify
+func TestReleaseManifestVerify(t *testing.T) {
+	manifest, seed := newTestReleaseManifest(t, 100)
+	if err := manifest.Verify([]string{seed}); err != nil {
+		t.Error(err)
+	}
+	if err := manifest.Verify([]string{"not-a-seed"}); err == nil {
+		t.Error("manifest signed by a non-seed was verified")
+	}
+
+	tampered := *manifest
+	tampered.Rollout = 50
+	if err := tampered.Verify([]string{seed}); err == nil {
+		t.Error("manifest with a tampered rollout was verified")
+	}
+
+	if err := manifest.VerifyArtifact(ReleaseBinary, []byte("binary")); err != nil {
+		t.Error(err)
+	}
+	if err := manifest.VerifyArtifact(ReleaseBinary, []byte("tampered")); err == nil {
+		t.Error("tampered artifact was verified")
+	}
+	if err := manifest.VerifyArtifact("unknown", []byte("binary")); err == nil {
+		t.Error("artifact not in the manifest was verified")
+	}
+}
+
+// TestNewReleaseManifestInvalid
+func TestNewReleaseManifestInvalid(t *testing.T) {
+	_, privateKey := crypto.GenerateKeyPair()
+	key := hex.EncodeToString(privateKey)
+	if _, err := NewReleaseManifest(key, "seed", "1.2.0", 0, map[string][]byte{ReleaseBinary: {}}); err == nil {
+		t.Error("manifest with a zero rollout was created")
+	}
+	if _, err := NewReleaseManifest(key, "seed", "../1.2.0", 100, map[string][]byte{ReleaseBinary: {}}); err == nil {
+		t.Error("manifest with a path as version was created")
+	}
+	if _, err := NewReleaseManifest(key, "seed", "1.2.0", 100, map[string][]byte{"other": {}}); err == nil {
+		t.Error("manifest without a binary was created")
+	}
+}
+
+// TestReleaseManifestInRollout
+func TestReleaseManifestInRollout(t *testing.T) {
+	manifest, _ := newTestReleaseManifest(t, 25)
+	wider := *manifest
+	wider.Rollout = 75
+	count := 0
+	for i := 0; i < 1000; i++ {
+		address := fmt.Sprintf("%040d", i)
+		if manifest.InRollout(address) {
+			count++
+			if !wider.InRollout(address) {
+				t.Fatal("raising the rollout dropped a node")
+			}
+		}
+	}
+	if count < 150 || count > 350 {
+		t.Errorf("expected about 250 of 1000 nodes in a 25%% rollout, got %d", count)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/pkg/errors"
+)
+
+// signSeedHash - Signs a hex hash with a hex private key
+func signSeedHash(privateKey string, hash string) (string, error) {
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return "", err
+	}
+	privateKeyBytes, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return "", err
+	}
+	signatureBytes, err := crypto.NewSignature(privateKeyBytes, hashBytes)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(signatureBytes), nil
+}
+
+// verifySeedSignature - The hash must be signed by seed and seed must be one of seeds
+func verifySeedSignature(seeds []string, seed string, hash string, signature string) error {
+	if hash == "" || signature == "" {
+		return errors.New("not signed")
+	}
+	authorized := false
+	for _, address := range seeds {
+		authorized = authorized || address == seed
+	}
+	if !authorized {
+		return errors.Errorf("not signed by a seed [seed=%s]", seed)
+	}
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return errors.New("unable to decode hash")
+	}
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.New("unable to decode signature")
+	}
+	publicKeyBytes, err := crypto.ToPublicKey(hashBytes, signatureBytes)
+	if err != nil {
+		return errors.New("unable to generate public key from hash and signature")
+	}
+	if hex.EncodeToString(crypto.ToAddress(publicKeyBytes)) != seed {
+		return errors.New("seed address does not match the computed address from hash and signature")
+	}
+	if !crypto.VerifySignature(publicKeyBytes, hashBytes, signatureBytes) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
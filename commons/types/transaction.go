@@ -25,13 +25,14 @@ import (
 	"strings"
 	"sort"
 	"strconv"
+	"math/big"
 
 	"fmt"
 
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
+	"github.com/dispatchlabs/disgo/commons/cache"
 	"github.com/pkg/errors"
 )
 
@@ -41,7 +42,7 @@ type Transaction struct {
 	Type      byte
 	From      string
 	To        string
-	Value     int64
+	Value     *big.Int
 	Code      string
 	Abi       string
 	Method    string
@@ -87,7 +88,11 @@ func (this *Transaction) Cache(cache *cache.Cache) {
 
 // Persist
 func (this *Transaction) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	record, err := this.ToRecord()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), record)
 	if err != nil {
 		return err
 	}
@@ -152,7 +157,7 @@ func (this Transaction) CalculateHash() []byte {
 		this.Type,
 		from,
 		to,
-		strconv.FormatInt(this.Value, 10),
+		[]byte(AmountString(this.Value)),
 		this.Time,
 		signature,
 	}
@@ -208,7 +213,7 @@ func ToTransactions(txn *badger.Txn) ([]*Transaction, error) {
 		if err != nil {
 			return nil, err
 		}
-		transaction, err := ToTransactionFromJson(value)
+		transaction, err := ToTransactionFromRecord(value)
 		if err != nil {
 			return nil, err
 		}
@@ -507,7 +512,7 @@ func ToTransactionByHash(txn *badger.Txn, hash string) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := ToTransactionFromJson(value)
+	transaction, err := ToTransactionFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -525,7 +530,7 @@ func ToTransactionByKey(txn *badger.Txn, key []byte) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := ToTransactionFromJson(value)
+	transaction, err := ToTransactionFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -550,7 +555,7 @@ func ToTransactionByAddress(txn *badger.Txn, address string) (*Transaction, erro
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := ToTransactionFromJson(value)
+	transaction, err := ToTransactionFromRecord(value)
 	if err != nil {
 		return nil, err
 	}
@@ -558,7 +563,7 @@ func ToTransactionByAddress(txn *badger.Txn, address string) (*Transaction, erro
 }
 
 // NewTransferTokensTransaction -
-func NewTransferTokensTransaction(privateKey string, from, to string, value int64, hertz int64, timeInMiliseconds int64) (*Transaction, error) {
+func NewTransferTokensTransaction(privateKey string, from, to string, value *big.Int, hertz int64, timeInMiliseconds int64) (*Transaction, error) {
 	var err error
 	transaction := &Transaction{}
 	transaction.Type = TypeTransferTokens
@@ -646,7 +651,7 @@ func NewUpdateTransaction(privateKey, from, version string, timeInMiliseconds in
 	transaction := &Transaction{}
 	transaction.Type = TypeUpdateCode
 	transaction.From = from
-	transaction.Value = 0
+	transaction.Value = big.NewInt(0)
 	transaction.Time, err = checkTime(timeInMiliseconds)
 	transaction.Params = version
 
@@ -685,7 +690,7 @@ func (this Transaction) NewHash() (string, error) {
 		this.Type,
 		fromBytes,
 		toBytes,
-		[]byte(strconv.FormatInt(this.Value, 10)),
+		[]byte(AmountString(this.Value)),
 		codeBytes,
 		[]byte(this.Method),
 		[]byte(this.Params),
@@ -756,7 +761,7 @@ func (this Transaction) Verify() error {
 		if len(this.To) != crypto.AddressLength*2 {
 			return errors.New("invalid to address")
 		}
-		if this.Value <= 0 {
+		if this.Value == nil || this.Value.Sign() <= 0 {
 			return errors.New("value cannot be less than or equal to zero")
 		}
 		break
@@ -871,21 +876,18 @@ func (this *Transaction) UnmarshalJSON(bytes []byte) error {
 		}
 	}
 	if jsonMap["value"] != nil {
-		var vFloat float64
-		value, ok1 := jsonMap["value"].(string)
-		if !ok1 {
-			var ok2 bool
-			vFloat, ok2 = jsonMap["value"].(float64)
-			if !ok2 {
-				return errors.Errorf("value for field 'value' must be a string")
-			}
-		} else {
-			vFloat, error = strconv.ParseFloat(value, 64)
-			if error != nil {
-			  return errors.Errorf("value for field 'value' must be convertable to an integer")
-			}
+		// Decode the raw value, jsonMap numbers are float64 and would lose precision.
+		var amounts struct {
+			Value json.RawMessage `json:"value"`
+		}
+		error = json.Unmarshal(bytes, &amounts)
+		if error != nil {
+			return error
+		}
+		this.Value, error = ToAmountFromJson(amounts.Value)
+		if error != nil {
+			return errors.Errorf("value for field 'value' must be convertable to an integer")
 		}
-		this.Value = int64(vFloat)
 	}
 	if jsonMap["code"] != nil {
 		this.Code, ok = jsonMap["code"].(string)
@@ -965,7 +967,7 @@ func (this Transaction) MarshalJSON() ([]byte, error) {
 		Type      byte    `json:"type"`
 		From      string  `json:"from"`
 		To        string  `json:"to,omitempty"`
-		Value     int64   `json:"value,omitempty,string"`
+		Value     string  `json:"value,omitempty"`
 		Code      string  `json:"code,omitempty"`
 		Abi       string  `json:"abi,omitempty"`
 		Method    string  `json:"method,omitempty"`
@@ -982,7 +984,7 @@ func (this Transaction) MarshalJSON() ([]byte, error) {
 		Type: this.Type,
 		From:      this.From,
 		To:        this.To,
-		Value:     this.Value,
+		Value:     this.valueString(),
 		Code:      this.Code,
 		Abi:       this.Abi,
 		Method:    this.Method,
@@ -997,6 +999,14 @@ func (this Transaction) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// valueString - zero values are omitted from JSON
+func (this Transaction) valueString() string {
+	if this.Value == nil || this.Value.Sign() == 0 {
+		return ""
+	}
+	return this.Value.String()
+}
+
 // Equals
 func (this Transaction) Equals(other string) bool {
 	return this.Hash == other
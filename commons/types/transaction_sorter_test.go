@@ -4,6 +4,7 @@ import (
 	"time"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"testing"
+	"math/big"
 )
 
 func getMockTransaction(value int64) *Transaction {
@@ -11,7 +12,7 @@ func getMockTransaction(value int64) *Transaction {
 		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a",
 		"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
 		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
-		value,
+		big.NewInt(value),
 		0,
 		utils.ToMilliSeconds(time.Now()),
 	)
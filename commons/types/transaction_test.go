@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 	"reflect"
+	"math/big"
 )
 
 //testMockTransaction
@@ -37,7 +38,7 @@ func testMockTransaction(t *testing.T) *Transaction {
 		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a",
 		"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
 		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
-		1,
+		big.NewInt(1),
 		0,
 		utils.ToMilliSeconds(d),
 	)
@@ -49,6 +50,49 @@ func testMockTransaction(t *testing.T) *Transaction {
 	//}
 }
 
+//TestTransactionValueBeyondInt64
+func TestTransactionValueBeyondInt64(t *testing.T) {
+	value, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tx, err := NewTransferTokensTransaction(
+		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a",
+		"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
+		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
+		value,
+		0,
+		utils.ToMilliSeconds(time.Now()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.Value.Cmp(value) != 0 {
+		t.Errorf("value did not survive a JSON round trip: %s", testTx.Value)
+	}
+	if err = testTx.Verify(); err != nil {
+		t.Error(err)
+	}
+}
+
+//TestTransactionLegacyValue
+func TestTransactionLegacyValue(t *testing.T) {
+	for _, value := range []string{`1000`, `"1000"`, `1e3`, `"1000.0"`} {
+		tx, err := ToTransactionFromJson([]byte(`{"type":0,"value":` + value + `}`))
+		if err != nil {
+			t.Fatalf("unable to parse value %s: %v", value, err)
+		}
+		if tx.Value.Int64() != 1000 {
+			t.Errorf("value %s parsed as %s", value, tx.Value)
+		}
+	}
+	_, err := ToTransactionFromJson([]byte(`{"type":0,"value":"10.5"}`))
+	if err == nil {
+		t.Error("fractional value should not parse")
+	}
+}
+
 //TestTransactionCache
 func TestTransactionCache(t *testing.T) {
 	tx := testMockTransaction(t)
@@ -111,7 +155,7 @@ func TestPrintTransaction(t *testing.T) {
 		privateKey,
 		from,
 		"d5765c93699c96327753230ac3d78edb3b34236b",
-		1,
+		big.NewInt(1),
 		1,
 		theTime,
 	)
@@ -353,7 +397,7 @@ func TestBadKeyTransaction(t *testing.T) {
 		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25",
 		"7777f2b40aacbef5a5127f65418dc5f951280833",
 		"0e19046b35344383ac0a27c1902fdc1c8c060fa9",
-		1,
+		big.NewInt(1),
 		0,
 		utils.ToMilliSeconds(time.Now()),
 		//codeBytes,
@@ -372,7 +416,7 @@ func TestBadFutureTime(t *testing.T) {
 		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a",
 		"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
 		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
-		1,
+		big.NewInt(1),
 		0,
 		utils.ToMilliSeconds(time.Now()) + int64(10000),
 	)
@@ -388,7 +432,7 @@ func TestBadNegativeTime(t *testing.T) {
 		"0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a",
 		"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
 		"d70613f93152c84050e7826c4e2b0cc02c1c3b99",
-		1,
+		big.NewInt(1),
 		0,
 		-1,
 	)
@@ -15,6 +15,40 @@ const (
 	defaultVersion = "3.1.0"
 )
 
+// ProtocolVersion - Bumped whenever transaction hashing, storage or peer messages change incompatibly. Nodes before protocol versions
+// were exchanged are version 0, version 2 signs gRPC requests which delegates require of each other, version 3 binds discovery
+// authentications to the fields of the authenticated node.
+const (
+	ProtocolVersion    int64 = 3
+	MinProtocolVersion int64 = 3
+)
+
+// Capabilities - Optional protocol features a node supports
+const (
+	CapabilityAntiEntropy    = "antiEntropy"
+	CapabilityStateSync      = "stateSync"
+	CapabilityKademlia       = "kademlia"
+	CapabilitySignedRequests = "signedRequests"
+)
+
+// GetCapabilities - The capabilities of this node
+func GetCapabilities() []string {
+	return []string{CapabilityAntiEntropy, CapabilityStateSync, CapabilityKademlia, CapabilitySignedRequests}
+}
+
+// GetMinProtocolVersion - Config.MinProtocolVersion or MinProtocolVersion
+func GetMinProtocolVersion() int64 {
+	if GetConfig().MinProtocolVersion != nil {
+		return *GetConfig().MinProtocolVersion
+	}
+	return MinProtocolVersion
+}
+
+// IsCompatibleProtocol
+func IsCompatibleProtocol(protocolVersion int64) bool {
+	return protocolVersion >= GetMinProtocolVersion()
+}
+
 type Version struct {
 	Version		string
 	BuildTime	string
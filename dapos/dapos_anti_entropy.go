@@ -0,0 +1,739 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/dispatchlabs/disgo/disgover"
+	"golang.org/x/net/context"
+)
+
+// Anti-entropy compares the merkle roots of transaction hashes bucketed by time with a peer delegate, recursing only
+// into the time ranges whose roots differ, and then pulls the transactions this node is missing. The root of a range is
+// the merkle root of the roots of its non-empty buckets in time order, so the roots of closed buckets can be cached.
+const (
+	antiEntropyBucket     int64 = 60000 // Milliseconds, the smallest range that is split
+	antiEntropyFanout     int64 = 16    // Subranges a differing range is split into
+	antiEntropyLeafCount  int64 = 256   // Ranges with at most this many transactions are compared hash by hash
+	antiEntropyBatchSize        = 50
+	antiEntropyMaxRanges        = 64
+	antiEntropyMaxHashes        = 4096 // Per page of a range's hashes
+	antiEntropyInterval         = 10 * time.Minute
+	antiEntropySettleTime       = time.Minute // Transactions still being gossiped are left to gossip
+	antiEntropyTimeout          = 5 * time.Minute
+)
+
+// timeKeyPrefix
+const timeKeyPrefix = "key-transaction-time-"
+
+// timeKey - a transaction time index key split into time and hash
+type timeKey struct {
+	time int64
+	hash string
+}
+
+// String - <time>-<hash>, the after of a range hashes page
+func (this timeKey) String() string {
+	return fmt.Sprintf("%d-%s", this.time, this.hash)
+}
+
+// isAfter - (time, hash) order
+func (this timeKey) isAfter(other timeKey) bool {
+	return this.time > other.time || (this.time == other.time && this.hash > other.hash)
+}
+
+// SynchronizeRangesGrpc - PROTO - Returns the merkle root and count of the transactions in each range
+func (this *DAPoSService) SynchronizeRangesGrpc(context context.Context, request *proto.SynchronizeRangesRequest) (*proto.SynchronizeRangesResponse, error) {
+	if len(request.Ranges) > antiEntropyMaxRanges {
+		return nil, fmt.Errorf("too many ranges [count=%d, max=%d]", len(request.Ranges), antiEntropyMaxRanges)
+	}
+	ranges, err := getRangeSummaries(request.Ranges)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	return &proto.SynchronizeRangesResponse{Ranges: ranges}, nil
+}
+
+// SynchronizeRangeHashesGrpc - PROTO - Returns a page of the hashes of the transactions in a range in (time, hash) order
+func (this *DAPoSService) SynchronizeRangeHashesGrpc(context context.Context, request *proto.Range) (*proto.SynchronizeRangeHashesResponse, error) {
+	start := request.Start
+	var after *timeKey
+	if request.After != "" {
+		key, ok := toTimeKey(timeKeyPrefix + request.After)
+		if !ok || key.time < request.Start || key.time >= request.End {
+			return nil, fmt.Errorf("invalid range page [after=%s]", request.After)
+		}
+		after = &key
+		start = key.time
+	}
+	response := &proto.SynchronizeRangeHashesResponse{}
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		var last timeKey
+		return forEachTimeKey(txn, start, request.End, func(key timeKey) bool {
+			if after != nil && !key.isAfter(*after) {
+				return true
+			}
+			if len(response.Hashes) == antiEntropyMaxHashes {
+				response.Next = last.String()
+				return false
+			}
+			response.Hashes = append(response.Hashes, key.hash)
+			last = key
+			return true
+		})
+	})
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// SynchronizeByHashesGrpc - PROTO - Returns the transactions and gossips for the requested hashes
+func (this *DAPoSService) SynchronizeByHashesGrpc(context context.Context, request *proto.SynchronizeByHashesRequest) (*proto.SynchronizeByHashesResponse, error) {
+	if len(request.Hashes) > antiEntropyBatchSize {
+		return nil, fmt.Errorf("too many hashes [count=%d, max=%d]", len(request.Hashes), antiEntropyBatchSize)
+	}
+	response := &proto.SynchronizeByHashesResponse{}
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	for _, hash := range request.Hashes {
+		transaction, err := types.ToTransactionByKey(txn, []byte(types.Transaction{Hash: hash}.Key()))
+		if err != nil {
+			utils.Debug(fmt.Sprintf("unable to find transaction [hash=%s]", hash), err)
+			continue
+		}
+		response.Transactions = append(response.Transactions, convertToProtoTransaction(transaction))
+		gossip, err := types.ToGossipByKey(txn, []byte(fmt.Sprintf("table-gossip-%s", hash)))
+		if err != nil {
+			utils.Debug(fmt.Sprintf("unable to find gossip [hash=%s]", hash), err)
+			continue
+		}
+		response.Gossips = append(response.Gossips, convertToProtoGossip(gossip))
+	}
+	return response, nil
+}
+
+// getRangeSummaries - merkle root and count of the local transactions in each range
+func getRangeSummaries(ranges []*proto.Range) ([]*proto.Range, error) {
+	summaries := make([]*proto.Range, 0, len(ranges))
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		for _, r := range ranges {
+			root, count, err := rangeSummaries.get(txn, r.Start, r.End)
+			if err != nil {
+				return err
+			}
+			summaries = append(summaries, &proto.Range{Start: r.Start, End: r.End, Root: root, Count: count})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// getLocalRangeHashes - hashes of the local transactions in r
+func getLocalRangeHashes(r *proto.Range) ([]string, error) {
+	hashes := make([]string, 0)
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		return forEachTimeKey(txn, r.Start, r.End, func(key timeKey) bool {
+			hashes = append(hashes, key.hash)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// bucketSummary - merkle root of the sorted hashes of a bucket and their count
+type bucketSummary struct {
+	root  string
+	count int64
+}
+
+// getBucket - start of the bucket of t
+func getBucket(t int64) int64 {
+	return t - t%antiEntropyBucket
+}
+
+// scanBuckets - calls fn in time order with the summary of each non-empty bucket in [start, end), a bucket cut by the
+// range only summarizes the hashes in the range
+func scanBuckets(txn *badger.Txn, start, end int64, fn func(bucket int64, summary bucketSummary)) error {
+	hashes := make([]string, 0)
+	var bucket int64
+	flush := func() {
+		if len(hashes) == 0 {
+			return
+		}
+		sort.Strings(hashes)
+		fn(bucket, bucketSummary{root: string(getMerkleRoot(hashes)), count: int64(len(hashes))})
+		hashes = hashes[:0]
+	}
+	err := forEachTimeKey(txn, start, end, func(key timeKey) bool {
+		if getBucket(key.time) != bucket {
+			flush()
+			bucket = getBucket(key.time)
+		}
+		hashes = append(hashes, key.hash)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	flush()
+	return nil
+}
+
+// foldBuckets - root and count of a range from the summaries of its buckets in time order
+func foldBuckets(summaries []bucketSummary) ([]byte, int64) {
+	roots := make([]string, 0, len(summaries))
+	count := int64(0)
+	for _, summary := range summaries {
+		roots = append(roots, summary.root)
+		count += summary.count
+	}
+	return getMerkleRoot(roots), count
+}
+
+// getRangeSummary - merkle root and count of the transactions in [start, end) read from txn, without the cache
+func getRangeSummary(txn *badger.Txn, start, end int64) ([]byte, int64, error) {
+	summaries := make([]bucketSummary, 0)
+	err := scanBuckets(txn, start, end, func(bucket int64, summary bucketSummary) {
+		summaries = append(summaries, summary)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	root, count := foldBuckets(summaries)
+	return root, count, nil
+}
+
+// rangeSummaries
+var rangeSummaries = newRangeSummaryCache()
+
+// rangeSummaryCache - summaries of the closed buckets, every bucket before closedUntil has been summarized and the non-empty
+// ones are kept. Writes of transactions into a closed bucket must refresh it once committed.
+type rangeSummaryCache struct {
+	mutex       sync.Mutex
+	closedUntil int64
+	buckets     map[int64]bucketSummary
+	starts      []int64 // Sorted
+}
+
+// newRangeSummaryCache
+func newRangeSummaryCache() *rangeSummaryCache {
+	return &rangeSummaryCache{buckets: map[int64]bucketSummary{}}
+}
+
+// get - like getRangeSummary, the closed buckets wholly in [start, end) are read from the cache
+func (this *rangeSummaryCache) get(txn *badger.Txn, start, end int64) ([]byte, int64, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	err := this.close(antiEntropyEnd())
+	if err != nil {
+		return nil, 0, err
+	}
+	if start >= this.closedUntil {
+		return getRangeSummary(txn, start, end)
+	}
+	from := getBucket(start)
+	if from < start {
+		from += antiEntropyBucket
+	}
+	to := getBucket(end)
+	if to > this.closedUntil {
+		to = this.closedUntil
+	}
+	if from >= to {
+		return getRangeSummary(txn, start, end)
+	}
+
+	summaries := make([]bucketSummary, 0)
+	collect := func(bucket int64, summary bucketSummary) {
+		summaries = append(summaries, summary)
+	}
+	err = scanBuckets(txn, start, from, collect)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := sort.Search(len(this.starts), func(i int) bool { return this.starts[i] >= from }); i < len(this.starts) && this.starts[i] < to; i++ {
+		summaries = append(summaries, this.buckets[this.starts[i]])
+	}
+	err = scanBuckets(txn, to, end, collect)
+	if err != nil {
+		return nil, 0, err
+	}
+	root, count := foldBuckets(summaries)
+	return root, count, nil
+}
+
+// close - summarizes the buckets that closed since the last call, caller holds the lock. A fresh view is read so that a
+// transaction committed before it is included and one committed after it refreshes its bucket.
+func (this *rangeSummaryCache) close(until int64) error {
+	if until <= this.closedUntil {
+		return nil
+	}
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		return scanBuckets(txn, this.closedUntil, until, this.set)
+	})
+	if err != nil {
+		return err
+	}
+	this.closedUntil = until
+	return nil
+}
+
+// refresh - summarizes again the closed buckets of times after transactions were committed in them
+func (this *rangeSummaryCache) refresh(times ...int64) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	buckets := map[int64]bool{}
+	for _, t := range times {
+		if getBucket(t) < this.closedUntil {
+			buckets[getBucket(t)] = true
+		}
+	}
+	if len(buckets) == 0 {
+		return
+	}
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		for bucket := range buckets {
+			this.remove(bucket)
+			err := scanBuckets(txn, bucket, bucket+antiEntropyBucket, this.set)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.Error("unable to refresh range summaries", err)
+		this.reset()
+	}
+}
+
+// set - caller holds the lock
+func (this *rangeSummaryCache) set(bucket int64, summary bucketSummary) {
+	if _, ok := this.buckets[bucket]; !ok {
+		i := sort.Search(len(this.starts), func(i int) bool { return this.starts[i] >= bucket })
+		this.starts = append(this.starts, 0)
+		copy(this.starts[i+1:], this.starts[i:])
+		this.starts[i] = bucket
+	}
+	this.buckets[bucket] = summary
+}
+
+// remove - caller holds the lock
+func (this *rangeSummaryCache) remove(bucket int64) {
+	if _, ok := this.buckets[bucket]; !ok {
+		return
+	}
+	delete(this.buckets, bucket)
+	i := sort.Search(len(this.starts), func(i int) bool { return this.starts[i] >= bucket })
+	this.starts = append(this.starts[:i], this.starts[i+1:]...)
+}
+
+// reset - caller holds the lock, every bucket is summarized again on the next get
+func (this *rangeSummaryCache) reset() {
+	this.closedUntil = 0
+	this.buckets = map[int64]bucketSummary{}
+	this.starts = nil
+}
+
+// forEachTimeKey - calls fn in (time, hash) order for the time index keys with start <= time < end until it returns false.
+func forEachTimeKey(txn *badger.Txn, start, end int64, fn func(key timeKey) bool) error {
+	return forEachTimeIndexKey(txn, timeKeyPrefix, start, end, func(item *badger.Item, t int64) bool {
+		key, ok := toTimeKey(string(item.Key()))
+		if !ok {
+			return true
+		}
+		return fn(key)
+	})
+}
+
+// forEachTimeIndexKey - calls fn in time order for the keys of an index of <prefix><time>[-<suffix>] with start <= time < end
+// until it returns false. Times are not zero padded so keys only sort by time among times with the same number of digits,
+// each length is seeked and stopped separately.
+func forEachTimeIndexKey(txn *badger.Txn, prefix string, start, end int64, fn func(item *badger.Item, t int64) bool) error {
+	if start < 0 {
+		start = 0
+	}
+	if start >= end {
+		return nil
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+
+	for digits := timeDigits(start); digits <= timeDigits(end-1); digits++ {
+		lower, upper := start, end
+		if first := firstTimeWithDigits(digits); lower < first {
+			lower = first
+		}
+		if first := firstTimeWithDigits(digits + 1); upper > first {
+			upper = first
+		}
+
+		// '.' sorts right after the '-' separating time and suffix
+		stop := []byte(prefix + strconv.FormatInt(upper-1, 10) + ".")
+		for iterator.Seek([]byte(prefix + strconv.FormatInt(lower, 10))); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+			if bytes.Compare(iterator.Item().Key(), stop) >= 0 {
+				break
+			}
+			t, ok := toIndexTime(string(iterator.Item().Key()), prefix)
+			if !ok || timeDigits(t) != digits || t < lower || t >= upper {
+				continue
+			}
+			if !fn(iterator.Item(), t) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// toIndexTime - parses the time of <prefix><time>[-<suffix>]
+func toIndexTime(key, prefix string) (int64, bool) {
+	value := strings.TrimPrefix(key, prefix)
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		value = value[:i]
+	}
+	t, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || t < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// timeDigits - decimal digits of a non negative time
+func timeDigits(t int64) int {
+	return len(strconv.FormatInt(t, 10))
+}
+
+// firstTimeWithDigits
+func firstTimeWithDigits(digits int) int64 {
+	if digits <= 1 {
+		return 0
+	}
+	first := int64(1)
+	for i := 1; i < digits; i++ {
+		if first > math.MaxInt64/10 {
+			return math.MaxInt64
+		}
+		first *= 10
+	}
+	return first
+}
+
+// getMerkleRoot - Folds the SHA-256 of each hash pairwise up to a root, an odd node is carried up a level as is. Nil for
+// no hashes.
+func getMerkleRoot(hashes []string) []byte {
+	if len(hashes) == 0 {
+		return nil
+	}
+	level := make([][]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		leaf := sha256.Sum256([]byte(hash))
+		level = append(level, leaf[:])
+	}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			node := sha256.Sum256(append(append(make([]byte, 0, 2*sha256.Size), level[i]...), level[i+1]...))
+			next = append(next, node[:])
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// toTimeKey - parses key-transaction-time-<time>-<hash>
+func toTimeKey(key string) (timeKey, bool) {
+	values := strings.Split(strings.TrimPrefix(key, timeKeyPrefix), "-")
+	if len(values) != 2 {
+		return timeKey{}, false
+	}
+	t, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return timeKey{}, false
+	}
+	return timeKey{time: t, hash: values[1]}, true
+}
+
+// splitRange - bucket aligned subranges of r
+func splitRange(r *proto.Range) []*proto.Range {
+	buckets := (r.End - r.Start + antiEntropyBucket - 1) / antiEntropyBucket
+	step := (buckets + antiEntropyFanout - 1) / antiEntropyFanout * antiEntropyBucket
+	subranges := make([]*proto.Range, 0, antiEntropyFanout)
+	for start := r.Start; start < r.End; start += step {
+		end := start + step
+		if end > r.End {
+			end = r.End
+		}
+		subranges = append(subranges, &proto.Range{Start: start, End: end})
+	}
+	return subranges
+}
+
+// findMissingTransactions - hashes of the transactions a peer has in [start, end) that this node does not
+func findMissingTransactions(ctx context.Context, client proto.DAPoSGrpcClient, start, end int64) ([]string, error) {
+	missing := make([]string, 0)
+	pending := []*proto.Range{{Start: start, End: end}}
+	for len(pending) > 0 {
+		batch := pending
+		if len(batch) > antiEntropyMaxRanges {
+			batch = pending[:antiEntropyMaxRanges]
+		}
+		pending = pending[len(batch):]
+
+		response, err := client.SynchronizeRangesGrpc(ctx, &proto.SynchronizeRangesRequest{Ranges: batch})
+		if err != nil {
+			return nil, err
+		}
+		if len(response.Ranges) != len(batch) {
+			return nil, fmt.Errorf("peer returned %d ranges for %d requested", len(response.Ranges), len(batch))
+		}
+		local, err := getRangeSummaries(batch)
+		if err != nil {
+			return nil, err
+		}
+		for i, remote := range response.Ranges {
+			if remote.Count == 0 || string(remote.Root) == string(local[i].Root) {
+				continue
+			}
+			if remote.Count > antiEntropyLeafCount && batch[i].End-batch[i].Start > antiEntropyBucket {
+				pending = append(pending, splitRange(batch[i])...)
+				continue
+			}
+			hashes, err := getRangeHashes(ctx, client, batch[i], remote.Count)
+			if err != nil {
+				return nil, err
+			}
+			localHashes, err := getLocalRangeHashes(batch[i])
+			if err != nil {
+				return nil, err
+			}
+			known := make(map[string]bool, len(localHashes))
+			for _, hash := range localHashes {
+				known[hash] = true
+			}
+			for _, hash := range hashes {
+				if !known[hash] {
+					missing = append(missing, hash)
+				}
+			}
+		}
+	}
+	return missing, nil
+}
+
+// getRangeHashes - every hash a peer has in r, a peer returning more than the count it reported for r is refused
+func getRangeHashes(ctx context.Context, client proto.DAPoSGrpcClient, r *proto.Range, count int64) ([]string, error) {
+	hashes := make([]string, 0)
+	after := ""
+	for {
+		response, err := client.SynchronizeRangeHashesGrpc(ctx, &proto.Range{Start: r.Start, End: r.End, After: after})
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, response.Hashes...)
+		if int64(len(hashes)) > count {
+			return nil, fmt.Errorf("peer returned more hashes than it counted in range [start=%d, end=%d, count=%d]", r.Start, r.End, count)
+		}
+		if response.Next == "" {
+			return hashes, nil
+		}
+		if response.Next == after || len(response.Hashes) == 0 {
+			return nil, fmt.Errorf("peer did not advance range page [start=%d, end=%d, after=%s]", r.Start, r.End, after)
+		}
+		after = response.Next
+	}
+}
+
+// pullTransactions - the transactions and gossips for hashes, sorted by time
+func pullTransactions(ctx context.Context, client proto.DAPoSGrpcClient, hashes []string) ([]*types.Transaction, map[string]*types.Gossip, error) {
+	transactions := make([]*types.Transaction, 0, len(hashes))
+	gossips := map[string]*types.Gossip{}
+	for i := 0; i < len(hashes); i += antiEntropyBatchSize {
+		end := i + antiEntropyBatchSize
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		response, err := client.SynchronizeByHashesGrpc(ctx, &proto.SynchronizeByHashesRequest{Hashes: hashes[i:end]})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ptx := range response.Transactions {
+			transaction, err := convertToDomainTransaction(ptx)
+			if err != nil {
+				return nil, nil, err
+			}
+			transactions = append(transactions, transaction)
+		}
+		for _, pgossip := range response.Gossips {
+			gossips[pgossip.TxHash] = convertToDomainGossip(pgossip)
+		}
+	}
+	sortTransactions(transactions)
+	return transactions, gossips, nil
+}
+
+// isInOrder - a missing transaction can only be executed when no newer transaction has already changed its accounts,
+// otherwise it would be applied to state it never saw
+func isInOrder(transaction *types.Transaction) bool {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	txTime := utils.ToTimeFromMilliseconds(transaction.Time)
+	for _, address := range []string{transaction.From, transaction.To} {
+		if address == "" {
+			continue
+		}
+		account, err := types.ToAccountByAddress(txn, address)
+		if err != nil {
+			continue
+		}
+		if account.Updated.After(txTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// antiEntropyEnd - transactions newer than this are still being gossiped
+func antiEntropyEnd() int64 {
+	end := utils.ToMilliSeconds(time.Now().Add(-antiEntropySettleTime))
+	return end - end%antiEntropyBucket
+}
+
+// synchronizeWithDelegate - pulls the transactions a delegate has and this node does not
+func (this *DAPoSService) synchronizeWithDelegate(delegate *types.Node) ([]*types.Transaction, map[string]*types.Gossip, error) {
+	conn, err := services.GetGrpcConnection(delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port)
+	if err != nil {
+		return nil, nil, err
+	}
+	client := proto.NewDAPoSGrpcClient(conn)
+	contextWithTimeout, cancel := context.WithTimeout(context.Background(), antiEntropyTimeout)
+	defer cancel()
+
+	missing, err := findMissingTransactions(contextWithTimeout, client, 0, antiEntropyEnd())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(missing) == 0 {
+		return nil, nil, nil
+	}
+	utils.Info(fmt.Sprintf("found %d missing transactions on delegate [address=%s]", len(missing), delegate.Address))
+	return pullTransactions(contextWithTimeout, client, missing)
+}
+
+// getPeerDelegates - unbanned delegates other than this node, those without an endpoint are looked up by disgover
+func getPeerDelegates() ([]*types.Node, error) {
+	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]*types.Node, 0, len(delegates))
+	for _, delegate := range delegates {
+		if delegate.Address == disgover.GetDisGoverService().ThisNode.Address || disgover.GetDisGoverService().IsBanned(delegate.Address) {
+			continue
+		}
+		if delegate.GrpcEndpoint == nil {
+			node, err := disgover.GetDisGoverService().FindNode(delegate.Address)
+			if err != nil {
+				continue
+			}
+			found := *delegate
+			found.GrpcEndpoint = node.GrpcEndpoint
+			delegate = &found
+		}
+		peers = append(peers, delegate)
+	}
+	return peers, nil
+}
+
+// antiEntropyWorker - delegates periodically reconcile transactions with a random peer delegate
+func (this *DAPoSService) antiEntropyWorker() {
+	ticker := time.NewTicker(antiEntropyInterval)
+	for range ticker.C {
+		if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate || !this.IsSynchronized() {
+			continue
+		}
+		delegates, err := getPeerDelegates()
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		if len(delegates) == 0 {
+			continue
+		}
+		delegate := delegates[rand.Intn(len(delegates))]
+		served, servedGossips, err := this.synchronizeWithDelegate(delegate)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("anti-entropy with delegate failed [address=%s]", delegate.Address), err)
+			continue
+		}
+		if len(served) == 0 {
+			continue
+		}
+		history, err := getDelegateHistory()
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		transactions, gossips, contradictions := crossValidate([]*peerTransactions{{address: delegate.Address, transactions: served, gossips: servedGossips}}, history)
+		this.flagContradictions(contradictions)
+		executed := 0
+		outOfOrder := make([]*QuarantineEntry, 0)
+		for _, transaction := range transactions {
+			if !isInOrder(transaction) {
+				utils.Warn(fmt.Sprintf("missing transaction predates its accounts' state, a state sync is needed to apply it [hash=%s, time=%d]", transaction.Hash, transaction.Time))
+				outOfOrder = append(outOfOrder, &QuarantineEntry{Hash: transaction.Hash, Address: delegate.Address, Reason: quarantineReasonOutOfOrder, Time: time.Now(), Transaction: transaction, Gossip: gossips[transaction.Hash]})
+				continue
+			}
+			ExecuteTransaction(transaction, types.NewReceipt(transaction.Hash), gossips[transaction.Hash], false)
+			executed++
+		}
+		quarantine(outOfOrder)
+		if executed > 0 {
+			utils.Info(fmt.Sprintf("anti-entropy executed %d transactions from delegate [address=%s]", executed, delegate.Address))
+		}
+	}
+}
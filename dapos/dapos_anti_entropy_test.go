@@ -0,0 +1,303 @@
+package dapos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// testRemote - a peer delegate holding keys, the DAPoSGrpcClient methods anti-entropy does not call are nil
+type testRemote struct {
+	proto.DAPoSGrpcClient
+	keys []timeKey
+}
+
+// inRange
+func (this *testRemote) inRange(start, end int64) []timeKey {
+	keys := make([]timeKey, 0)
+	for _, key := range this.keys {
+		if key.time >= start && key.time < end {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[j].isAfter(keys[i]) })
+	return keys
+}
+
+// SynchronizeRangesGrpc
+func (this *testRemote) SynchronizeRangesGrpc(ctx context.Context, in *proto.SynchronizeRangesRequest, opts ...grpc.CallOption) (*proto.SynchronizeRangesResponse, error) {
+	response := &proto.SynchronizeRangesResponse{}
+	for _, r := range in.Ranges {
+		summaries := make([]bucketSummary, 0)
+		hashes := make([]string, 0)
+		keys := this.inRange(r.Start, r.End)
+		for i, key := range keys {
+			hashes = append(hashes, key.hash)
+			if i+1 == len(keys) || getBucket(keys[i+1].time) != getBucket(key.time) {
+				sort.Strings(hashes)
+				summaries = append(summaries, bucketSummary{root: string(getMerkleRoot(hashes)), count: int64(len(hashes))})
+				hashes = make([]string, 0)
+			}
+		}
+		root, count := foldBuckets(summaries)
+		response.Ranges = append(response.Ranges, &proto.Range{Start: r.Start, End: r.End, Root: root, Count: count})
+	}
+	return response, nil
+}
+
+// SynchronizeRangeHashesGrpc
+func (this *testRemote) SynchronizeRangeHashesGrpc(ctx context.Context, in *proto.Range, opts ...grpc.CallOption) (*proto.SynchronizeRangeHashesResponse, error) {
+	response := &proto.SynchronizeRangeHashesResponse{}
+	after, _ := toTimeKey(timeKeyPrefix + in.After)
+	var last timeKey
+	for _, key := range this.inRange(in.Start, in.End) {
+		if in.After != "" && !key.isAfter(after) {
+			continue
+		}
+		if len(response.Hashes) == antiEntropyMaxHashes {
+			response.Next = last.String()
+			break
+		}
+		response.Hashes = append(response.Hashes, key.hash)
+		last = key
+	}
+	return response, nil
+}
+
+// testLocal - the local service as a peer
+type testLocal struct {
+	proto.DAPoSGrpcClient
+}
+
+// SynchronizeRangeHashesGrpc
+func (this *testLocal) SynchronizeRangeHashesGrpc(ctx context.Context, in *proto.Range, opts ...grpc.CallOption) (*proto.SynchronizeRangeHashesResponse, error) {
+	return (&DAPoSService{}).SynchronizeRangeHashesGrpc(ctx, in)
+}
+
+// setTimeKeys - the range summary cache is reset as the keys are written directly
+func setTimeKeys(t *testing.T, keys []timeKey) {
+	rangeSummaries.mutex.Lock()
+	rangeSummaries.reset()
+	rangeSummaries.mutex.Unlock()
+	for i := 0; i < len(keys); i += 1000 {
+		values := map[string]string{}
+		for _, key := range keys[i:int(math.Min(float64(i+1000), float64(len(keys))))] {
+			values[timeKeyPrefix+key.String()] = "table-transaction-" + key.hash
+		}
+		setTestKeys(t, values)
+	}
+}
+
+// TestForEachTimeKey
+func TestForEachTimeKey(t *testing.T) {
+	defer deleteTestKeys(t, timeKeyPrefix)
+	setTimeKeys(t, []timeKey{
+		{0, "a"}, {5, "a"}, {42, "a"}, {1600000000000, "b"}, {1600000000000, "a"}, {1600000059999, "a"},
+		{1600000060000, "a"}, {160000000000, "a"}, {16000000000005, "a"},
+	})
+	tests := []struct {
+		start, end int64
+		expected   []timeKey
+	}{
+		{0, 100, []timeKey{{0, "a"}, {5, "a"}, {42, "a"}}},
+		{1600000000000, 1600000060000, []timeKey{{1600000000000, "a"}, {1600000000000, "b"}, {1600000059999, "a"}}},
+		{100, 1600000000000, []timeKey{{160000000000, "a"}}},
+		{1600000060000, math.MaxInt64, []timeKey{{1600000060000, "a"}, {16000000000005, "a"}}},
+		{42, 42, []timeKey{}},
+	}
+	for _, test := range tests {
+		keys := make([]timeKey, 0)
+		err := services.GetDb().View(func(txn *badger.Txn) error {
+			return forEachTimeKey(txn, test.start, test.end, func(key timeKey) bool {
+				keys = append(keys, key)
+				return true
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("[%d, %d) returned %v, expected %v", test.start, test.end, keys, test.expected)
+		}
+	}
+}
+
+// TestSynchronizeRangeHashesPaging - a range with more hashes than a page is returned whole
+func TestSynchronizeRangeHashesPaging(t *testing.T) {
+	defer deleteTestKeys(t, timeKeyPrefix)
+	start := int64(1600000000000)
+	keys := make([]timeKey, 0)
+	for i := 0; i < antiEntropyMaxHashes*2+10; i++ {
+		keys = append(keys, timeKey{start + int64(i%7), fmt.Sprintf("%064x", i)})
+	}
+	setTimeKeys(t, keys)
+
+	r := &proto.Range{Start: start, End: start + antiEntropyBucket}
+	hashes, err := getRangeHashes(context.Background(), &testLocal{}, r, int64(len(keys)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unique := map[string]bool{}
+	for _, hash := range hashes {
+		unique[hash] = true
+	}
+	if len(hashes) != len(keys) || len(unique) != len(keys) {
+		t.Errorf("returned %d hashes (%d unique), expected %d", len(hashes), len(unique), len(keys))
+	}
+
+	if _, err = getRangeHashes(context.Background(), &testLocal{}, r, int64(len(keys)-1)); err == nil {
+		t.Error("more hashes than counted were accepted")
+	}
+	if _, err = (&DAPoSService{}).SynchronizeRangeHashesGrpc(context.Background(), &proto.Range{Start: start, End: start + 1, After: "5-x"}); err == nil {
+		t.Error("page after a time outside the range was accepted")
+	}
+}
+
+// TestFindMissingTransactions
+func TestFindMissingTransactions(t *testing.T) {
+	defer deleteTestKeys(t, timeKeyPrefix)
+	start := int64(1600000000000)
+	remote := &testRemote{}
+	local := make([]timeKey, 0)
+	expected := make([]string, 0)
+	for i := 0; i < 2000; i++ {
+		key := timeKey{start + int64(i)*97, fmt.Sprintf("%064x", i)}
+		remote.keys = append(remote.keys, key)
+		if i%13 == 0 {
+			expected = append(expected, key.hash)
+			continue
+		}
+		local = append(local, key)
+	}
+
+	// Only this node has it
+	local = append(local, timeKey{start + 5, fmt.Sprintf("%064x", 99999)})
+	setTimeKeys(t, local)
+
+	missing, err := findMissingTransactions(context.Background(), remote, 0, start+2000*97)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(missing)
+	sort.Strings(expected)
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("found %d missing transactions, expected %d", len(missing), len(expected))
+	}
+}
+
+// TestRangeSummaryCache - cached summaries match a scan of the range and are refreshed after a closed bucket changes
+func TestRangeSummaryCache(t *testing.T) {
+	defer deleteTestKeys(t, timeKeyPrefix)
+	start := int64(1600000000000)
+	keys := make([]timeKey, 0)
+	for i := 0; i < 500; i++ {
+		keys = append(keys, timeKey{start + int64(i)*997, fmt.Sprintf("%064x", i)})
+	}
+	setTimeKeys(t, keys)
+
+	ranges := []*proto.Range{
+		{Start: 0, End: antiEntropyEnd()},
+		{Start: start, End: start + 10*antiEntropyBucket},
+		{Start: start + 123, End: start + 5*antiEntropyBucket + 456},
+		{Start: start + 7, End: start + 9},
+		{Start: start + 3*antiEntropyBucket, End: math.MaxInt64},
+		{Start: start, End: start - 1},
+	}
+	check := func() {
+		summaries, err := getRangeSummaries(ranges)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, r := range ranges {
+			var root []byte
+			var count int64
+			err = services.GetDb().View(func(txn *badger.Txn) error {
+				root, count, err = getRangeSummary(txn, r.Start, r.End)
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(summaries[i].Root, root) || summaries[i].Count != count {
+				t.Errorf("[%d, %d) returned count %d, expected %d", r.Start, r.End, summaries[i].Count, count)
+			}
+		}
+	}
+	check()
+	if len(rangeSummaries.starts) == 0 {
+		t.Fatal("no buckets were cached")
+	}
+
+	transaction := &types.Transaction{Hash: fmt.Sprintf("%064x", 99999), Time: start + 5}
+	setTestKeys(t, map[string]string{transaction.TimeKey(): transaction.Key()})
+	rangeSummaries.refresh(transaction.Time)
+	check()
+}
+
+// TestIsInOrder
+func TestIsInOrder(t *testing.T) {
+	defer deleteTestKeys(t, "table-account-")
+	updated := time.Now().Truncate(time.Millisecond)
+	account := &types.Account{Address: "1111111111111111111111111111111111111111", Balance: big.NewInt(1), Created: updated, Updated: updated}
+	txn := services.NewTxn(true)
+	if err := account.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ms := utils.ToMilliSeconds(updated)
+	other := "2222222222222222222222222222222222222222"
+	tests := []struct {
+		transaction *types.Transaction
+		expected    bool
+	}{
+		{&types.Transaction{From: account.Address, To: other, Time: ms - 1}, false},
+		{&types.Transaction{From: other, To: account.Address, Time: ms - 1}, false},
+		{&types.Transaction{From: account.Address, To: other, Time: ms}, true},
+		{&types.Transaction{From: other, To: account.Address, Time: ms + 1}, true},
+		{&types.Transaction{From: other, Time: 1}, true},
+	}
+	for i, test := range tests {
+		if isInOrder(test.transaction) != test.expected {
+			t.Errorf("test %d: isInOrder returned %t", i, !test.expected)
+		}
+	}
+}
+
+// TestGetMerkleRoot
+func TestGetMerkleRoot(t *testing.T) {
+	if getMerkleRoot(nil) != nil {
+		t.Error("root of no hashes is not nil")
+	}
+	leaf := sha256.Sum256([]byte("a"))
+	if !bytes.Equal(getMerkleRoot([]string{"a"}), leaf[:]) {
+		t.Error("root of one hash is not its leaf")
+	}
+	roots := map[string][]string{}
+	for _, hashes := range [][]string{{"a", "b"}, {"b", "a"}, {"a", "b", "c"}, {"a", "b", "c", "c"}, {"a", "b", "c", "d"}} {
+		root := getMerkleRoot(hashes)
+		if !bytes.Equal(root, getMerkleRoot(append([]string{}, hashes...))) {
+			t.Errorf("root of %v is not deterministic", hashes)
+		}
+		roots[string(root)] = append(roots[string(root)], fmt.Sprint(hashes))
+	}
+	if len(roots) != 5 {
+		t.Errorf("roots collide: %v", roots)
+	}
+}
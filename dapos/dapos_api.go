@@ -18,6 +18,7 @@ package dapos
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/dgraph-io/badger"
@@ -27,6 +28,8 @@ import (
 	"github.com/dispatchlabs/disgo/disgover"
 	"time"
 	"github.com/dispatchlabs/disgo/commons/helper"
+	"encoding/hex"
+	"github.com/pkg/errors"
 )
 
 // GetDelegateNodes
@@ -70,6 +73,51 @@ func (this *DAPoSService) GetDelegateNodes() *types.Response {
 	return response
 }
 
+// DelegateHealth - a delegate and its liveness as seen by this node
+type DelegateHealth struct {
+	*types.Node
+	Health *disgover.NodeHealth `json:"health"`
+}
+
+// GetDelegateHealth - delegates with their heartbeat health, healthiest first
+func (this *DAPoSService) GetDelegateHealth() *types.Response {
+	response := this.GetDelegateNodes()
+	nodes, ok := response.Data.([]*types.Node)
+	if !ok {
+		return response
+	}
+	delegates := make([]*DelegateHealth, 0, len(nodes))
+	for _, node := range nodes {
+		delegates = append(delegates, &DelegateHealth{Node: node, Health: disgover.GetDisGoverService().GetHealth(node.Address)})
+	}
+	sort.SliceStable(delegates, func(i, j int) bool {
+		return getScore(delegates[i].Health) > getScore(delegates[j].Health)
+	})
+	response.Data = delegates
+	return response
+}
+
+// GetDelegateSets - The signed delegate sets this node accepted, newest first
+func (this *DAPoSService) GetDelegateSets() *types.Response {
+	delegateSets, err := disgover.GetDisGoverService().GetDelegateSets(0)
+	if err != nil {
+		utils.Error(err)
+		return types.NewResponseWithError(err)
+	}
+	response := types.NewResponse()
+	response.Data = delegateSets
+	response.Status = types.StatusOk
+	return response
+}
+
+// getScore - delegates without heartbeats yet sort after those with a score
+func getScore(health *disgover.NodeHealth) float64 {
+	if health == nil {
+		return -1
+	}
+	return health.Score
+}
+
 // GetReceipt
 func (this *DAPoSService) GetReceipt(transactionHash string) *types.Response {
 	txn := services.NewTxn(false)
@@ -143,7 +191,7 @@ func (this *DAPoSService) GetAccount(address string) *types.Response {
 				response.Status = types.StatusInternalError
 			}
 		} else {
-			account.HertzAvailable, err = types.CheckMinimumAvailable(txn, services.GetCache(), account.Address, account.Balance.Uint64())
+			account.HertzAvailable, err = types.CheckMinimumAvailable(txn, services.GetCache(), account.Address, account.Balance)
 			if err != nil {
 				utils.Error(err)
 			}
@@ -165,7 +213,12 @@ func (this *DAPoSService) NewTransaction(transaction *types.Transaction) *types.
 
 	// Delegate?
 	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
-		response = this.startGossiping(transaction)
+		if this.IsSynchronized() {
+			response = this.startGossiping(transaction)
+		} else {
+			response.Status = types.StatusSynchronizing
+			response.HumanReadableStatus = types.StatusSynchronizingAsHumanReadable
+		}
 	} else {
 		response.Status = types.StatusNotDelegate
 		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
@@ -193,6 +246,31 @@ func (this *DAPoSService) CallTransaction(transaction *types.Transaction) *types
 
 }
 
+// prepareTransaction - Checks a contract transaction's ABI and params before it is gossiped or called, executions and reads
+// take the ABI of the deployed contract. Returns the status to reply with when the transaction is refused.
+func prepareTransaction(transaction *types.Transaction) (string, error) {
+	if transaction.Type == types.TypeDeploySmartContract {
+		_, err := helper.GetABI(hex.EncodeToString([]byte(transaction.Abi)))
+		if err != nil {
+			return types.StatusJsonParseError, err
+		}
+	}
+	if transaction.Type == types.TypeExecuteSmartContract || transaction.Type == types.TypeReadSmartContract {
+		txn := services.NewTxn(true)
+		defer txn.Discard()
+		contractTx, err := types.ToTransactionByAddress(txn, transaction.To)
+		if err != nil {
+			return types.StatusNotFound, errors.Errorf("Could not find contract with address %s", transaction.To)
+		}
+		transaction.Abi = contractTx.Abi
+		_, err = helper.GetConvertedParams(transaction)
+		if err != nil {
+			return types.StatusJsonParseError, err
+		}
+	}
+	return "", nil
+}
+
 // GetTransaction
 func (this *DAPoSService) GetTransaction(hash string) *types.Response {
 	txn := services.NewTxn(false)
@@ -347,6 +425,16 @@ func (this *DAPoSService) DumpQueue() *types.Response {
 	return response
 }
 
+// GetMetrics
+func (this *DAPoSService) GetMetrics() *types.Response {
+	response := types.NewResponse()
+	response.Data = map[string]interface{}{
+		"cache": services.GetCacheStats(),
+	}
+	response.Status = types.StatusOk
+	return response
+}
+
 func (this *DAPoSService) ToBeSupported() *types.Response {
 	response := types.NewResponse()
 	response.Data = types.StatusUnavailableFeature
@@ -0,0 +1,87 @@
+package dapos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/disgover"
+)
+
+// cacheTestDelegates - returns a function uncaching them
+func cacheTestDelegates(addresses ...string) ([]*types.Node, func()) {
+	delegates := make([]*types.Node, 0, len(addresses))
+	for _, address := range addresses {
+		delegate := &types.Node{
+			Address:         address,
+			GrpcEndpoint:    &types.Endpoint{Host: "127.0.0.1", Port: 1973},
+			HttpEndpoint:    &types.Endpoint{Host: "127.0.0.1", Port: 1975},
+			Type:            types.TypeDelegate,
+			ProtocolVersion: types.ProtocolVersion,
+		}
+		delegate.Cache(services.GetCache())
+		delegates = append(delegates, delegate)
+	}
+	return delegates, func() {
+		for _, delegate := range delegates {
+			services.GetCache().Delete(delegate.Key())
+			services.GetCache().Delete(delegate.TypeKey())
+		}
+	}
+}
+
+// TestGetRandomDelegatePrefersHealthy - unhealthy delegates are only chosen when no healthy one is left
+func TestGetRandomDelegatePrefersHealthy(t *testing.T) {
+	delegates, uncache := cacheTestDelegates("random-healthy", "random-unhealthy")
+	defer uncache()
+	for i := 0; i < 3; i++ {
+		disgover.GetDisGoverService().RecordFailure("random-unhealthy")
+	}
+	gossip := &types.Gossip{Transaction: types.Transaction{Hash: "random-hash"}}
+	for i := 0; i < 20; i++ {
+		if delegate := GetDAPoSService().getRandomDelegate(gossip, delegates); delegate == nil || delegate.Address != "random-healthy" {
+			t.Fatalf("returned %v, expected the healthy delegate", delegate)
+		}
+	}
+	if delegate := GetDAPoSService().getRandomDelegate(gossip, delegates[1:]); delegate == nil || delegate.Address != "random-unhealthy" {
+		t.Errorf("returned %v, expected the only delegate left", delegate)
+	}
+}
+
+// TestGetDelegatesHandlerHealth - /v1/delegates?health=true lists delegates healthiest first, those without heartbeats last
+func TestGetDelegatesHandlerHealth(t *testing.T) {
+	_, uncache := cacheTestDelegates("health-unknown", "health-down", "health-up")
+	defer uncache()
+	disgover.GetDisGoverService().RecordSuccess("health-up", 10*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		disgover.GetDisGoverService().RecordFailure("health-down")
+	}
+
+	recorder := httptest.NewRecorder()
+	GetDAPoSService().getDelegatesHandler(recorder, httptest.NewRequest(http.MethodGet, "/v1/delegates?health=true", nil))
+	response := &struct {
+		Status string `json:"status"`
+		Data   []struct {
+			Address string               `json:"address"`
+			Health  *disgover.NodeHealth `json:"health"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatal(err)
+	}
+	addresses := make([]string, 0)
+	for _, delegate := range response.Data {
+		addresses = append(addresses, delegate.Address)
+	}
+	if response.Status != types.StatusOk || !reflect.DeepEqual(addresses, []string{"health-up", "health-down", "health-unknown"}) {
+		t.Fatalf("unexpected delegates %s", recorder.Body.String())
+	}
+	if !response.Data[0].Health.Healthy || response.Data[1].Health.Healthy || response.Data[2].Health != nil {
+		t.Errorf("unexpected health %s", recorder.Body.String())
+	}
+}
@@ -0,0 +1,340 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/dispatchlabs/disgo/disgover"
+	"golang.org/x/net/context"
+)
+
+// syncPeerCount - delegates synchronized from in parallel
+const syncPeerCount = 3
+
+// syncPeer
+type syncPeer struct {
+	address string
+	client  proto.DAPoSGrpcClient
+}
+
+// peerTransactions - what a peer served for a range
+type peerTransactions struct {
+	address      string
+	transactions []*types.Transaction
+	gossips      map[string]*types.Gossip
+}
+
+// contradiction - a peer served a transaction that fails verification, lacks a rumor quorum or differs from the one that has it
+type contradiction struct {
+	address     string
+	hash        string
+	reason      string
+	transaction *types.Transaction
+	gossip      *types.Gossip
+}
+
+// transactionVersion - one record for a hash and the rumors every peer serving it sent
+type transactionVersion struct {
+	record      string
+	transaction *types.Transaction
+	gossip      *types.Gossip
+	addresses   []string
+}
+
+// delegateHistory - the delegate sets rumor quorums are counted against
+type delegateHistory struct {
+	sets    []*types.DelegateSet // Oldest first
+	current []string
+}
+
+// addressesAt - the delegates of the latest set accepted at or before t, transactions older than every known set are counted
+// against the oldest one, the cached delegates are used when no set is known
+func (this *delegateHistory) addressesAt(t int64) []string {
+	if len(this.sets) == 0 {
+		return this.current
+	}
+	index := sort.Search(len(this.sets), func(i int) bool { return this.sets[i].Time > t }) - 1
+	if index < 0 {
+		index = 0
+	}
+	addresses := make([]string, 0, len(this.sets[index].Delegates))
+	for _, delegate := range this.sets[index].Delegates {
+		addresses = append(addresses, delegate.Address)
+	}
+	return addresses
+}
+
+// crossValidate - accepts the signed transactions whose verified rumors, merged across the peers serving the same record, come from at
+// least 2/3 of the delegates of the set accepted when the transaction was sent
+func crossValidate(results []*peerTransactions, history *delegateHistory) ([]*types.Transaction, map[string]*types.Gossip, []contradiction) {
+	versions := map[string]map[string]*transactionVersion{}
+	contradictions := make([]contradiction, 0)
+	for _, result := range results {
+		for _, transaction := range result.transactions {
+			err := transaction.Verify()
+			if err != nil {
+				contradictions = append(contradictions, contradiction{address: result.address, hash: transaction.Hash, reason: fmt.Sprintf("invalid transaction: %s", err), transaction: transaction, gossip: result.gossips[transaction.Hash]})
+				continue
+			}
+			bytes, err := transaction.ToRecord()
+			if err != nil {
+				utils.Error(err)
+				continue
+			}
+			record := string(bytes)
+			if versions[transaction.Hash] == nil {
+				versions[transaction.Hash] = map[string]*transactionVersion{}
+			}
+			version, ok := versions[transaction.Hash][record]
+			if !ok {
+				version = &transactionVersion{record: record, transaction: transaction, gossip: &types.Gossip{Transaction: *transaction, Rumors: make([]types.Rumor, 0)}}
+				versions[transaction.Hash][record] = version
+			}
+			version.addresses = append(version.addresses, result.address)
+			if gossip, ok := result.gossips[transaction.Hash]; ok {
+				mergeRumors(version.gossip, gossip.Rumors)
+			}
+		}
+	}
+
+	transactions := make([]*types.Transaction, 0, len(versions))
+	gossips := map[string]*types.Gossip{}
+	for hash, byRecord := range versions {
+		records := make([]string, 0, len(byRecord))
+		for record := range byRecord {
+			records = append(records, record)
+		}
+		sort.Strings(records)
+
+		var accepted *transactionVersion
+		for _, record := range records {
+			if byRecord[record].gossip.HasQuorum(history.addressesAt(byRecord[record].transaction.Time)) {
+				accepted = byRecord[record]
+				break
+			}
+		}
+		if accepted != nil {
+			transactions = append(transactions, accepted.transaction)
+			gossips[hash] = accepted.gossip
+		}
+		for _, record := range records {
+			version := byRecord[record]
+			if version == accepted {
+				continue
+			}
+			reason := "transaction has no rumor quorum"
+			if accepted != nil {
+				reason = "transaction contradicts the one with a rumor quorum"
+			}
+			for _, address := range version.addresses {
+				contradictions = append(contradictions, contradiction{address: address, hash: hash, reason: reason, transaction: version.transaction, gossip: version.gossip})
+			}
+		}
+	}
+	sortTransactions(transactions)
+	return transactions, gossips, contradictions
+}
+
+// mergeRumors - adds the rumors gossip does not have yet
+func mergeRumors(gossip *types.Gossip, rumors []types.Rumor) {
+	for _, rumor := range rumors {
+		exists := false
+		for _, existing := range gossip.Rumors {
+			if existing.Address == rumor.Address && existing.Signature == rumor.Signature {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			gossip.Rumors = append(gossip.Rumors, rumor)
+		}
+	}
+}
+
+// sortTransactions - by time then hash
+func sortTransactions(transactions []*types.Transaction) {
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].Time == transactions[j].Time {
+			return transactions[i].Hash < transactions[j].Hash
+		}
+		return transactions[i].Time < transactions[j].Time
+	})
+}
+
+// getDelegateAddresses - the delegate set rumor quorums are counted against
+func getDelegateAddresses() ([]string, error) {
+	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(delegates))
+	for _, delegate := range delegates {
+		addresses = append(addresses, delegate.Address)
+	}
+	return addresses, nil
+}
+
+// getDelegateHistory - every delegate set this node accepted or recorded and the cached delegates
+func getDelegateHistory() (*delegateHistory, error) {
+	current, err := getDelegateAddresses()
+	if err != nil {
+		return nil, err
+	}
+	sets, err := disgover.GetDisGoverService().GetDelegateSets(0)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Time < sets[j].Time })
+	return &delegateHistory{sets: sets, current: current}, nil
+}
+
+// summarizeRange - asks every peer for its summary of r in parallel, returns the peers that answered and the largest count
+func (this *DAPoSService) summarizeRange(peers []*syncPeer, r *proto.Range) ([]*syncPeer, int64) {
+	counts := make([]int64, len(peers))
+	errs := make([]error, len(peers))
+	var waitGroup sync.WaitGroup
+	for i, peer := range peers {
+		waitGroup.Add(1)
+		go func(i int, peer *syncPeer) {
+			defer waitGroup.Done()
+			contextWithTimeout, cancel := context.WithTimeout(context.Background(), antiEntropyTimeout)
+			defer cancel()
+			response, err := peer.client.SynchronizeRangesGrpc(contextWithTimeout, &proto.SynchronizeRangesRequest{Ranges: []*proto.Range{r}})
+			if err == nil && len(response.Ranges) != 1 {
+				err = fmt.Errorf("peer returned %d ranges for 1 requested", len(response.Ranges))
+			}
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			counts[i] = response.Ranges[0].Count
+		}(i, peer)
+	}
+	waitGroup.Wait()
+
+	answered := make([]*syncPeer, 0, len(peers))
+	var count int64
+	for i, peer := range peers {
+		if errs[i] != nil {
+			utils.Warn(fmt.Sprintf("unable to synchronize with delegate [address=%s]", peer.address), errs[i])
+			this.syncState.fail(peer.address, errs[i])
+			penalizeTimeout(peer.address, errs[i])
+			continue
+		}
+		answered = append(answered, peer)
+		if counts[i] > count {
+			count = counts[i]
+		}
+	}
+	return answered, count
+}
+
+// pullRange - pulls what each peer has in r and this node does not in parallel, peers that fail are left out
+func (this *DAPoSService) pullRange(peers []*syncPeer, r *proto.Range) []*peerTransactions {
+	results := make([]*peerTransactions, len(peers))
+	var waitGroup sync.WaitGroup
+	for i, peer := range peers {
+		waitGroup.Add(1)
+		go func(i int, peer *syncPeer) {
+			defer waitGroup.Done()
+			contextWithTimeout, cancel := context.WithTimeout(context.Background(), antiEntropyTimeout)
+			defer cancel()
+			missing, err := findMissingTransactions(contextWithTimeout, peer.client, r.Start, r.End)
+			if err != nil {
+				utils.Warn(fmt.Sprintf("unable to synchronize with delegate [address=%s]", peer.address), err)
+				this.syncState.fail(peer.address, err)
+				penalizeTimeout(peer.address, err)
+				return
+			}
+			transactions, gossips, err := pullTransactions(contextWithTimeout, peer.client, missing)
+			if err != nil {
+				utils.Warn(fmt.Sprintf("unable to synchronize with delegate [address=%s]", peer.address), err)
+				this.syncState.fail(peer.address, err)
+				penalizeTimeout(peer.address, err)
+				return
+			}
+			results[i] = &peerTransactions{address: peer.address, transactions: transactions, gossips: gossips}
+		}(i, peer)
+	}
+	waitGroup.Wait()
+
+	pulled := make([]*peerTransactions, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			pulled = append(pulled, result)
+		}
+	}
+	return pulled
+}
+
+// synchronizeRange - pulls r from peers in parallel in time order, checkpointing after each range of at most syncCheckpointSize transactions
+func (this *DAPoSService) synchronizeRange(peers []*syncPeer, r *proto.Range, history *delegateHistory) error {
+	peers, count := this.summarizeRange(peers, r)
+	if len(peers) == 0 {
+		return fmt.Errorf("no delegate answered for range [start=%d, end=%d]", r.Start, r.End)
+	}
+	if count > syncCheckpointSize && r.End-r.Start > antiEntropyBucket {
+		for _, subrange := range splitRange(r) {
+			err := this.synchronizeRange(peers, subrange, history)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	addresses := make([]string, 0, len(peers))
+	persisted := 0
+	if count > 0 {
+		results := this.pullRange(peers, r)
+		if len(results) == 0 {
+			return fmt.Errorf("no delegate served range [start=%d, end=%d]", r.Start, r.End)
+		}
+		transactions, gossips, contradictions := crossValidate(results, history)
+		this.flagContradictions(contradictions)
+		persisted = persistTransactions(transactions, gossips)
+		for _, result := range results {
+			addresses = append(addresses, result.address)
+		}
+	} else {
+		for _, peer := range peers {
+			addresses = append(addresses, peer.address)
+		}
+	}
+	this.syncState.checkpoint(r.End, persisted, addresses...)
+	return nil
+}
+
+// flagContradictions - flags the peers and quarantines what they served
+func (this *DAPoSService) flagContradictions(contradictions []contradiction) {
+	entries := make([]*QuarantineEntry, 0, len(contradictions))
+	for _, c := range contradictions {
+		utils.Warn(fmt.Sprintf("delegate served contradicted data [address=%s, hash=%s, reason=%s]", c.address, c.hash, c.reason))
+		this.syncState.flag(c.address, c.hash)
+		disgover.GetDisGoverService().PenalizeAddress(c.address, disgover.OffenseContradiction)
+		entries = append(entries, &QuarantineEntry{Hash: c.hash, Address: c.address, Reason: c.reason, Time: time.Now(), Transaction: c.transaction, Gossip: c.gossip})
+	}
+	quarantine(entries)
+}
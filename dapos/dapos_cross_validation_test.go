@@ -0,0 +1,104 @@
+package dapos
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// testDelegate
+type testDelegate struct {
+	address    string
+	privateKey string
+}
+
+// newTestDelegates
+func newTestDelegates(count int) []testDelegate {
+	delegates := make([]testDelegate, 0, count)
+	for i := 0; i < count; i++ {
+		publicKey, privateKey := crypto.GenerateKeyPair()
+		delegates = append(delegates, testDelegate{address: hex.EncodeToString(crypto.ToAddress(publicKey)), privateKey: hex.EncodeToString(privateKey)})
+	}
+	return delegates
+}
+
+// newTestDelegateSet
+func newTestDelegateSet(time int64, delegates []testDelegate) *types.DelegateSet {
+	set := &types.DelegateSet{Time: time}
+	for _, delegate := range delegates {
+		set.Delegates = append(set.Delegates, &types.Node{Address: delegate.address})
+	}
+	return set
+}
+
+// newTestGossip - a signed transaction sent at time with rumors from the delegates
+func newTestGossip(t *testing.T, time int64, delegates []testDelegate) (*types.Transaction, *types.Gossip) {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	from := hex.EncodeToString(crypto.ToAddress(publicKey))
+	transaction, err := types.NewTransferTokensTransaction(hex.EncodeToString(privateKey), from, "1111111111111111111111111111111111111111", big.NewInt(1), 0, time)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gossip := types.NewGossip(*transaction)
+	for _, delegate := range delegates {
+		gossip.Rumors = append(gossip.Rumors, *types.NewRumor(delegate.privateKey, delegate.address, transaction.Hash))
+	}
+	return transaction, gossip
+}
+
+// TestDelegateHistoryAddressesAt
+func TestDelegateHistoryAddressesAt(t *testing.T) {
+	old := newTestDelegates(1)
+	rotated := newTestDelegates(1)
+	current := []string{"cached"}
+	history := &delegateHistory{sets: []*types.DelegateSet{newTestDelegateSet(100, old), newTestDelegateSet(200, rotated)}, current: current}
+	tests := []struct {
+		time     int64
+		expected []string
+	}{
+		{50, []string{old[0].address}},
+		{100, []string{old[0].address}},
+		{199, []string{old[0].address}},
+		{200, []string{rotated[0].address}},
+		{300, []string{rotated[0].address}},
+	}
+	for _, test := range tests {
+		if addresses := history.addressesAt(test.time); !reflect.DeepEqual(addresses, test.expected) {
+			t.Errorf("addressesAt(%d) returned %v, expected %v", test.time, addresses, test.expected)
+		}
+	}
+	if addresses := (&delegateHistory{current: current}).addressesAt(100); !reflect.DeepEqual(addresses, current) {
+		t.Errorf("addressesAt without sets returned %v, expected the cached delegates", addresses)
+	}
+}
+
+// TestCrossValidateRotatedDelegates - a transaction is counted against the delegates of its epoch, not the current ones
+func TestCrossValidateRotatedDelegates(t *testing.T) {
+	old := newTestDelegates(3)
+	rotated := newTestDelegates(3)
+	history := &delegateHistory{sets: []*types.DelegateSet{newTestDelegateSet(1000, old), newTestDelegateSet(2000, rotated)}}
+
+	historical, historicalGossip := newTestGossip(t, 1500, old[:2])
+	recent, recentGossip := newTestGossip(t, 2500, rotated[:2])
+	stale, staleGossip := newTestGossip(t, 2600, old)
+	result := &peerTransactions{
+		address:      "peer",
+		transactions: []*types.Transaction{historical, recent, stale},
+		gossips:      map[string]*types.Gossip{historical.Hash: historicalGossip, recent.Hash: recentGossip, stale.Hash: staleGossip},
+	}
+
+	transactions, gossips, contradictions := crossValidate([]*peerTransactions{result}, history)
+	if len(transactions) != 2 || transactions[0].Hash != historical.Hash || transactions[1].Hash != recent.Hash {
+		t.Fatalf("accepted %d transactions, expected the historical and recent ones", len(transactions))
+	}
+	if gossips[historical.Hash] == nil || gossips[recent.Hash] == nil {
+		t.Error("accepted transactions have no gossip")
+	}
+	if len(contradictions) != 1 || contradictions[0].hash != stale.Hash || contradictions[0].address != "peer" {
+		t.Errorf("got %d contradictions, expected the transaction with rumors from the rotated out delegates", len(contradictions))
+	}
+}
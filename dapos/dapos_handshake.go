@@ -295,6 +295,23 @@ func updateReceiptStatus(txHash, status string) {
 	} else {
 		receipt.Status = status
 		receipt.Cache(services.GetCache())
+		services.PublishSubscriptionEvent(services.ReceiptTopic(txHash), services.ReceiptEvent, receipt)
+	}
+}
+
+// publishExecution - Pushes the receipt and the transaction to WebSocket subscribers once executing it concluded, replays
+// while synchronizing are not news to them
+func publishExecution(transaction *types.Transaction, receipt *types.Receipt, replay bool) {
+	if replay || receipt.Status == types.StatusPending {
+		return
+	}
+	services.PublishSubscriptionEvent(services.ReceiptTopic(transaction.Hash), services.ReceiptEvent, receipt)
+	executed := *transaction
+	executed.Receipt = *receipt
+	services.PublishSubscriptionEvent(services.TransactionsTopic, services.TransactionEvent, executed)
+	services.PublishSubscriptionEvent(services.AddressTopic(transaction.From), services.TransactionEvent, executed)
+	if transaction.To != "" && transaction.To != transaction.From {
+		services.PublishSubscriptionEvent(services.AddressTopic(transaction.To), services.TransactionEvent, executed)
 	}
 }
 
@@ -321,7 +338,7 @@ func (this *DAPoSService) getRandomDelegate(gossip *types.Gossip, delegateNodes
 		if !containsRumor {
 			utils.Debug(fmt.Sprintf("Don't have a Rumor for: [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
 		}
-		if isThisAddress || haveSent || !node.IsAvailable() {
+		if isThisAddress || haveSent || !node.IsAvailable() || !node.IsCompatible() || disgover.GetDisGoverService().IsBanned(node.Address) {
 			continue
 		}
 		delegatesNotRumored = append(delegatesNotRumored, node)
@@ -329,6 +346,18 @@ func (this *DAPoSService) getRandomDelegate(gossip *types.Gossip, delegateNodes
 	if len(delegatesNotRumored) == 0 {
 		return nil
 	}
+
+	// Prefer delegates that answer their heartbeats.
+	healthy := make([]*types.Node, 0, len(delegatesNotRumored))
+	for _, node := range delegatesNotRumored {
+		if disgover.GetDisGoverService().IsHealthy(node.Address) {
+			healthy = append(healthy, node)
+		}
+	}
+	if len(healthy) > 0 {
+		delegatesNotRumored = healthy
+	}
+
 	// Find random delegate.
 	rand.Seed(time.Now().UTC().UnixNano())
 	index := rand.Intn(len(delegatesNotRumored))
@@ -446,6 +475,12 @@ func getAllTxTimestamps() []string {
 }
 
 func ReplayTransactions() {
+	replayTransactions("", nil, nil)
+}
+
+// replayTransactions - replays the transactions after the time key after except those skip returns true for, progress is called with
+// each replayed time key
+func replayTransactions(after string, skip func(key string) bool, progress func(key string)) {
 	utils.Info("ReplayTransactions() in progress")
 
 	//get all the timestamps of the transactions in db
@@ -473,6 +508,10 @@ func ReplayTransactions() {
 
 	// Iterate over the sorted array of tamestamp indexes
 	for _, key := range timestamps {
+		if key <= after || (skip != nil && skip(key)) {
+			continue
+		}
+
 		// extract the hash from the key
 		k := strings.Split(key, "-")
 		hash := k[4]
@@ -495,6 +534,9 @@ func ReplayTransactions() {
 		receipt := types.NewReceipt(hash)
 
 		ExecuteTransaction(tx, receipt, gossip, true)
+		if progress != nil {
+			progress(key)
+		}
 	}
 }
 
@@ -503,6 +545,7 @@ func ExecuteTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	utils.Info("executeTransaction --> ", transaction.Hash)
 	services.Lock(transaction.Hash)
 	defer services.Unlock(transaction.Hash)
+	defer publishExecution(transaction, receipt, replay)
 
 	txn := services.NewTxn(true)
 	defer txn.Discard()
@@ -557,13 +600,13 @@ func ExecuteTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	}
 
 	//Check to see if there is enough Hertz to execute minimum
-	availableHertz, err := types.CheckMinimumAvailable(txn, services.GetCache(), fromAccount.Address, fromAccount.Balance.Uint64())
+	availableHertz, err := types.CheckMinimumAvailable(txn, services.GetCache(), fromAccount.Address, fromAccount.Balance)
 	if err != nil {
 		utils.Error(err)
 	}
 
-  if availableHertz < (minHertzUsed * types.HertzMultiplier) {
-		msg := fmt.Sprintf("Account %s has a hertz balance of %d\n", fromAccount.Address, availableHertz)
+  if availableHertz.Cmp(new(big.Int).SetUint64(minHertzUsed * types.HertzMultiplier)) < 0 {
+		msg := fmt.Sprintf("Account %s has a hertz balance of %s\n", fromAccount.Address, availableHertz)
 		utils.Error(msg)
 		receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInsufficientHertz)
 		return
@@ -573,14 +616,14 @@ func ExecuteTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	switch transaction.Type {
 	case types.TypeTransferTokens:
 		// Sufficient tokens?
-		if fromAccount.Balance.Int64() < transaction.Value {
+		if fromAccount.Balance.Cmp(transaction.Value) < 0 {
 			utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
 			receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInsufficientTokens)
 			return
 		}
 
-		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - transaction.Value)
-		toAccount.Balance.SetInt64(toAccount.Balance.Int64() + transaction.Value)
+		fromAccount.Balance.Sub(fromAccount.Balance, transaction.Value)
+		toAccount.Balance.Add(toAccount.Balance, transaction.Value)
 
 		hertz = minHertzUsed
 		utils.Info(fmt.Sprintf("transferred tokens [hash=%s, rumors=%d]", transaction.Hash, len(gossip.Rumors)))
@@ -728,8 +771,8 @@ func ExecuteTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	rateLimit.Set(*window, txn, services.GetCache())
 
 
-	if availableHertz < hertz {
-		msg := fmt.Sprintf("Account %s has an insufficient hertz balance of %d\n", fromAccount.Address, availableHertz)
+	if availableHertz.Cmp(new(big.Int).SetUint64(hertz)) < 0 {
+		msg := fmt.Sprintf("Account %s has an insufficient hertz balance of %s\n", fromAccount.Address, availableHertz)
 		utils.Error(msg)
 		receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInsufficientHertz)
 		return
@@ -817,6 +860,7 @@ func ExecuteTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		receipt.Cache(services.GetCache())
 		return
 	}
+	rangeSummaries.refresh(transaction.Time)
 }
 
 //Call Transaction
@@ -0,0 +1,183 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Synced transactions that fail verification are kept apart from the ledger for inspection, entries expire after quarantineTTL and
+// the oldest are dropped beyond maxQuarantineEntries.
+const (
+	quarantinePrefix     = "quarantine-transaction-"
+	quarantineReportSize = 1000
+	quarantineTTL        = 7 * 24 * time.Hour
+	maxQuarantineEntries = 10000
+
+	maxQuarantineDeletesPerTxn = 1000 // Well below badger's ErrTxnTooBig
+
+	quarantineReasonOutOfOrder = "transaction predates its accounts' state" // Only a state sync can apply it
+)
+
+// QuarantineEntry - a transaction a peer served that was not persisted
+type QuarantineEntry struct {
+	Hash        string             `json:"hash"`
+	Address     string             `json:"address"` // Peer that served it
+	Reason      string             `json:"reason"`
+	Time        time.Time          `json:"time"`
+	Transaction *types.Transaction `json:"transaction,omitempty"`
+	Gossip      *types.Gossip      `json:"gossip,omitempty"`
+}
+
+// QuarantineReport
+type QuarantineReport struct {
+	Count     int                `json:"count"`
+	ByAddress map[string]int     `json:"byAddress"`
+	ByReason  map[string]int     `json:"byReason"`
+	Entries   []*QuarantineEntry `json:"entries"` // At most quarantineReportSize
+}
+
+// Key - one entry per hash and peer
+func (this QuarantineEntry) Key() string {
+	return fmt.Sprintf("%s%s-%s", quarantinePrefix, this.Hash, this.Address)
+}
+
+// quarantine
+func quarantine(entries []*QuarantineEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	err := services.GetDb().Update(func(txn *badger.Txn) error {
+		for _, entry := range entries {
+			bytes, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			err = txn.SetWithTTL([]byte(entry.Key()), bytes, quarantineTTL)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.Error("unable to quarantine transactions", err)
+		return
+	}
+	err = trimQuarantine(maxQuarantineEntries)
+	if err != nil {
+		utils.Error("unable to trim the quarantine", err)
+	}
+}
+
+// trimQuarantine - deletes the entries that expire first until at most max are left
+func trimQuarantine(max int) error {
+	type expiringKey struct {
+		key       []byte
+		expiresAt uint64
+	}
+	keys := make([]expiringKey, 0)
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		options := badger.DefaultIteratorOptions
+		options.PrefetchValues = false
+		iterator := txn.NewIterator(options)
+		defer iterator.Close()
+		prefix := []byte(quarantinePrefix)
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			keys = append(keys, expiringKey{key: iterator.Item().KeyCopy(nil), expiresAt: iterator.Item().ExpiresAt()})
+		}
+		return nil
+	})
+	if err != nil || len(keys) <= max {
+		return err
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].expiresAt < keys[j].expiresAt })
+	keys = keys[:len(keys)-max]
+	for start := 0; start < len(keys); start += maxQuarantineDeletesPerTxn {
+		end := start + maxQuarantineDeletesPerTxn
+		if end > len(keys) {
+			end = len(keys)
+		}
+		err = services.GetDb().Update(func(txn *badger.Txn) error {
+			for _, key := range keys[start:end] {
+				err := txn.Delete(key.key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetQuarantineReport
+func GetQuarantineReport() (*QuarantineReport, error) {
+	report := &QuarantineReport{ByAddress: map[string]int{}, ByReason: map[string]int{}, Entries: make([]*QuarantineEntry, 0)}
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		prefix := []byte(quarantinePrefix)
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			value, err := iterator.Item().Value()
+			if err != nil {
+				return err
+			}
+			entry := &QuarantineEntry{}
+			err = json.Unmarshal(value, entry)
+			if err != nil {
+				utils.Error(fmt.Sprintf("invalid quarantine entry [key=%s]", iterator.Item().Key()), err)
+				continue
+			}
+			report.Count++
+			report.ByAddress[entry.Address]++
+			report.ByReason[entry.Reason]++
+			if len(report.Entries) < quarantineReportSize {
+				report.Entries = append(report.Entries, entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetQuarantine
+func (this *DAPoSService) GetQuarantine() *types.Response {
+	response := types.NewResponse()
+	report, err := GetQuarantineReport()
+	if err != nil {
+		utils.Error(err)
+		return types.NewResponseWithError(err)
+	}
+	response.Data = report
+	response.Status = types.StatusOk
+	return response
+}
@@ -0,0 +1,75 @@
+package dapos
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+)
+
+// TestQuarantineExpires
+func TestQuarantineExpires(t *testing.T) {
+	defer deleteTestKeys(t, quarantinePrefix)
+	entry := &QuarantineEntry{Hash: "hash", Address: "peer", Reason: "transaction has no rumor quorum", Time: time.Now()}
+	quarantine([]*QuarantineEntry{entry})
+
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(entry.Key()))
+		if err != nil {
+			return err
+		}
+		expiresAt := time.Unix(int64(item.ExpiresAt()), 0)
+		if expiresAt.Before(time.Now().Add(quarantineTTL-time.Minute)) || expiresAt.After(time.Now().Add(quarantineTTL+time.Minute)) {
+			t.Errorf("entry expires at %s, expected in %s", expiresAt, quarantineTTL)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTrimQuarantine
+func TestTrimQuarantine(t *testing.T) {
+	defer deleteTestKeys(t, quarantinePrefix)
+	for i := 0; i < 5; i++ {
+		// Every entry expires one second after the previous one.
+		err := services.GetDb().Update(func(txn *badger.Txn) error {
+			entry := &QuarantineEntry{Hash: fmt.Sprintf("hash-%d", 4-i), Address: "peer"}
+			return txn.SetWithTTL([]byte(entry.Key()), []byte("{}"), quarantineTTL+time.Duration(i)*time.Second)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := trimQuarantine(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := GetQuarantineReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Count != 2 {
+		t.Fatalf("%d entries left, expected 2", report.Count)
+	}
+	services.GetDb().View(func(txn *badger.Txn) error {
+		for _, hash := range []string{"hash-0", "hash-1"} {
+			if _, err := txn.Get([]byte((&QuarantineEntry{Hash: hash, Address: "peer"}).Key())); err != nil {
+				t.Errorf("the entry of %s expiring last was dropped: %v", hash, err)
+			}
+		}
+		return nil
+	})
+
+	err = trimQuarantine(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report, _ = GetQuarantineReport(); report.Count != 2 {
+		t.Errorf("%d entries left after trimming below the cap, expected 2", report.Count)
+	}
+}
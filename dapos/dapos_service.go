@@ -52,6 +52,7 @@ type DAPoSService struct {
 	queueChan   chan *types.Gossip
 	timoutChan  chan bool
 	gossipQueue *queue.GossipQueue
+	syncState   *SyncState
 }
 
 // IsRunning -
@@ -73,34 +74,51 @@ func (this *DAPoSService) Go() {
 // OnEvent - Event to synchronize peers
 func (this *DAPoSService) disGoverServiceInitFinished() {
 
-	// Create genesis account.
-	err := this.CreateGenesisAccount()
-	if err == nil {
-		//created new genesis account
-		//can assume db was empty beforehand
-
-		if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
-			//peerSynchronize only if the badger db is empty
-			this.peerSynchronize()
-			utils.Info("this prints out after peerSynchronize is called in disGoverServiceInitFinished()")
-			//dapos.ReplayTransactions();
-			ReplayTransactions()
-
-			//ExecuteTransaction()
-
-		}
+	// Sync state is created before the genesis account so an interrupted first sync is resumed.
+	syncState, err := loadSyncState(!this.hasGenesisAccount())
+	if err != nil {
+		services.GetDbService().Close()
+		utils.Fatal("unable to load sync state", err)
+	}
+	this.syncState = syncState
 
-	} else if err != nil && err.Error() != "genesis already exists" {
+	// Create genesis account.
+	err = this.CreateGenesisAccount()
+	if err != nil && err.Error() != "genesis already exists" {
 		services.GetDbService().Close()
 		utils.Fatal("unable to create genesis account", err)
 	}
 
+	if !this.syncState.IsComplete() {
+		if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+			this.syncState.setPhase(SyncPhaseComplete)
+		} else if !this.synchronize() {
+			utils.Warn("initial sync incomplete, this delegate will not vote until it completes")
+			go this.synchronizeWorker()
+		}
+	}
+
 	go this.gossipWorker()
 	go this.transactionWorker()
+	go this.antiEntropyWorker()
 
 	utils.Events().Raise(types.Events.DAPoSServiceInitFinished)
 }
 
+// hasGenesisAccount
+func (this *DAPoSService) hasGenesisAccount() bool {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+
+	genesisAccount, err := types.GetGenesisAccount()
+	if err != nil {
+		utils.Error(err)
+		return false
+	}
+	_, err = types.ToAccountByAddress(txn, genesisAccount.Address)
+	return err == nil
+}
+
 // createGenesisTransactionAndAccount
 func (this *DAPoSService) CreateGenesisAccount() error {
 	txn := services.GetDb().NewTransaction(true)
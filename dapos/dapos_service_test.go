@@ -0,0 +1,64 @@
+package dapos
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+)
+
+// TestMain - the DB and config are created in a temporary working directory
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "disgo-dapos")
+	if err != nil {
+		panic(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	services.GetDb().Close()
+	os.Chdir(wd)
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// setTestKeys
+func setTestKeys(t *testing.T, keys map[string]string) {
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	for key, value := range keys {
+		if err := txn.Set([]byte(key), []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deleteTestKeys - removes every key with prefix
+func deleteTestKeys(t *testing.T, prefix string) {
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	iterator := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
+	var keys [][]byte
+	for iterator.Seek([]byte(prefix)); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+		keys = append(keys, iterator.Item().KeyCopy(nil))
+	}
+	iterator.Close()
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,661 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/dispatchlabs/disgo/disgover"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+	protobuf "github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+)
+
+// State sync downloads the accounts and contract state at a checkpoint at least 2/3 of the delegates agree on instead of replaying all
+// history, only transactions the checkpoint does not reflect are replayed. Snapshots are served from a badger read transaction and
+// downloads are staged in the database until they match the checkpoint, only keys and hashes are kept in memory.
+const (
+	stateSyncPageSize    = 50
+	stateSyncRefresh     = 2 * time.Minute
+	stateSyncMaxRestarts = 3
+	stateSyncBatchSize   = 500
+	stateSyncStagePrefix = "state-sync-"
+)
+
+var errStateSnapshotChanged = errors.New("state snapshot changed while downloading")
+
+// stateSnapshot
+type stateSnapshot struct {
+	checkpoint  *proto.StateCheckpoint
+	txn         *badger.Txn // Read only view of the database when the snapshot was created
+	accountKeys []string
+	itemKeys    []string
+	created     time.Time
+	idle        *time.Timer // Discards the snapshot when no page has been read for stateSyncRefresh
+}
+
+var stateSnapshotInstance *stateSnapshot
+var stateSnapshotMutex sync.Mutex
+
+// SynchronizeAccountsGrpc - PROTO - Pages a snapshot of account and contract state, accounts first, a stale snapshot is rebuilt when page 0 is requested
+func (this *DAPoSService) SynchronizeAccountsGrpc(context context.Context, request *proto.SynchronizeRequest) (*proto.SynchronizeAccountsResponse, error) {
+	if !this.IsSynchronized() {
+		return nil, errors.New(types.StatusSynchronizingAsHumanReadable)
+	}
+	if request.Index < 0 {
+		return nil, fmt.Errorf("invalid index %d", request.Index)
+	}
+	response, err := getStatePage(request.Index)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// getStatePage - pages are read while holding the lock so the snapshot is not discarded mid page
+func getStatePage(index int64) (*proto.SynchronizeAccountsResponse, error) {
+	stateSnapshotMutex.Lock()
+	defer stateSnapshotMutex.Unlock()
+	if stateSnapshotInstance == nil || (index == 0 && time.Since(stateSnapshotInstance.created) > stateSyncRefresh) {
+		snapshot, err := newStateSnapshot()
+		if err != nil {
+			return nil, err
+		}
+		if stateSnapshotInstance != nil {
+			stateSnapshotInstance.discard()
+		}
+		snapshot.idle = time.AfterFunc(stateSyncRefresh, func() { discardStateSnapshot(snapshot) })
+		stateSnapshotInstance = snapshot
+		utils.Info(fmt.Sprintf("created state snapshot [accounts=%d, items=%d, time=%d]", len(snapshot.accountKeys), len(snapshot.itemKeys), snapshot.checkpoint.Time))
+	}
+	stateSnapshotInstance.idle.Reset(stateSyncRefresh)
+	return stateSnapshotInstance.page(index)
+}
+
+// discardStateSnapshot - releases the read transaction of snapshot unless it was already replaced
+func discardStateSnapshot(snapshot *stateSnapshot) {
+	stateSnapshotMutex.Lock()
+	defer stateSnapshotMutex.Unlock()
+	if stateSnapshotInstance != snapshot {
+		return
+	}
+	snapshot.discard()
+	stateSnapshotInstance = nil
+	utils.Info(fmt.Sprintf("discarded idle state snapshot [time=%d]", snapshot.checkpoint.Time))
+}
+
+// discard - caller holds the lock
+func (this *stateSnapshot) discard() {
+	this.idle.Stop()
+	this.txn.Discard()
+}
+
+// page
+func (this *stateSnapshot) page(index int64) (*proto.SynchronizeAccountsResponse, error) {
+	response := &proto.SynchronizeAccountsResponse{Checkpoint: this.checkpoint}
+	total := int64(len(this.accountKeys) + len(this.itemKeys))
+	for i := index * stateSyncPageSize; i < (index+1)*stateSyncPageSize && i < total; i++ {
+		if i < int64(len(this.accountKeys)) {
+			value, err := getSnapshotValue(this.txn, this.accountKeys[i])
+			if err != nil {
+				return nil, err
+			}
+			account, err := types.ToAccountFromRecord(value)
+			if err != nil {
+				return nil, err
+			}
+			response.Accounts = append(response.Accounts, convertToProtoAccount(account))
+		} else {
+			key := this.itemKeys[i-int64(len(this.accountKeys))]
+			value, err := getSnapshotValue(this.txn, key)
+			if err != nil {
+				return nil, err
+			}
+			response.Items = append(response.Items, &proto.Item{Key: key, Value: append([]byte{}, value...)})
+		}
+	}
+	return response, nil
+}
+
+// getSnapshotValue
+func getSnapshotValue(txn *badger.Txn, key string) ([]byte, error) {
+	item, err := txn.Get([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state snapshot [key=%x]: %v", key, err)
+	}
+	return item.Value()
+}
+
+// newStateSnapshot - accounts, AccountState- roots and the transaction index are read in one badger transaction that is kept to serve
+// the pages, trie nodes are content addressed
+func newStateSnapshot() (*stateSnapshot, error) {
+	checkpoint := &proto.StateCheckpoint{Time: antiEntropyEnd()}
+	snapshot := &stateSnapshot{checkpoint: checkpoint, txn: services.NewTxn(false), created: time.Now()}
+	err := snapshot.collect()
+	if err != nil {
+		snapshot.txn.Discard()
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// collect - the keys, checkpoint and state root of the snapshot
+func (this *stateSnapshot) collect() error {
+	leaves := make([]string, 0)
+	stateRoots := make([]*proto.Item, 0)
+	iterator := this.txn.NewIterator(badger.DefaultIteratorOptions)
+	prefix := []byte("table-account-")
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+		account, err := types.ToAccountFromRecord(value)
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+		leaf, err := getAccountLeaf(convertToProtoAccount(account))
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+		this.accountKeys = append(this.accountKeys, string(iterator.Item().KeyCopy(nil)))
+		leaves = append(leaves, leaf)
+	}
+	iterator.Close()
+
+	iterator = this.txn.NewIterator(badger.DefaultIteratorOptions)
+	prefix = vmstatehelperimplemtations.AccountStatePrefix
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+		stateRoots = append(stateRoots, &proto.Item{Key: string(iterator.Item().KeyCopy(nil)), Value: append([]byte{}, value...)})
+	}
+	iterator.Close()
+
+	var err error
+	this.checkpoint.TransactionsRoot, this.checkpoint.TransactionCount, err = getRangeSummary(this.txn, 0, this.checkpoint.Time)
+	if err != nil {
+		return err
+	}
+	err = forEachTimeKey(this.txn, this.checkpoint.Time, math.MaxInt64, func(key timeKey) bool {
+		this.checkpoint.Executed = append(this.checkpoint.Executed, key.hash)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	// Nodes reachable from a root in this view were written before it, so they are in the view too.
+	nodes := map[string]bool{}
+	for _, item := range stateRoots {
+		err := vmstatehelperimplemtations.CollectContractState(crypto.BytesToHash(item.Value), nodes)
+		if err != nil {
+			return fmt.Errorf("unable to collect contract state [key=%s]: %v", item.Key, err)
+		}
+		this.itemKeys = append(this.itemKeys, item.Key)
+		leaves = append(leaves, getItemLeaf(item))
+	}
+	for key := range nodes {
+		value, err := getSnapshotValue(this.txn, key)
+		if err != nil {
+			return err
+		}
+		this.itemKeys = append(this.itemKeys, key)
+		leaves = append(leaves, getItemLeaf(&proto.Item{Key: key, Value: value}))
+	}
+	sort.Strings(this.itemKeys)
+	sort.Strings(leaves)
+
+	this.checkpoint.AccountCount = int64(len(this.accountKeys))
+	this.checkpoint.ItemCount = int64(len(this.itemKeys))
+	this.checkpoint.StateRoot = getMerkleRoot(leaves)
+	return nil
+}
+
+// getAccountLeaf - the state root is the merkle root of the sorted hashes of every account and item
+func getAccountLeaf(account *proto.Account) (string, error) {
+	bytes, err := protobuf.Marshal(account)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// getItemLeaf
+func getItemLeaf(item *proto.Item) string {
+	hash := sha256.Sum256(append(append([]byte(item.Key), 0), item.Value...))
+	return hex.EncodeToString(hash[:])
+}
+
+// stateSynchronize - downloads, verifies and persists the state at the checkpoint at least 2/3 of the delegates serve
+func (this *DAPoSService) stateSynchronize() bool {
+	utils.Info("synchronizing state with peer delegate...")
+
+	delegates, err := getPeerDelegates()
+	if err != nil {
+		utils.Error(err)
+		return false
+	}
+	if len(delegates) == 0 {
+		utils.Warn("unable to find a delegate to synchronize state with")
+		return true
+	}
+	delegateAddresses, err := getDelegateAddresses()
+	if err != nil {
+		utils.Error(err)
+		return false
+	}
+	state := this.syncState
+	clients := map[string]proto.DAPoSGrpcClient{}
+	checkpoints := map[string]*proto.StateCheckpoint{}
+	for _, delegate := range delegates {
+		conn, err := services.GetGrpcConnection(delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to connect to delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
+			continue
+		}
+		clients[delegate.Address] = proto.NewDAPoSGrpcClient(conn)
+		contextWithTimeout, cancel := context.WithTimeout(context.Background(), antiEntropyTimeout)
+		response, err := clients[delegate.Address].SynchronizeAccountsGrpc(contextWithTimeout, &proto.SynchronizeRequest{Index: 0})
+		cancel()
+		if err != nil || response.Checkpoint == nil {
+			utils.Warn(fmt.Sprintf("unable to get the state checkpoint of delegate [address=%s]", delegate.Address), err)
+			continue
+		}
+		checkpoints[delegate.Address] = response.Checkpoint
+	}
+	checkpoint, addresses := agreeOnCheckpoint(checkpoints, delegateAddresses, disgover.GetDisGoverService().ThisNode.Address)
+	if checkpoint == nil {
+		utils.Warn(fmt.Sprintf("no state checkpoint is served by 2/3 of the delegates [checkpoints=%d, delegates=%d]", len(checkpoints), len(delegateAddresses)))
+		return false
+	}
+
+	sort.SliceStable(addresses, func(i, j int) bool {
+		return state.getContradictions(addresses[i]) < state.getContradictions(addresses[j])
+	})
+	for _, address := range addresses {
+		state.setActive([]string{address})
+		err = downloadState(clients[address], checkpoint)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to synchronize state with delegate [address=%s]", address), err)
+			state.fail(address, err)
+			continue
+		}
+		state.setStateCheckpoint(address, checkpoint)
+		utils.Info(fmt.Sprintf("synchronized state with delegate [address=%s, accounts=%d, items=%d, time=%d]", address, checkpoint.AccountCount, checkpoint.ItemCount, checkpoint.Time))
+		return true
+	}
+	return false
+}
+
+// agreeOnCheckpoint - the checkpoint identical in every field served by at least 2/3 of the delegates other than this node, and the
+// delegates serving it
+func agreeOnCheckpoint(checkpoints map[string]*proto.StateCheckpoint, delegateAddresses []string, thisAddress string) (*proto.StateCheckpoint, []string) {
+	voters := 0
+	byCheckpoint := map[string][]string{}
+	for _, address := range delegateAddresses {
+		if address == thisAddress {
+			continue
+		}
+		voters++
+		checkpoint, ok := checkpoints[address]
+		if !ok {
+			continue
+		}
+		record, err := protobuf.Marshal(checkpoint)
+		if err != nil {
+			continue
+		}
+		byCheckpoint[string(record)] = append(byCheckpoint[string(record)], address)
+	}
+	for _, addresses := range byCheckpoint {
+		if voters > 0 && float32(len(addresses)) >= float32(voters)*2/3 {
+			return checkpoints[addresses[0]], addresses
+		}
+	}
+	return nil, nil
+}
+
+// downloadState - restarts when the peer rebuilds its snapshot mid download
+func downloadState(client proto.DAPoSGrpcClient, checkpoint *proto.StateCheckpoint) error {
+	for attempt := 0; attempt < stateSyncMaxRestarts; attempt++ {
+		err := fetchState(client, checkpoint)
+		if err != nil {
+			if err := deleteStagedState(); err != nil {
+				utils.Error("unable to delete staged state", err)
+			}
+		}
+		if err == errStateSnapshotChanged {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		stateRoots, err := applyState()
+		if err != nil {
+			return err
+		}
+
+		// Every node reachable from each contract root must now be present locally.
+		for key, root := range stateRoots {
+			err = vmstatehelperimplemtations.CollectContractState(crypto.BytesToHash(root), map[string]bool{})
+			if err != nil {
+				return fmt.Errorf("incomplete contract state [key=%s]: %v", key, err)
+			}
+		}
+		return nil
+	}
+	return errStateSnapshotChanged
+}
+
+// fetchState - stages every page of the snapshot at checkpoint, trie nodes and code are persisted as they arrive since they must hash to
+// their key, accounts and contract roots are staged until the snapshot matches the checkpoint's counts and root
+func fetchState(client proto.DAPoSGrpcClient, checkpoint *proto.StateCheckpoint) error {
+	err := deleteStagedState()
+	if err != nil {
+		return err
+	}
+	batch := newStateBatch()
+	defer batch.discard()
+	leaves := make([]string, 0)
+	var accountCount, itemCount int64
+	for index := int64(0); ; index++ {
+		contextWithTimeout, cancel := context.WithTimeout(context.Background(), antiEntropyTimeout)
+		response, err := client.SynchronizeAccountsGrpc(contextWithTimeout, &proto.SynchronizeRequest{Index: index})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if response.Checkpoint == nil {
+			return errors.New("state snapshot has no checkpoint")
+		}
+		if !protobuf.Equal(response.Checkpoint, checkpoint) {
+			if index == 0 {
+				return errors.New("state snapshot does not match the agreed checkpoint")
+			}
+			return errStateSnapshotChanged
+		}
+		if len(response.Accounts) == 0 && len(response.Items) == 0 {
+			break
+		}
+		accountCount += int64(len(response.Accounts))
+		itemCount += int64(len(response.Items))
+		if accountCount > checkpoint.AccountCount || itemCount > checkpoint.ItemCount {
+			return errors.New("state snapshot is larger than its checkpoint")
+		}
+		for _, account := range response.Accounts {
+			leaf, err := verifyStateAccount(account)
+			if err != nil {
+				return err
+			}
+			leaves = append(leaves, leaf)
+			record, err := protobuf.Marshal(account)
+			if err != nil {
+				return err
+			}
+			err = batch.set([]byte(stateSyncStagePrefix+"account-"+account.Address), record)
+			if err != nil {
+				return err
+			}
+		}
+		for _, item := range response.Items {
+			err := verifyStateItem(item)
+			if err != nil {
+				return err
+			}
+			leaves = append(leaves, getItemLeaf(item))
+			key := item.Key
+			if strings.HasPrefix(item.Key, string(vmstatehelperimplemtations.AccountStatePrefix)) {
+				key = stateSyncStagePrefix + "item-" + item.Key
+			}
+			err = batch.set([]byte(key), item.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = batch.commit()
+	if err != nil {
+		return err
+	}
+	if accountCount != checkpoint.AccountCount || itemCount != checkpoint.ItemCount {
+		return fmt.Errorf("state snapshot count mismatch [accounts=%d/%d, items=%d/%d]", accountCount, checkpoint.AccountCount, itemCount, checkpoint.ItemCount)
+	}
+	sort.Strings(leaves)
+	if !bytes.Equal(getMerkleRoot(leaves), checkpoint.StateRoot) {
+		return errors.New("state snapshot does not match its state root")
+	}
+	return nil
+}
+
+// verifyStateAccount - the account's state root leaf
+func verifyStateAccount(account *proto.Account) (string, error) {
+	if _, err := types.ToAmount(account.Balance); err != nil {
+		return "", fmt.Errorf("invalid account balance [address=%s]", account.Address)
+	}
+	return getAccountLeaf(account)
+}
+
+// verifyStateItem - trie nodes and code must hash to their key
+func verifyStateItem(item *proto.Item) error {
+	if strings.HasPrefix(item.Key, string(vmstatehelperimplemtations.AccountStatePrefix)) {
+		if len(item.Value) != crypto.HashLength {
+			return fmt.Errorf("invalid contract state root [key=%s]", item.Key)
+		}
+		return nil
+	}
+	hash := crypto.NewHash(item.Value)
+	if item.Key != string(hash[:]) {
+		return fmt.Errorf("trie node does not match its hash [key=%x]", item.Key)
+	}
+	return nil
+}
+
+// applyState - moves the staged accounts and contract roots in place, returns the contract roots by key
+func applyState() (map[string][]byte, error) {
+	stateRoots := map[string][]byte{}
+	batch := newStateBatch()
+	defer batch.discard()
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		prefix := []byte(stateSyncStagePrefix)
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			key := iterator.Item().KeyCopy(nil)
+			value, err := iterator.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if name := strings.TrimPrefix(string(key), stateSyncStagePrefix+"item-"); name != string(key) {
+				stateRoots[name] = value
+				err = batch.set([]byte(name), value)
+			} else {
+				paccount := &proto.Account{}
+				err = protobuf.Unmarshal(value, paccount)
+				if err != nil {
+					return err
+				}
+				account, err := convertToDomainAccount(paccount)
+				if err != nil {
+					return err
+				}
+				err = batch.apply(func(txn *badger.Txn) error { return account.Set(txn, services.GetCache()) })
+			}
+			if err != nil {
+				return err
+			}
+			err = batch.delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = batch.commit()
+	if err != nil {
+		return nil, err
+	}
+	return stateRoots, nil
+}
+
+// deleteStagedState - what an interrupted download left behind
+func deleteStagedState() error {
+	batch := newStateBatch()
+	defer batch.discard()
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		iterator := txn.NewIterator(opts)
+		defer iterator.Close()
+		prefix := []byte(stateSyncStagePrefix)
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			err := batch.delete(iterator.Item().KeyCopy(nil))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return batch.commit()
+}
+
+// stateBatch - writes committed every stateSyncBatchSize operations
+type stateBatch struct {
+	txn   *badger.Txn
+	count int
+}
+
+// newStateBatch
+func newStateBatch() *stateBatch {
+	return &stateBatch{txn: services.NewTxn(true)}
+}
+
+// set
+func (this *stateBatch) set(key, value []byte) error {
+	return this.apply(func(txn *badger.Txn) error { return txn.Set(key, value) })
+}
+
+// delete
+func (this *stateBatch) delete(key []byte) error {
+	return this.apply(func(txn *badger.Txn) error { return txn.Delete(key) })
+}
+
+// apply - runs write, commits when the batch is full
+func (this *stateBatch) apply(write func(txn *badger.Txn) error) error {
+	err := write(this.txn)
+	if err != nil {
+		return err
+	}
+	this.count++
+	if this.count < stateSyncBatchSize {
+		return nil
+	}
+	return this.commit()
+}
+
+// commit
+func (this *stateBatch) commit() error {
+	err := this.txn.Commit(nil)
+	if err != nil {
+		return err
+	}
+	this.count = 0
+	this.txn = services.NewTxn(true)
+	return nil
+}
+
+// discard
+func (this *stateBatch) discard() {
+	this.txn.Discard()
+}
+
+// verifyStateLedger - the synchronized transactions before the state checkpoint must be the ones the state reflects, missing ones are
+// pulled from the delegate the state came from
+func (this *DAPoSService) verifyStateLedger() bool {
+	state := this.syncState
+	checkpoint, transactionsRoot, address := state.getStateCheckpoint()
+	if checkpoint == 0 {
+		return true
+	}
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	r := &proto.Range{Start: 0, End: checkpoint}
+	for attempt := 0; ; attempt++ {
+		summaries, err := getRangeSummaries([]*proto.Range{r})
+		if err != nil {
+			utils.Error(err)
+			return false
+		}
+		if bytes.Equal(summaries[0].Root, transactionsRoot) {
+			return true
+		}
+		if attempt > 0 {
+			utils.Warn(fmt.Sprintf("synchronized transactions do not match the state checkpoint [delegate=%s, time=%d]", address, checkpoint))
+			return false
+		}
+
+		delegate, err := types.ToNodeByAddress(txn, address)
+		if err != nil || delegate.GrpcEndpoint == nil {
+			utils.Warn(fmt.Sprintf("unable to find state delegate [address=%s]", address), err)
+			return false
+		}
+		conn, err := services.GetGrpcConnection(delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to connect to delegate [address=%s]", address), err)
+			return false
+		}
+		history, err := getDelegateHistory()
+		if err != nil {
+			utils.Error(err)
+			return false
+		}
+		err = this.synchronizeRange([]*syncPeer{{address: address, client: proto.NewDAPoSGrpcClient(conn)}}, r, history)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to synchronize with delegate [address=%s]", address), err)
+			return false
+		}
+	}
+}
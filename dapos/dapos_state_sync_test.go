@@ -0,0 +1,200 @@
+package dapos
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// testStateRemote - a peer delegate serving a snapshot, tamper can change a page before it is sent
+type testStateRemote struct {
+	proto.DAPoSGrpcClient
+	snapshot *stateSnapshot
+	tamper   func(index int64, response *proto.SynchronizeAccountsResponse)
+}
+
+// SynchronizeAccountsGrpc
+func (this *testStateRemote) SynchronizeAccountsGrpc(ctx context.Context, in *proto.SynchronizeRequest, opts ...grpc.CallOption) (*proto.SynchronizeAccountsResponse, error) {
+	response, err := this.snapshot.page(in.Index)
+	if err == nil && this.tamper != nil {
+		this.tamper(in.Index, response)
+	}
+	return response, err
+}
+
+// countTestKeys
+func countTestKeys(t *testing.T, prefix string) int {
+	count := 0
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.IteratorOptions{PrefetchValues: false})
+		defer iterator.Close()
+		for iterator.Seek([]byte(prefix)); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// newTestStateSnapshot - a snapshot of count accounts that are then deleted, the snapshot still serves them
+func newTestStateSnapshot(t *testing.T, count int) *stateSnapshot {
+	updated := time.Now().Truncate(time.Millisecond)
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	for i := 0; i < count; i++ {
+		account := &types.Account{Address: fmt.Sprintf("%040d", i), Name: fmt.Sprintf("account-%d", i), Balance: big.NewInt(int64(i + 1)), HertzAvailable: big.NewInt(0), Created: updated, Updated: updated}
+		if err := account.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := newStateSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteTestKeys(t, "table-account-")
+	deleteTestKeys(t, "key-account-name-")
+	return snapshot
+}
+
+// TestAgreeOnCheckpoint
+func TestAgreeOnCheckpoint(t *testing.T) {
+	honest := &proto.StateCheckpoint{StateRoot: []byte("root"), TransactionsRoot: []byte("transactions"), Time: 1000}
+	forged := &proto.StateCheckpoint{StateRoot: []byte("forged"), TransactionsRoot: []byte("transactions"), Time: 1000}
+	delegates := []string{"this", "a", "b", "c"}
+	tests := []struct {
+		checkpoints map[string]*proto.StateCheckpoint
+		expected    *proto.StateCheckpoint
+	}{
+		{map[string]*proto.StateCheckpoint{"a": honest, "b": honest, "c": forged}, honest},
+		{map[string]*proto.StateCheckpoint{"a": honest, "b": forged}, nil},
+		{map[string]*proto.StateCheckpoint{"a": forged}, nil},
+		{map[string]*proto.StateCheckpoint{"a": forged, "outsider": forged, "this": forged}, nil},
+		{map[string]*proto.StateCheckpoint{"a": honest, "b": {StateRoot: []byte("root"), TransactionsRoot: []byte("transactions"), Time: 1000, Executed: []string{"hash"}}}, nil},
+	}
+	for i, test := range tests {
+		checkpoint, addresses := agreeOnCheckpoint(test.checkpoints, delegates, "this")
+		if checkpoint != test.expected {
+			t.Errorf("test %d: agreed on %v, expected %v", i, checkpoint, test.expected)
+		}
+		if checkpoint != nil && len(addresses) != 2 {
+			t.Errorf("test %d: %d delegates serve the agreed checkpoint, expected 2", i, len(addresses))
+		}
+	}
+}
+
+// TestDownloadState
+func TestDownloadState(t *testing.T) {
+	defer deleteTestKeys(t, "table-account-")
+	defer deleteTestKeys(t, "key-account-name-")
+	snapshot := newTestStateSnapshot(t, 2*stateSyncPageSize+10)
+	defer snapshot.txn.Discard()
+
+	err := downloadState(&testStateRemote{snapshot: snapshot}, snapshot.checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := countTestKeys(t, "table-account-"); count != 2*stateSyncPageSize+10 {
+		t.Errorf("downloaded %d accounts, expected %d", count, 2*stateSyncPageSize+10)
+	}
+	if count := countTestKeys(t, stateSyncStagePrefix); count != 0 {
+		t.Errorf("%d staged keys left", count)
+	}
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	account, err := types.ToAccountByAddress(txn, fmt.Sprintf("%040d", 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Balance.Int64() != 8 {
+		t.Errorf("downloaded balance %s, expected 8", account.Balance)
+	}
+}
+
+// TestDownloadStateRejected - nothing is persisted from a snapshot that does not match the agreed checkpoint
+func TestDownloadStateRejected(t *testing.T) {
+	defer deleteTestKeys(t, "table-account-")
+	defer deleteTestKeys(t, "key-account-name-")
+	snapshot := newTestStateSnapshot(t, 2*stateSyncPageSize)
+	defer snapshot.txn.Discard()
+
+	other := *snapshot.checkpoint
+	other.StateRoot = []byte("forged")
+	huge := *snapshot.checkpoint
+	huge.AccountCount = math.MaxInt64 / 2
+	tests := []struct {
+		remote     *testStateRemote
+		checkpoint *proto.StateCheckpoint
+		expected   string
+	}{
+		{&testStateRemote{snapshot: snapshot}, &other, "does not match the agreed checkpoint"},
+		{&testStateRemote{snapshot: snapshot, tamper: func(index int64, response *proto.SynchronizeAccountsResponse) {
+			if index == 1 {
+				response.Accounts[0].Balance = "1000000"
+			}
+		}}, snapshot.checkpoint, "does not match its state root"},
+		{&testStateRemote{snapshot: snapshot, tamper: func(index int64, response *proto.SynchronizeAccountsResponse) {
+			if index == 1 {
+				response.Accounts = response.Accounts[1:]
+			}
+		}}, snapshot.checkpoint, "count mismatch"},
+		{&testStateRemote{snapshot: snapshot, tamper: func(index int64, response *proto.SynchronizeAccountsResponse) {
+			response.Accounts[0].Balance = "invalid"
+		}}, snapshot.checkpoint, "invalid account balance"},
+		{&testStateRemote{snapshot: snapshot, tamper: func(index int64, response *proto.SynchronizeAccountsResponse) {
+			response.Checkpoint = &huge
+		}}, &huge, "count mismatch"},
+	}
+	for i, test := range tests {
+		err := downloadState(test.remote, test.checkpoint)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("test %d: downloadState returned %v, expected %q", i, err, test.expected)
+		}
+		if count := countTestKeys(t, "table-account-"); count != 0 {
+			t.Errorf("test %d: %d accounts persisted", i, count)
+		}
+		if count := countTestKeys(t, stateSyncStagePrefix); count != 0 {
+			t.Errorf("test %d: %d staged keys left", i, count)
+		}
+	}
+}
+
+// TestStateSnapshotIdle - an idle snapshot releases its read transaction, a replaced one is left to its successor
+func TestStateSnapshotIdle(t *testing.T) {
+	if _, err := getStatePage(0); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := stateSnapshotInstance
+	if snapshot == nil {
+		t.Fatal("no snapshot was created")
+	}
+	discardStateSnapshot(snapshot)
+	if stateSnapshotInstance != nil {
+		t.Error("idle snapshot was not discarded")
+	}
+
+	if _, err := getStatePage(0); err != nil {
+		t.Fatal(err)
+	}
+	current := stateSnapshotInstance
+	discardStateSnapshot(snapshot)
+	if stateSnapshotInstance != current {
+		t.Error("a replaced snapshot discarded its successor")
+	}
+	discardStateSnapshot(current)
+}
@@ -0,0 +1,411 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+)
+
+// Sync phases, a delegate does not vote until its phase is complete
+const (
+	SyncPhaseState        = "State"
+	SyncPhaseTransactions = "Transactions"
+	SyncPhaseReplay       = "Replay"
+	SyncPhaseComplete     = "Complete"
+)
+
+const (
+	syncStateKey                   = "sync-state"
+	syncCheckpointSize       int64 = 5000 // Ranges with more transactions are split so progress is checkpointed
+	syncRetryInterval              = time.Minute
+	syncReplayCheckpointSize       = 100
+	syncMaxContradicted            = 20
+)
+
+// SyncCursor - progress synchronizing from a peer delegate
+type SyncCursor struct {
+	Cursor       int64     `json:"cursor"` // Transactions before this time (ms) are synchronized
+	Transactions int64     `json:"transactions"`
+	Error        string    `json:"error,omitempty"`
+	Updated      time.Time `json:"updated"`
+
+	// Peers that served transactions other peers contradict are flagged and synchronized from last.
+	Contradictions int64    `json:"contradictions"`
+	Contradicted   []string `json:"contradicted,omitempty"` // Most recent contradicted transaction hashes
+}
+
+// SyncState - persisted initial sync progress
+type SyncState struct {
+	Phase        string                 `json:"phase"`
+	Target       int64                  `json:"target"`           // Initial sync ends here (ms), later transactions are reconciled by anti-entropy
+	Active       []string               `json:"active,omitempty"` // Peers being synchronized from
+	Peers        map[string]*SyncCursor `json:"peers"`
+	ReplayCursor string                 `json:"replayCursor,omitempty"` // Last replayed transaction time key
+	Replayed     int64                  `json:"replayed"`
+
+	// State sync, transactions before StateCheckpoint (ms) and those in StateExecuted are reflected in the downloaded state.
+	StatePeer             string   `json:"statePeer,omitempty"`
+	StateCheckpoint       int64    `json:"stateCheckpoint,omitempty"`
+	StateTransactionsRoot []byte   `json:"stateTransactionsRoot,omitempty"`
+	StateExecuted         []string `json:"stateExecuted,omitempty"`
+
+	Started   time.Time `json:"started"`
+	Completed time.Time `json:"completed,omitempty"`
+	mutex     sync.Mutex
+}
+
+// newSyncState - starts with state sync when configured
+func newSyncState() *SyncState {
+	phase := SyncPhaseTransactions
+	if types.GetConfig().StateSync {
+		phase = SyncPhaseState
+	}
+	return &SyncState{
+		Phase:   phase,
+		Target:  antiEntropyEnd(),
+		Peers:   map[string]*SyncCursor{},
+		Started: time.Now(),
+	}
+}
+
+// loadSyncState - nodes with a ledger and no sync state predate checkpointed sync and are complete
+func loadSyncState(emptyDb bool) (*SyncState, error) {
+	state := &SyncState{}
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(syncStateKey))
+		if err != nil {
+			return err
+		}
+		value, err := item.Value()
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(value, state)
+	})
+	if err == nil {
+		if state.Peers == nil {
+			state.Peers = map[string]*SyncCursor{}
+		}
+		return state, nil
+	}
+	if err != badger.ErrKeyNotFound {
+		return nil, err
+	}
+
+	state = newSyncState()
+	if !emptyDb {
+		state.Phase = SyncPhaseComplete
+		state.Completed = state.Started
+	}
+	return state, state.persist()
+}
+
+// persist - caller holds the lock or owns the state
+func (this *SyncState) persist() error {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		return err
+	}
+	return services.GetDb().Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(syncStateKey), bytes)
+	})
+}
+
+// IsComplete
+func (this *SyncState) IsComplete() bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.Phase == SyncPhaseComplete
+}
+
+// getPhase
+func (this *SyncState) getPhase() string {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.Phase
+}
+
+// setPhase
+func (this *SyncState) setPhase(phase string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.Phase = phase
+	if phase == SyncPhaseComplete {
+		this.Completed = time.Now()
+	}
+	this.persistOrLog()
+}
+
+// getCursor - start of the range still to synchronize from a peer
+func (this *SyncState) getCursor(address string) int64 {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if cursor, ok := this.Peers[address]; ok {
+		return cursor.Cursor
+	}
+	return 0
+}
+
+// setActive
+func (this *SyncState) setActive(addresses []string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.Active = addresses
+	for _, address := range addresses {
+		this.getOrCreateCursor(address).Updated = time.Now()
+	}
+	this.persistOrLog()
+}
+
+// checkpoint - everything before end has been synchronized from peers
+func (this *SyncState) checkpoint(end int64, count int, addresses ...string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	for _, address := range addresses {
+		cursor := this.getOrCreateCursor(address)
+		cursor.Cursor = end
+		cursor.Transactions += int64(count)
+		cursor.Error = ""
+		cursor.Updated = time.Now()
+	}
+	this.persistOrLog()
+}
+
+// fail
+func (this *SyncState) fail(address string, err error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	cursor := this.getOrCreateCursor(address)
+	cursor.Error = err.Error()
+	cursor.Updated = time.Now()
+	this.persistOrLog()
+}
+
+// flag - a peer served a contradicted transaction
+func (this *SyncState) flag(address, hash string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	cursor := this.getOrCreateCursor(address)
+	cursor.Contradictions++
+	cursor.Contradicted = append(cursor.Contradicted, hash)
+	if len(cursor.Contradicted) > syncMaxContradicted {
+		cursor.Contradicted = cursor.Contradicted[len(cursor.Contradicted)-syncMaxContradicted:]
+	}
+	cursor.Updated = time.Now()
+	this.persistOrLog()
+}
+
+// getContradictions
+func (this *SyncState) getContradictions(address string) int64 {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if cursor, ok := this.Peers[address]; ok {
+		return cursor.Contradictions
+	}
+	return 0
+}
+
+// getOrCreateCursor - caller holds the lock
+func (this *SyncState) getOrCreateCursor(address string) *SyncCursor {
+	cursor, ok := this.Peers[address]
+	if !ok {
+		cursor = &SyncCursor{}
+		this.Peers[address] = cursor
+	}
+	return cursor
+}
+
+// setStateCheckpoint - the transaction sync must reach the checkpoint and everything the state reflects
+func (this *SyncState) setStateCheckpoint(address string, checkpoint *proto.StateCheckpoint) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.StatePeer = address
+	this.StateCheckpoint = checkpoint.Time
+	this.StateTransactionsRoot = checkpoint.TransactionsRoot
+	this.StateExecuted = checkpoint.Executed
+	if checkpoint.Time > this.Target {
+		this.Target = checkpoint.Time
+	}
+	this.persistOrLog()
+}
+
+// getStateCheckpoint
+func (this *SyncState) getStateCheckpoint() (int64, []byte, string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.StateCheckpoint, this.StateTransactionsRoot, this.StatePeer
+}
+
+// getReplaySkip - transactions the downloaded state already reflects, nil without state sync
+func (this *SyncState) getReplaySkip() func(key string) bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.StateCheckpoint == 0 {
+		return nil
+	}
+	checkpoint := this.StateCheckpoint
+	executed := make(map[string]bool, len(this.StateExecuted))
+	for _, hash := range this.StateExecuted {
+		executed[hash] = true
+	}
+	return func(key string) bool {
+		timeKey, ok := toTimeKey(key)
+		return ok && (timeKey.time < checkpoint || executed[timeKey.hash])
+	}
+}
+
+// getReplayCursor
+func (this *SyncState) getReplayCursor() string {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.ReplayCursor
+}
+
+// replayed - persisted every syncReplayCheckpointSize transactions, a restart replays at most that many again
+func (this *SyncState) replayed(key string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.ReplayCursor = key
+	this.Replayed++
+	if this.Replayed%syncReplayCheckpointSize == 0 {
+		this.persistOrLog()
+	}
+}
+
+// persistOrLog - caller holds the lock
+func (this *SyncState) persistOrLog() {
+	err := this.persist()
+	if err != nil {
+		utils.Error("unable to persist sync state", err)
+	}
+}
+
+// MarshalJSON - a consistent copy
+func (this *SyncState) MarshalJSON() ([]byte, error) {
+	type syncState SyncState
+	peers := make(map[string]SyncCursor, len(this.Peers))
+	for address, cursor := range this.Peers {
+		peers[address] = *cursor
+	}
+	return json.Marshal(&struct {
+		*syncState
+		Peers map[string]SyncCursor `json:"peers"`
+	}{(*syncState)(this), peers})
+}
+
+// IsSynchronized - false while the initial sync has not completed
+func (this *DAPoSService) IsSynchronized() bool {
+	return this.syncState != nil && this.syncState.IsComplete()
+}
+
+// GetSyncStatus
+func (this *DAPoSService) GetSyncStatus() *types.Response {
+	response := types.NewResponse()
+	if this.syncState == nil {
+		response.Status = types.StatusSynchronizing
+		return response
+	}
+	this.syncState.mutex.Lock()
+	bytes, err := json.Marshal(this.syncState)
+	this.syncState.mutex.Unlock()
+	if err != nil {
+		utils.Error(err)
+		return types.NewResponseWithError(err)
+	}
+	response.Data = json.RawMessage(bytes)
+	response.Status = types.StatusOk
+	return response
+}
+
+// synchronize - runs or resumes the initial sync, false if no peer delegate could be synchronized with
+func (this *DAPoSService) synchronize() bool {
+	if this.syncState.getPhase() == SyncPhaseState {
+		if !this.stateSynchronize() {
+			return false
+		}
+		this.syncState.setPhase(SyncPhaseTransactions)
+	}
+	if this.syncState.getPhase() == SyncPhaseTransactions {
+		if !this.peerSynchronize() || !this.verifyStateLedger() {
+			return false
+		}
+		this.syncState.setPhase(SyncPhaseReplay)
+	}
+	if this.syncState.getPhase() == SyncPhaseReplay {
+		replayTransactions(this.syncState.getReplayCursor(), this.syncState.getReplaySkip(), this.syncState.replayed)
+		this.syncState.setPhase(SyncPhaseComplete)
+	}
+	utils.Info("initial sync complete")
+	return true
+}
+
+// synchronizeWorker - retries the initial sync until it completes
+func (this *DAPoSService) synchronizeWorker() {
+	ticker := time.NewTicker(syncRetryInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if this.synchronize() {
+			return
+		}
+	}
+}
+
+// persistTransactions - persists transactions and their gossips without executing them
+func persistTransactions(transactions []*types.Transaction, gossips map[string]*types.Gossip) int {
+	count := 0
+	for i := 0; i < len(transactions); i += antiEntropyBatchSize {
+		end := i + antiEntropyBatchSize
+		if end > len(transactions) {
+			end = len(transactions)
+		}
+		txn := services.NewTxn(true)
+		times := make([]int64, 0, end-i)
+		for _, transaction := range transactions[i:end] {
+			err := transaction.Persist(txn)
+			if err != nil {
+				utils.Error(err)
+				continue
+			}
+			count++
+			times = append(times, transaction.Time)
+			gossip, ok := gossips[transaction.Hash]
+			if !ok {
+				continue
+			}
+			gossip.Transaction = *transaction
+			err = gossip.Persist(txn)
+			if err != nil {
+				utils.Error(err)
+			}
+		}
+		err := txn.Commit(nil)
+		if err != nil {
+			utils.Error(err)
+		}
+		txn.Discard()
+		rangeSummaries.refresh(times...)
+	}
+	return count
+}
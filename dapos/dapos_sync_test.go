@@ -0,0 +1,150 @@
+package dapos
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"golang.org/x/net/context"
+)
+
+// TestLoadSyncState - a missing sync state starts a sync on an empty DB and is complete on a DB with a ledger
+func TestLoadSyncState(t *testing.T) {
+	defer deleteTestKeys(t, syncStateKey)
+	tests := []struct {
+		emptyDb bool
+		phase   string
+	}{
+		{true, SyncPhaseTransactions},
+		{false, SyncPhaseComplete},
+	}
+	for _, test := range tests {
+		deleteTestKeys(t, syncStateKey)
+		state, err := loadSyncState(test.emptyDb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Phase != test.phase || state.IsComplete() != (test.phase == SyncPhaseComplete) {
+			t.Errorf("emptyDb=%t: phase is %s, expected %s", test.emptyDb, state.Phase, test.phase)
+		}
+		if countTestKeys(t, syncStateKey) != 1 {
+			t.Errorf("emptyDb=%t: sync state was not persisted", test.emptyDb)
+		}
+	}
+}
+
+// TestSyncStateResume - progress persisted under sync-state is restored after a restart
+func TestSyncStateResume(t *testing.T) {
+	defer deleteTestKeys(t, syncStateKey)
+	deleteTestKeys(t, syncStateKey)
+	state, err := loadSyncState(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.setActive([]string{"peer-a", "peer-b"})
+	state.checkpoint(1000, 7, "peer-a", "peer-b")
+	state.fail("peer-b", errors.New("unreachable"))
+	state.flag("peer-b", "contradicted-hash")
+	state.setStateCheckpoint("peer-a", &proto.StateCheckpoint{Time: 900, TransactionsRoot: []byte("root"), Executed: []string{"executedhash"}})
+	state.setPhase(SyncPhaseReplay)
+
+	resumed, err := loadSyncState(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.getPhase() != SyncPhaseReplay {
+		t.Errorf("phase is %s, expected %s", resumed.getPhase(), SyncPhaseReplay)
+	}
+	if resumed.getCursor("peer-a") != 1000 || resumed.getCursor("peer-b") != 1000 || resumed.getCursor("peer-c") != 0 {
+		t.Errorf("cursors not restored: %+v", resumed.Peers)
+	}
+	if resumed.Peers["peer-b"].Error != "unreachable" || resumed.getContradictions("peer-b") != 1 {
+		t.Errorf("peer-b not restored: %+v", resumed.Peers["peer-b"])
+	}
+	checkpoint, root, address := resumed.getStateCheckpoint()
+	if checkpoint != 900 || string(root) != "root" || address != "peer-a" {
+		t.Errorf("state checkpoint not restored [checkpoint=%d, root=%s, address=%s]", checkpoint, root, address)
+	}
+	skip := resumed.getReplaySkip()
+	if !skip(timeKeyPrefix+"899-hash") || !skip(timeKeyPrefix+"950-executedhash") || skip(timeKeyPrefix+"950-hash") {
+		t.Error("replay skip does not match the state checkpoint")
+	}
+
+	// The replay phase resumes and completes without peers.
+	service := &DAPoSService{syncState: resumed}
+	if !service.synchronize() {
+		t.Fatal("resumed sync did not complete")
+	}
+	completed, err := loadSyncState(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !completed.IsComplete() || completed.Completed.IsZero() {
+		t.Errorf("completed phase was not persisted: %s", completed.Phase)
+	}
+}
+
+// TestIsSynchronizedGatesVotes - a delegate neither gossips nor accepts transactions until its sync is complete
+func TestIsSynchronizedGatesVotes(t *testing.T) {
+	defer setThisNodeType(types.TypeDelegate)()
+	tests := []struct {
+		state        *SyncState
+		synchronized bool
+	}{
+		{nil, false},
+		{&SyncState{Phase: SyncPhaseState}, false},
+		{&SyncState{Phase: SyncPhaseTransactions}, false},
+		{&SyncState{Phase: SyncPhaseReplay}, false},
+		{&SyncState{Phase: SyncPhaseComplete}, true},
+	}
+	for _, test := range tests {
+		service := &DAPoSService{syncState: test.state}
+		if service.IsSynchronized() != test.synchronized {
+			t.Errorf("%+v: IsSynchronized returned %t", test.state, !test.synchronized)
+		}
+		if test.synchronized {
+			continue
+		}
+		if _, err := service.GossipGrpc(context.Background(), &proto.Request{}); err == nil || err.Error() != types.StatusSynchronizingAsHumanReadable {
+			t.Errorf("%+v: gossip returned %v", test.state, err)
+		}
+		if response := service.NewTransaction(&types.Transaction{Hash: "sync-hash"}); response.Status != types.StatusSynchronizing {
+			t.Errorf("%+v: new transaction status is %s", test.state, response.Status)
+		}
+	}
+}
+
+// TestGetSyncStatusHandler
+func TestGetSyncStatusHandler(t *testing.T) {
+	service := &DAPoSService{}
+	recorder := httptest.NewRecorder()
+	service.getSyncStatusHandler(recorder, httptest.NewRequest(http.MethodGet, "/v1/sync/status", nil))
+	response := &types.Response{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != types.StatusSynchronizing {
+		t.Errorf("status without a sync state is %s", response.Status)
+	}
+
+	service.syncState = &SyncState{Phase: SyncPhaseTransactions, Target: 1000, Peers: map[string]*SyncCursor{"peer-a": {Cursor: 500, Transactions: 3}}}
+	recorder = httptest.NewRecorder()
+	service.getSyncStatusHandler(recorder, httptest.NewRequest(http.MethodGet, "/v1/sync/status", nil))
+	status := &struct {
+		Status string    `json:"status"`
+		Data   SyncState `json:"data"`
+	}{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != types.StatusOk || status.Data.Phase != SyncPhaseTransactions || status.Data.Target != 1000 {
+		t.Errorf("unexpected status %s", recorder.Body.String())
+	}
+	if cursor, ok := status.Data.Peers["peer-a"]; !ok || cursor.Cursor != 500 || cursor.Transactions != 3 {
+		t.Errorf("unexpected peers %+v", status.Data.Peers)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/dispatchlabs/disgo/disgover"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Streamed receipts are polled until their status is final, a hash that stays unknown stops being waited for and no
+// stream outlives maxReceiptStreamLifetime.
+const (
+	maxStreamedReceipts   = 100
+	receiptStreamInterval = 250 * time.Millisecond
+)
+
+// Replaced in tests.
+var (
+	receiptNotFoundTimeout   = time.Minute
+	maxReceiptStreamLifetime = 10 * time.Minute
+)
+
+// SubmitTransactionGrpc - Read smart contract transactions are called and answer with their receipt
+func (this *DAPoSService) SubmitTransactionGrpc(context context.Context, request *proto.Transaction) (*proto.SubmitTransactionResponse, error) {
+	transaction, err := convertToDomainTransaction(request)
+	if err != nil {
+		return &proto.SubmitTransactionResponse{Status: types.StatusInvalidTransaction, HumanReadableStatus: err.Error(), Hash: request.Hash}, nil
+	}
+	refusedStatus, err := prepareTransaction(transaction)
+	if err != nil {
+		return &proto.SubmitTransactionResponse{Status: refusedStatus, HumanReadableStatus: err.Error(), Hash: transaction.Hash}, nil
+	}
+	if transaction.Type == types.TypeReadSmartContract {
+		receipt := this.CallTransaction(transaction)
+		return &proto.SubmitTransactionResponse{
+			Status:              receipt.Status,
+			HumanReadableStatus: receipt.HumanReadableStatus,
+			Hash:                transaction.Hash,
+			Receipt:             convertToProtoReceipt(receipt),
+		}, nil
+	}
+	response := this.NewTransaction(transaction)
+	return &proto.SubmitTransactionResponse{Status: response.Status, HumanReadableStatus: response.HumanReadableStatus, Hash: transaction.Hash}, nil
+}
+
+// GetAccountGrpc
+func (this *DAPoSService) GetAccountGrpc(context context.Context, request *proto.GetAccountRequest) (*proto.AccountResponse, error) {
+	response := this.GetAccount(request.Address)
+	accountResponse := &proto.AccountResponse{Status: response.Status, HumanReadableStatus: response.HumanReadableStatus}
+	if account, ok := response.Data.(*types.Account); ok {
+		accountResponse.Account = convertToProtoAccount(account)
+	}
+	return accountResponse, nil
+}
+
+// GetTransactionGrpc
+func (this *DAPoSService) GetTransactionGrpc(context context.Context, request *proto.GetTransactionRequest) (*proto.TransactionResponse, error) {
+	response := this.GetTransaction(request.Hash)
+	transactionResponse := &proto.TransactionResponse{Status: response.Status, HumanReadableStatus: response.HumanReadableStatus}
+	if transaction, ok := response.Data.(*types.Transaction); ok {
+		transactionResponse.Transaction = convertToProtoTransaction(transaction)
+		if transaction.Receipt.TransactionHash != "" {
+			transactionResponse.Receipt = convertToProtoReceipt(&transaction.Receipt)
+		}
+	}
+	return transactionResponse, nil
+}
+
+// GetReceiptGrpc
+func (this *DAPoSService) GetReceiptGrpc(context context.Context, request *proto.GetReceiptRequest) (*proto.ReceiptResponse, error) {
+	return this.getReceiptResponse(request.Hash), nil
+}
+
+// ListDelegatesGrpc
+func (this *DAPoSService) ListDelegatesGrpc(context context.Context, request *proto.Empty) (*proto.ListDelegatesResponse, error) {
+	response := this.GetDelegateNodes()
+	delegatesResponse := &proto.ListDelegatesResponse{Status: response.Status, HumanReadableStatus: response.HumanReadableStatus}
+	if nodes, ok := response.Data.([]*types.Node); ok {
+		for _, node := range nodes {
+			delegatesResponse.Delegates = append(delegatesResponse.Delegates, convertToProtoNode(node))
+		}
+	}
+	return delegatesResponse, nil
+}
+
+// StreamReceiptsGrpc - Sends each receipt when its status changes, the stream ends once every receipt is final or has not been
+// found for receiptNotFoundTimeout
+func (this *DAPoSService) StreamReceiptsGrpc(request *proto.StreamReceiptsRequest, stream proto.ClientGrpc_StreamReceiptsGrpcServer) error {
+	if len(request.Hashes) == 0 || len(request.Hashes) > maxStreamedReceipts {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("between 1 and %d hashes may be streamed", maxStreamedReceipts))
+	}
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		return status.Error(codes.FailedPrecondition, types.StatusNotDelegateAsHumanReadable)
+	}
+	sent := make(map[string]string, len(request.Hashes))
+	notFoundSince := make(map[string]time.Time, len(request.Hashes))
+	ticker := time.NewTicker(receiptStreamInterval)
+	defer ticker.Stop()
+	lifetime := time.NewTimer(maxReceiptStreamLifetime)
+	defer lifetime.Stop()
+	for {
+		pending := 0
+		now := time.Now()
+		for _, hash := range request.Hashes {
+			response := this.getReceiptResponse(hash)
+			receiptStatus := response.Status
+			if response.Receipt != nil {
+				receiptStatus = response.Receipt.Status
+			}
+			if receiptStatus != types.StatusNotFound {
+				delete(notFoundSince, hash)
+			} else if _, ok := notFoundSince[hash]; !ok {
+				notFoundSince[hash] = now
+			}
+			if !isFinalReceiptStatus(receiptStatus) && !isLostReceipt(notFoundSince, hash, now) {
+				pending++
+			}
+			if previous, ok := sent[hash]; ok && previous == receiptStatus {
+				continue
+			}
+			err := stream.Send(response)
+			if err != nil {
+				return err
+			}
+			sent[hash] = receiptStatus
+		}
+		if pending == 0 {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-lifetime.C:
+			return status.Error(codes.DeadlineExceeded, fmt.Sprintf("receipts are streamed for at most %s", maxReceiptStreamLifetime))
+		case <-ticker.C:
+		}
+	}
+}
+
+// isLostReceipt - A hash not found for receiptNotFoundTimeout is not expected to be gossiped to this delegate anymore
+func isLostReceipt(notFoundSince map[string]time.Time, hash string, now time.Time) bool {
+	since, ok := notFoundSince[hash]
+	return ok && now.Sub(since) >= receiptNotFoundTimeout
+}
+
+// getReceiptResponse
+func (this *DAPoSService) getReceiptResponse(hash string) *proto.ReceiptResponse {
+	response := this.GetReceipt(hash)
+	receiptResponse := &proto.ReceiptResponse{Status: response.Status, HumanReadableStatus: response.HumanReadableStatus}
+	if receipt, ok := response.Data.(*types.Receipt); ok {
+		receiptResponse.Receipt = convertToProtoReceipt(receipt)
+	}
+	return receiptResponse
+}
+
+// isFinalReceiptStatus - A receipt that is not found yet may still be gossiped to this delegate
+func isFinalReceiptStatus(status string) bool {
+	return status != types.StatusPending && status != types.StatusReceived && status != types.StatusNotFound
+}
+
+// convertToProtoReceipt
+func convertToProtoReceipt(receipt *types.Receipt) *proto.Receipt {
+	var contractResult []byte
+	if receipt.ContractResult != nil {
+		var err error
+		contractResult, err = json.Marshal(receipt.ContractResult)
+		if err != nil {
+			utils.Error("failed to marshal contract result", err)
+		}
+	}
+	return &proto.Receipt{
+		TransactionHash:     receipt.TransactionHash,
+		Status:              receipt.Status,
+		HumanReadableStatus: receipt.HumanReadableStatus,
+		ContractAddress:     receipt.ContractAddress,
+		ContractResult:      contractResult,
+		Created:             toNanoseconds(receipt.Created),
+	}
+}
+
+// convertToProtoNode
+func convertToProtoNode(node *types.Node) *proto.Node {
+	protoNode := &proto.Node{
+		Address:          node.Address,
+		GrpcEndpoint:     convertToProtoEndpoint(node.GrpcEndpoint),
+		HttpEndpoint:     convertToProtoEndpoint(node.HttpEndpoint),
+		LocalHttpApiPort: node.LocalHttpApiPort,
+		Type:             node.Type,
+		Status:           node.Status,
+		StatusTime:       toNanoseconds(node.StatusTime),
+	}
+	if node.Version != nil {
+		protoNode.Version = &proto.Version{Version: node.Version.Version, BuildTime: node.Version.BuildTime}
+	}
+	return protoNode
+}
+
+// convertToProtoEndpoint
+func convertToProtoEndpoint(endpoint *types.Endpoint) *proto.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	return &proto.Endpoint{Host: endpoint.Host, Port: endpoint.Port}
+}
+
+// toNanoseconds - zero for an unset time
+func toNanoseconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
@@ -0,0 +1,171 @@
+package dapos
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/dispatchlabs/disgo/disgover"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testReceiptStream - calls send with each response
+type testReceiptStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	send func(*proto.ReceiptResponse)
+}
+
+// Context
+func (this *testReceiptStream) Context() context.Context {
+	return this.ctx
+}
+
+// Send
+func (this *testReceiptStream) Send(response *proto.ReceiptResponse) error {
+	this.send(response)
+	return nil
+}
+
+// setThisNodeType - returns a function restoring the previous type
+func setThisNodeType(nodeType string) func() {
+	thisNode := disgover.GetDisGoverService().ThisNode
+	previous := thisNode.Type
+	thisNode.Type = nodeType
+	return func() {
+		thisNode.Type = previous
+	}
+}
+
+// TestStreamReceiptsGrpcRefused
+func TestStreamReceiptsGrpcRefused(t *testing.T) {
+	tooMany := make([]string, maxStreamedReceipts+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("hash-%d", i)
+	}
+	tests := []struct {
+		name     string
+		nodeType string
+		hashes   []string
+		code     codes.Code
+	}{
+		{"no hashes", types.TypeDelegate, nil, codes.InvalidArgument},
+		{"too many hashes", types.TypeDelegate, tooMany, codes.InvalidArgument},
+		{"not a delegate", types.TypeNode, []string{"hash"}, codes.FailedPrecondition},
+	}
+	for _, test := range tests {
+		restore := setThisNodeType(test.nodeType)
+		stream := &testReceiptStream{ctx: context.Background(), send: func(*proto.ReceiptResponse) {
+			t.Errorf("%s: a receipt was sent", test.name)
+		}}
+		err := GetDAPoSService().StreamReceiptsGrpc(&proto.StreamReceiptsRequest{Hashes: test.hashes}, stream)
+		if status.Code(err) != test.code {
+			t.Errorf("%s: returned %v, expected %s", test.name, err, test.code)
+		}
+		restore()
+	}
+}
+
+// TestStreamReceiptsGrpc - each status change is sent once and the stream ends when every receipt is final
+func TestStreamReceiptsGrpc(t *testing.T) {
+	defer setThisNodeType(types.TypeDelegate)()
+	pending := &types.Receipt{TransactionHash: "stream-pending", Status: types.StatusPending, Created: time.Now()}
+	final := &types.Receipt{TransactionHash: "stream-final", Status: types.StatusOk, ContractResult: []interface{}{"result"}, Created: time.Now()}
+	pending.Cache(services.GetCache())
+	final.Cache(services.GetCache())
+	defer services.GetCache().Delete(pending.Key())
+	defer services.GetCache().Delete(final.Key())
+
+	var sent []string
+	stream := &testReceiptStream{ctx: context.Background(), send: func(response *proto.ReceiptResponse) {
+		if response.Receipt == nil {
+			t.Fatalf("response without a receipt: %v", response)
+		}
+		sent = append(sent, response.Receipt.TransactionHash+"="+response.Receipt.Status)
+		if response.Receipt.TransactionHash == pending.TransactionHash {
+			pending.Status = types.StatusOk
+		}
+	}}
+	err := GetDAPoSService().StreamReceiptsGrpc(&proto.StreamReceiptsRequest{Hashes: []string{pending.TransactionHash, final.TransactionHash}}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"stream-pending=Pending", "stream-final=Ok", "stream-pending=Ok"}
+	if fmt.Sprint(sent) != fmt.Sprint(expected) {
+		t.Errorf("sent %v, expected %v", sent, expected)
+	}
+}
+
+// TestStreamReceiptsGrpcCancelled - a receipt that is never final streams until the client goes away
+func TestStreamReceiptsGrpcCancelled(t *testing.T) {
+	defer setThisNodeType(types.TypeDelegate)()
+	ctx, cancel := context.WithCancel(context.Background())
+	sends := 0
+	stream := &testReceiptStream{ctx: ctx, send: func(response *proto.ReceiptResponse) {
+		sends++
+		if response.Status != types.StatusNotFound {
+			t.Errorf("status is %s, expected %s", response.Status, types.StatusNotFound)
+		}
+		cancel()
+	}}
+	err := GetDAPoSService().StreamReceiptsGrpc(&proto.StreamReceiptsRequest{Hashes: []string{"stream-missing"}}, stream)
+	if err != context.Canceled {
+		t.Errorf("returned %v, expected %v", err, context.Canceled)
+	}
+	if sends != 1 {
+		t.Errorf("sent %d responses, expected 1", sends)
+	}
+}
+
+// TestStreamReceiptsGrpcNotFound - the stream ends once a hash has not been found for receiptNotFoundTimeout
+func TestStreamReceiptsGrpcNotFound(t *testing.T) {
+	defer setThisNodeType(types.TypeDelegate)()
+	defer func(timeout time.Duration) { receiptNotFoundTimeout = timeout }(receiptNotFoundTimeout)
+	receiptNotFoundTimeout = 2 * receiptStreamInterval
+	sends := 0
+	stream := &testReceiptStream{ctx: context.Background(), send: func(response *proto.ReceiptResponse) {
+		sends++
+	}}
+	err := GetDAPoSService().StreamReceiptsGrpc(&proto.StreamReceiptsRequest{Hashes: []string{"stream-missing"}}, stream)
+	if err != nil {
+		t.Errorf("returned %v", err)
+	}
+	if sends != 1 {
+		t.Errorf("sent %d responses, expected 1", sends)
+	}
+}
+
+// TestStreamReceiptsGrpcLifetime - a receipt that stays pending does not keep the stream open past its lifetime
+func TestStreamReceiptsGrpcLifetime(t *testing.T) {
+	defer setThisNodeType(types.TypeDelegate)()
+	defer func(lifetime time.Duration) { maxReceiptStreamLifetime = lifetime }(maxReceiptStreamLifetime)
+	maxReceiptStreamLifetime = 2 * receiptStreamInterval
+	pending := &types.Receipt{TransactionHash: "stream-stuck", Status: types.StatusPending, Created: time.Now()}
+	pending.Cache(services.GetCache())
+	defer services.GetCache().Delete(pending.Key())
+
+	stream := &testReceiptStream{ctx: context.Background(), send: func(response *proto.ReceiptResponse) {}}
+	err := GetDAPoSService().StreamReceiptsGrpc(&proto.StreamReceiptsRequest{Hashes: []string{pending.TransactionHash}}, stream)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("returned %v, expected %s", err, codes.DeadlineExceeded)
+	}
+}
+
+// TestConvertToProtoReceipt
+func TestConvertToProtoReceipt(t *testing.T) {
+	created := time.Now()
+	receipt := convertToProtoReceipt(&types.Receipt{TransactionHash: "hash", Status: types.StatusOk, ContractResult: []interface{}{"a", 1}, Created: created})
+	if string(receipt.ContractResult) != `["a",1]` || receipt.Created != created.UnixNano() {
+		t.Errorf("converted to %v", receipt)
+	}
+	receipt = convertToProtoReceipt(&types.Receipt{TransactionHash: "hash", Status: types.StatusPending})
+	if receipt.ContractResult != nil || receipt.Created != 0 {
+		t.Errorf("unset fields converted to %v", receipt)
+	}
+}
@@ -17,7 +17,9 @@
 package dapos
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/dgraph-io/badger"
@@ -27,12 +29,12 @@ import (
 	"github.com/dispatchlabs/disgo/dapos/proto"
 	"github.com/dispatchlabs/disgo/disgover"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"strings"
 	"github.com/dispatchlabs/disgo/commons/helper"
-	"encoding/json"
+	"math"
 	"math/big"
-	"strconv"
 )
 
 // TODO: Should we GZIP the response from remote call?
@@ -45,23 +47,25 @@ var refreshTimestamp = time.Now()
 // WithGrpc -
 func (this *DAPoSService) WithGrpc() *DAPoSService {
 	proto.RegisterDAPoSGrpcServer(services.GetGrpcService().Server, this)
+	services.GetGrpcService().RestrictMethods([]string{types.TypeDelegate},
+		"/proto.DAPoSGrpc/SynchronizeGrpc",
+		"/proto.DAPoSGrpc/SynchronizeAccountsGrpc",
+		"/proto.DAPoSGrpc/SynchronizeTransactionsGrpc",
+		"/proto.DAPoSGrpc/SynchronizeGossipGrpc",
+		"/proto.DAPoSGrpc/GossipGrpc",
+		"/proto.DAPoSGrpc/SynchronizeRangesGrpc",
+		"/proto.DAPoSGrpc/SynchronizeRangeHashesGrpc",
+		"/proto.DAPoSGrpc/SynchronizeByHashesGrpc",
+	)
+
+	// The client API is open to unsigned requests from external clients, limited like the public HTTP API.
+	proto.RegisterClientGrpcServer(services.GetGrpcService().Server, this)
+	limits := services.NewGrpcLimits("/proto.ClientGrpc/", types.GetConfig().HttpLimits)
+	services.GetGrpcService().AddUnaryInterceptor(limits.UnaryInterceptor)
+	services.GetGrpcService().AddStreamInterceptor(limits.StreamInterceptor)
 	return this
 }
 
-//func (this *DAPoSService) SynchronizeAccountsGrpc(context context.Context, request *proto.SynchronizeRequest) (*proto.SynchronizeAccountsResponse, error) {
-//	utils.Info("synchronizing accounts with a delegate...")
-//
-//	if transactionMap == nil { //todo add OR if timestamp is old
-//		loadMaps()
-//	}
-//	if transactionMap[request.Index] == nil {
-//		utils.Info("DB synchronized Account")
-//		transactionMap[request.Index] = make([]*proto.Transaction, 0)
-//		fmt.Printf("\nCountMap: %v\n", helper.GetCounts().ToPrettyJson())
-//	}
-//	return &proto.SynchronizeAccountsResponse{Accounts: accountMap[request.Index]}, nil
-//}
-
 // SyncTransactions - PROTO - Called when a peer asks to sync missed TX, usually this happens at node boot
 func (this *DAPoSService) SynchronizeTransactionsGrpc(context context.Context, request *proto.SynchronizeRequest) (*proto.SynchronizeTransactionsResponse, error) {
 	utils.Info("synchronizing index", request.Index, " of transactions to a delegate...")
@@ -188,8 +192,8 @@ func addTx(txPage int64, value []byte) {
 	if transactionMap[txPage] == nil {
 		transactionMap[txPage] = make([]*proto.Transaction, 0)
 	}
-	transaction := &types.Transaction{}
-	if err := json.Unmarshal(value, transaction); err != nil {
+	transaction, err := types.ToTransactionFromRecord(value)
+	if err != nil {
 		utils.Error(err)
 		//helper.HandleInvalidTransaction()
 	} else {
@@ -206,8 +210,8 @@ func addGossip(gossipPage int64, value []byte) {
 	if gossipMap[gossipPage] == nil {
 		gossipMap[gossipPage] = make([]*proto.Gossip, 0)
 	}
-	gossip := &types.Gossip{}
-	if err := json.Unmarshal(value, gossip); err != nil {
+	gossip, err := types.ToGossipFromRecord(value)
+	if err != nil {
 		utils.Error(err)
 	} else {
 		pgossip := convertToProtoGossip(gossip)
@@ -271,176 +275,105 @@ func (this *DAPoSService) SynchronizeGrpc(constext context.Context, request *pro
 	return &proto.SynchronizeResponse{Items: items}, nil
 }
 
-// peerDelegateExecuteGrpc //Caller
-func (this *DAPoSService) peerSynchronize() {
-	utils.Info("synchronizing DB with peer delegate...")
-
+// peerSynchronize - pulls the transactions and gossips of up to syncPeerCount delegates in parallel up to the sync target, resuming from the
+// last checkpoint, they are executed by replayTransactions
+func (this *DAPoSService) peerSynchronize() bool {
+	utils.Info("synchronizing DB with peer delegates...")
 
-	// Find delegate nodes.
-	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(),types.TypeDelegate)
+	delegates, err := getPeerDelegates()
 	if err != nil {
 		utils.Error(err)
-		return
+		return false
 	}
 	if len(delegates) == 0 {
 		utils.Warn("unable to find a delegate to synchronize with")
-		return
+		return true
+	}
+	// Old transactions have the rumor quorums of the delegates of their time.
+	err = disgover.GetDisGoverService().SynchronizeDelegateSets()
+	if err != nil {
+		utils.Warn("unable to synchronize delegate set history, quorums of old transactions are counted against the known sets", err)
+	}
+	history, err := getDelegateHistory()
+	if err != nil {
+		utils.Error(err)
+		return false
 	}
 
-	utils.Info("number of delegates is ", len(delegates))
-
-	//This isn't actually looping through all of the delegates there is a return in the loop
-	for _, delegate := range delegates {
-
-		fmt.Printf("going to try to connect to delegate %s\n", delegate.Address)
-
-		// Is this me?
-		if delegate.Address == disgover.GetDisGoverService().ThisNode.Address {
-			fmt.Println("This delegate is me")
-			continue
-		}
-		// Connect to delegate.
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), grpc.WithInsecure())
-		if err != nil {
-			utils.Warn(fmt.Sprintf("unable to connect to delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-			continue
-		} else {
-			utils.Info("connecting to delegate %s worked", delegate.Address)
-		}
-		defer conn.Close()
-		client := proto.NewDAPoSGrpcClient(conn)
-		contextWithTimeout, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		// Synchronize
-		var txIndex int64 = 0
-		var gossipIndex int64 = 0
-		count := 0
-		//This For loop indexes through the sending delegates TXs
-		for {
-			//Open up a new badger db tx
-			txn := services.NewTxn(true)
-			defer txn.Discard()
-
-			//Synchronizing Transactions First
-			txResponse, err := client.SynchronizeTransactionsGrpc(contextWithTimeout, &proto.SynchronizeRequest{Index: txIndex})
+	// Delegates that served contradicted data are tried last.
+	state := this.syncState
+	sort.SliceStable(delegates, func(i, j int) bool {
+		return state.getContradictions(delegates[i].Address) < state.getContradictions(delegates[j].Address)
+	})
+	for len(delegates) > 0 {
+		peers := make([]*syncPeer, 0, syncPeerCount)
+		for len(delegates) > 0 && len(peers) < syncPeerCount {
+			delegate := delegates[0]
+			delegates = delegates[1:]
+			conn, err := services.GetGrpcConnection(delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port)
 			if err != nil {
-				utils.Warn(fmt.Sprintf("unable to synchronize with delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
+				utils.Warn(fmt.Sprintf("unable to connect to delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
 				continue
 			}
-			if len(txResponse.Transactions) == 0 {
-				break
-			}
-
-
-			/*
-			 * Adding code to avoid issues we are having with corrupt data coming from sync
-			 * Concerned about the case where current delegate might have an old version of a key value
-			 * At the moment this is so much less of a concern than getting an invalid JSON
-			 * that I'm willing to let this be the case until we get the Merkle tree implemented. (B.S.)
-			 */
-			//for _, item := range response.Items {
-			//	exists, _ := txn.Get([]byte(item.Key))
-			//	if exists == nil {
-			//		if helper.ValidateSync([]byte(item.Key), item.Value) {
-			//			err = txn.Set([]byte(item.Key), item.Value)
-			//			if err != nil {
-			//				utils.Error(err)
-			//			}
-			//		}
-			//	} else {
-			//		utils.Info(fmt.Sprintf("skipping key: %s ", item.Key))
-			//	}
-			//}
-			//index += int64(len(response.Items))
-
-			//Takes newly received transactions and persists them to badger
-			for _, ptx := range txResponse.Transactions {
-				count++
-				tx := convertToDomainTransaction(ptx)
-				//exists, _ := txn.Get([]byte(tx.Key()))
-				//if exists == nil {
-					err = tx.Persist(txn)
-					if err != nil {
-						utils.Error(err)
-					}
-				//}
-				if helper.ValidateTxSync(tx) {
-					if err != nil {
-						utils.Error(err)
-					}
-				}
-			}
-			//index += int64(len(response.Transactions))
-			txIndex++
-			err = txn.Commit(nil)
-			if err != nil {
-				utils.Error(err)
-			}
+			peers = append(peers, &syncPeer{address: delegate.Address, client: proto.NewDAPoSGrpcClient(conn)})
+		}
+		if len(peers) == 0 {
+			break
 		}
 
-		//this for loop indexes through the sending delegate's gossips
-		for {
-			//Open up a new Badger db TX
-			txn := services.NewTxn(true)
-			defer txn.Discard()
-
-			//Call the next index of gossips from the sender
-			gossipResponse, err := client.SynchronizeGossipGrpc(contextWithTimeout, &proto.SynchronizeRequest{Index: gossipIndex})
-			if err != nil {
-				utils.Warn(fmt.Sprintf("unable to synchronize with delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-				continue
-			}
-			if len(gossipResponse.Gossips) == 0 {
-				break
-			}
-
-
-			//Takes newly received Gossips and Persists them to badger
-			for _, pg := range gossipResponse.Gossips {
-				count++
-				gossip := convertToDomainGossip(pg)
-				//exists, _ := txn.Get([]byte(gossip.Key()))
-				//if exists == nil {
-					err = gossip.Persist(txn)
-					if err != nil {
-						utils.Error(err)
-					}
-				//}
-				if helper.ValidateGossipSync(gossip) {
-					if err != nil {
-						utils.Error(err)
-					}
-				}
+		// Resume from the earliest checkpoint of the peers.
+		addresses := make([]string, 0, len(peers))
+		cursor := state.Target
+		for _, peer := range peers {
+			addresses = append(addresses, peer.address)
+			if peerCursor := state.getCursor(peer.address); peerCursor < cursor {
+				cursor = peerCursor
 			}
-			//index += int64(len(response.Transactions))
-			gossipIndex++
-			err = txn.Commit(nil)
-			if err != nil {
-				utils.Error(err)
-			}
-
 		}
+		state.setActive(addresses)
+		utils.Info(fmt.Sprintf("synchronizing with delegates [addresses=%v, cursor=%d, target=%d]", addresses, cursor, state.Target))
 
-		utils.Info("this is where the Last CountMap gets printed")
-		utils.Info("\nCountMap: %v\n", helper.GetCounts().ToPrettyJson())
-		fmt.Printf("\nCountMap: %v\n", helper.GetCounts().ToPrettyJson())
-		utils.Info(fmt.Sprintf("synchronized %d records from peer delegate %s's DB", count, delegate.Address))
-
-		return
-		//This return means that db is only synced with the first (non-self) delegate returned by the seed node
-		//That's good to know because basically the for loop is nearly pointless
+		err = this.synchronizeRange(peers, &proto.Range{Start: cursor, End: state.Target}, history)
+		if err != nil {
+			utils.Warn("unable to synchronize with delegates", err)
+			continue
+		}
+		utils.Info(fmt.Sprintf("synchronized with peer delegates %v", addresses))
+		return true
 	}
+	return false
 }
 
 // Gossip
 func (this *DAPoSService) GossipGrpc(context context.Context, request *proto.Request) (*proto.Response, error) {
+	if !this.IsSynchronized() {
+		return nil, errors.New(types.StatusSynchronizingAsHumanReadable)
+	}
+	if !types.IsCompatibleProtocol(request.ProtocolVersion) {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("protocol version %d is below the minimum %d", request.ProtocolVersion, types.GetMinProtocolVersion()))
+	}
 	gossip, err := types.ToGossipFromJson([]byte(request.Payload))
 	if err != nil {
 		utils.Error(err)
+		disgover.GetDisGoverService().PenalizePeer(context, disgover.OffenseInvalidGossip)
 		return nil, err
 	}
 
+	// Peers sending invalid transactions or forged rumors lose reputation.
+	err = gossip.Transaction.Verify()
+	if err != nil {
+		utils.Warn(fmt.Sprintf("received invalid gossip [hash=%s]", gossip.Transaction.Hash), err)
+		disgover.GetDisGoverService().PenalizePeer(context, disgover.OffenseInvalidGossip)
+		return nil, err
+	}
+	for _, rumor := range gossip.Rumors {
+		if rumor.TransactionHash != gossip.Transaction.Hash || !rumor.Verify() {
+			utils.Warn(fmt.Sprintf("received forged rumor [hash=%s, address=%s]", gossip.Transaction.Hash, rumor.Address))
+			disgover.GetDisGoverService().PenalizePeer(context, disgover.OffenseForgedRumor)
+			return nil, errors.New("gossip contains a forged rumor")
+		}
+	}
+
 	// Synchronize gossip.
 	synchronizedGossip, err, addToChan := this.synchronizeGossip(gossip)
 	if err != nil {
@@ -453,7 +386,7 @@ func (this *DAPoSService) GossipGrpc(context context.Context, request *proto.Req
 		this.gossipChan <- gossip
 	}
 
-	return &proto.Response{Payload: synchronizedGossip.String()}, nil
+	return &proto.Response{Payload: synchronizedGossip.String(), ProtocolVersion: types.ProtocolVersion, Capabilities: types.GetCapabilities()}, nil
 }
 
 // peerGossipGrpc
@@ -471,9 +404,12 @@ func (this *DAPoSService) peerGossipGrpc(node types.Node, gossip *types.Gossip)
 	defer cancel()
 
 	// Remote gossip.
-	response, err := client.GossipGrpc(contextWithTimeout, &proto.Request{Payload: gossip.String()})
+	start := time.Now()
+	response, err := client.GossipGrpc(contextWithTimeout, &proto.Request{Payload: gossip.String(), ProtocolVersion: types.ProtocolVersion, Capabilities: types.GetCapabilities()})
 	if err != nil {
 		utils.Error(fmt.Sprintf("cannot connect to node [host=%s, port=%d]", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), err)
+		disgover.GetDisGoverService().RecordFailure(node.Address)
+		penalizeTimeout(node.Address, err)
 
 		txn := services.NewTxn(true)
 		defer txn.Discard()
@@ -486,6 +422,15 @@ func (this *DAPoSService) peerGossipGrpc(node types.Node, gossip *types.Gossip)
 		}
 		return nil, err
 	}
+	disgover.GetDisGoverService().RecordSuccess(node.Address, time.Since(start))
+
+	// Demote delegates below the minimum protocol version, they are no longer picked to gossip with.
+	if node.ProtocolVersion != response.ProtocolVersion {
+		recordProtocol(node.Address, response.ProtocolVersion, response.Capabilities)
+	}
+	if !types.IsCompatibleProtocol(response.ProtocolVersion) {
+		return nil, fmt.Errorf("delegate protocol version %d is below the minimum %d [address=%s]", response.ProtocolVersion, types.GetMinProtocolVersion(), node.Address)
+	}
 	remoteGossip, err := types.ToGossipFromJson([]byte(response.Payload))
 	if err != nil {
 		utils.Error(err)
@@ -497,12 +442,34 @@ func (this *DAPoSService) peerGossipGrpc(node types.Node, gossip *types.Gossip)
 	return remoteGossip, err
 }
 
+// recordProtocol - records the protocol a delegate answered with on its cached node, warns when it is below the minimum
+func recordProtocol(address string, protocolVersion int64, capabilities []string) {
+	node, err := types.ToNodeFromCache(services.GetCache(), address)
+	if err != nil {
+		return
+	}
+	updated := *node
+	updated.ProtocolVersion = protocolVersion
+	updated.Capabilities = capabilities
+	updated.Cache(services.GetCache())
+	if !updated.IsCompatible() {
+		utils.Warn(fmt.Sprintf("delegate is below the minimum protocol version [address=%s, protocolVersion=%d]", address, protocolVersion))
+	}
+}
+
+// penalizeTimeout - peers that let calls time out lose reputation
+func penalizeTimeout(address string, err error) {
+	if status.Code(err) == codes.DeadlineExceeded {
+		disgover.GetDisGoverService().PenalizeAddress(address, disgover.OffenseTimeout)
+	}
+}
+
 func convertToProtoAccount(acct *types.Account) *proto.Account {
 	return &proto.Account{
 		Address:			acct.Address,
 		Name:				acct.Name,
 		Balance:			acct.Balance.String(),
-		HertzAvailable:		acct.HertzAvailable,
+		HertzAvailable:		toHertzAvailable(acct.HertzAvailable),
 		TransactionHash:	acct.TransactionHash,
 		Created:			utils.ToMilliSeconds(acct.Created),
 		Updated:			utils.ToMilliSeconds(acct.Updated),
@@ -510,21 +477,22 @@ func convertToProtoAccount(acct *types.Account) *proto.Account {
 	}
 }
 
-func convertToDomainAccount(pacct *proto.Account) *types.Account {
-	bFloat, err := strconv.ParseFloat(pacct.Balance, 64)
+// convertToDomainAccount - an unparsable balance rejects the account
+func convertToDomainAccount(pacct *proto.Account) (*types.Account, error) {
+	balance, err := types.ToAmount(pacct.Balance)
 	if err != nil {
-		utils.Error("failed to parse balance")
+		return nil, err
 	}
 	return &types.Account{
 		Address:         pacct.Address,
 		Name:            pacct.Name,
-		Balance:         big.NewInt(int64(bFloat)),
-		HertzAvailable:  pacct.HertzAvailable,
+		Balance:         balance,
+		HertzAvailable:  new(big.Int).SetUint64(pacct.HertzAvailable),
 		TransactionHash: pacct.TransactionHash,
 		Created:         utils.ToTimeFromMilliseconds(pacct.Created),
 		Updated:         utils.ToTimeFromMilliseconds(pacct.Updated),
 		Nonce:           pacct.Nonce,
-	}
+	}, nil
 }
 
 func convertToProtoTransaction(tx *types.Transaction) *proto.Transaction {
@@ -533,7 +501,8 @@ func convertToProtoTransaction(tx *types.Transaction) *proto.Transaction {
 		Type:		int32(tx.Type),
 		From:      	tx.From,
 		To:        	tx.To,
-		Value:     	tx.Value,
+		Value:     	toLegacyValue(tx.Value),
+		Amount:		types.AmountString(tx.Value),
 		Code:		tx.Code,
 		Abi:		tx.Abi,
 		Method:		tx.Method,
@@ -546,13 +515,22 @@ func convertToProtoTransaction(tx *types.Transaction) *proto.Transaction {
 	}
 }
 
-func convertToDomainTransaction(ptx *proto.Transaction) *types.Transaction {
+// convertToDomainTransaction - an unparsable amount rejects the transaction, peers running older versions only send value
+func convertToDomainTransaction(ptx *proto.Transaction) (*types.Transaction, error) {
+	value := big.NewInt(ptx.Value)
+	if ptx.Amount != "" {
+		var err error
+		value, err = types.ToAmount(ptx.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &types.Transaction{
 		Hash:      	ptx.Hash,
 		Type:		byte(ptx.Type),
 		From:      	ptx.From,
 		To:        	ptx.To,
-		Value:     	ptx.Value,
+		Value:     	value,
 		Code:		ptx.Code,
 		Abi:		ptx.Abi,
 		Method:		ptx.Method,
@@ -562,7 +540,7 @@ func convertToDomainTransaction(ptx *proto.Transaction) *types.Transaction {
 		Hertz:		ptx.Hertz,
 		FromName:	ptx.FromName,
 		ToName:		ptx.ToName,
-	}
+	}, nil
 }
 
 func convertToProtoRumor(rumor *types.Rumor) *proto.Rumor {
@@ -606,3 +584,22 @@ func convertToDomainGossip(pgossip *proto.Gossip) *types.Gossip {
 		Rumors:			rumors,
 	}
 }
+
+// toLegacyValue - int64 value for peers that do not read amount, zero when the value does not fit
+func toLegacyValue(value *big.Int) int64 {
+	if value == nil || !value.IsInt64() {
+		return 0
+	}
+	return value.Int64()
+}
+
+// toHertzAvailable - hertz available is transient and recalculated on read, saturate instead of overflowing
+func toHertzAvailable(hertzAvailable *big.Int) uint64 {
+	if hertzAvailable == nil {
+		return 0
+	}
+	if !hertzAvailable.IsUint64() {
+		return math.MaxUint64
+	}
+	return hertzAvailable.Uint64()
+}
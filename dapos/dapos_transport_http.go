@@ -26,8 +26,6 @@ import (
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/gorilla/mux"
-	"github.com/dispatchlabs/disgo/commons/helper"
-	"encoding/hex"
 )
 
 // WithHttp -
@@ -55,6 +53,7 @@ func (this *DAPoSService) WithHttp() *DAPoSService {
 	services.GetHttpRouter().HandleFunc("/v1/artifacts/{hash}", this.unsupportedFunctionHandler).Methods("GET")
 	//delegates
 	services.GetHttpRouter().HandleFunc("/v1/delegates", this.getDelegatesHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/delegates/history", this.getDelegateSetsHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/delegates/subscribe", this.unsupportedFunctionHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/delegates/unsubscribe", this.unsupportedFunctionHandler).Methods("POST")
 
@@ -65,9 +64,14 @@ func (this *DAPoSService) WithHttp() *DAPoSService {
 	services.GetHttpRouter().HandleFunc("/v1/queue", this.getQueueHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/gossips", this.getGossipsHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/gossips/{hash}", this.getGossipHandler).Methods("GET")
+	services.GetLocalHttpRouter().HandleFunc("/v1/metrics", this.getMetricsHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/sync/status", this.getSyncStatusHandler).Methods("GET")
 
 	services.GetHttpRouter().HandleFunc("/v1/receipts/{hash}", this.unsupportedFunctionHandler).Methods("GET")
 
+	//Ethereum JSON-RPC
+	services.GetHttpRouter().HandleFunc("/v1/eth", this.jsonRpcHandler).Methods("POST")
+
 	return this
 }
 
@@ -111,9 +115,20 @@ func setHeaders(response *types.Response, responseWriter *http.ResponseWriter) {
 // getDelegatesHandler
 func (this *DAPoSService) getDelegatesHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	setHeaders(nil, &responseWriter)
+	if request.URL.Query().Get("health") == "true" {
+		responseWriter.Write([]byte(this.GetDelegateHealth().String()))
+		return
+	}
 	responseWriter.Write([]byte(this.GetDelegateNodes().String()))
 }
 
+// getDelegateSetsHandler
+func (this *DAPoSService) getDelegateSetsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetDelegateSets()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 // getSeedAddressHandler
 func (this *DAPoSService) getSeedAddressHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	response := types.NewResponse()
@@ -172,32 +187,11 @@ func (this *DAPoSService) newTransactionHandler(responseWriter http.ResponseWrit
 		}
 	}
 
-	txn := services.NewTxn(true)
-	defer txn.Discard()
-
-	if transaction.Type == types.TypeDeploySmartContract {
-		_, err := helper.GetABI(hex.EncodeToString([]byte(transaction.Abi)))
-		if err != nil {
-			utils.Error("Paramater type error", err)
-			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusJsonParseError, err), http.StatusBadRequest)
-			return
-		}
-	}
-
-	if transaction.Type == types.TypeExecuteSmartContract || transaction.Type == types.TypeReadSmartContract {
-		contractTx, err := types.ToTransactionByAddress(txn, transaction.To)
-		if err != nil {
-			utils.Error(err)
-			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: Could not find contract with address %s"}`, types.StatusNotFound, transaction.To), http.StatusBadRequest)
-			return
-		}
-		transaction.Abi = contractTx.Abi
-		_, err = helper.GetConvertedParams(transaction)
-		if err != nil {
-			utils.Error("Paramater type error", err)
-			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusJsonParseError, err), http.StatusBadRequest)
-			return
-		}
+	status, err := prepareTransaction(transaction)
+	if err != nil {
+		utils.Error(err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, status, err), http.StatusBadRequest)
+		return
 	}
 
 	//If the TX is a read, then "call" transaction; else create NewTransaction
@@ -251,6 +245,20 @@ func (this *DAPoSService) getQueueHandler(responseWriter http.ResponseWriter, re
 	responseWriter.Write([]byte(response.String()))
 }
 
+// getMetricsHandler
+func (this *DAPoSService) getMetricsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetMetrics()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getSyncStatusHandler
+func (this *DAPoSService) getSyncStatusHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetSyncStatus()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 // getArtifactHandler
 func (this *DAPoSService) unsupportedFunctionHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	response := this.ToBeSupported()
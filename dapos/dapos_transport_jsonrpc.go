@@ -0,0 +1,811 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net/http"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/disgover"
+	"github.com/dispatchlabs/disgo/dvm"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common/hexutil"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
+)
+
+// Ethereum JSON-RPC 2.0 subset for existing Ethereum tooling. Dispatch has no blocks: every transaction is reported as
+// its own block, the block hash is the transaction hash and the block number is the transaction time in milliseconds.
+// Only the latest state is available.
+
+// JSON-RPC 2.0 error codes
+const (
+	jsonRpcParseError     = -32700
+	jsonRpcInvalidRequest = -32600
+	jsonRpcMethodNotFound = -32601
+	jsonRpcInvalidParams  = -32602
+	jsonRpcInternalError  = -32603
+	jsonRpcServerError    = -32000
+	jsonRpcNotSupported   = -32004 // EIP-1474
+)
+
+const (
+	maxJsonRpcBatch = 100
+	maxJsonRpcLogs  = 10000
+	maxJsonRpcScan  = 100000 // Transactions the eth_getLogs calls of an HTTP request may read, logs are filtered after reading the transaction
+)
+
+// jsonRpcMethods - Methods that read the ledger are only served by delegates
+var jsonRpcMethods = map[string]struct {
+	call             func(*DAPoSService, []json.RawMessage, *jsonRpcBudget) (interface{}, *jsonRpcError)
+	requiresDelegate bool
+}{
+	"eth_chainId":               {(*DAPoSService).ethChainId, false},
+	"eth_getBalance":            {(*DAPoSService).ethGetBalance, true},
+	"eth_getCode":               {(*DAPoSService).ethGetCode, true},
+	"eth_call":                  {(*DAPoSService).ethCall, true},
+	"eth_getTransactionReceipt": {(*DAPoSService).ethGetTransactionReceipt, true},
+	"eth_getLogs":               {(*DAPoSService).ethGetLogs, true},
+	"eth_sendRawTransaction":    {(*DAPoSService).ethSendRawTransaction, true},
+}
+
+// jsonRpcBudget - Shared by the requests of a batch so splitting a query across a batch does not read more
+type jsonRpcBudget struct {
+	scans int
+}
+
+// jsonRpcRequest
+type jsonRpcRequest struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// jsonRpcResponse - Result is the JSON null when a call has no result
+type jsonRpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonRpcError   `json:"error,omitempty"`
+}
+
+// jsonRpcError
+type jsonRpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ethCallArgs - Gas, gas price and value are accepted but contract calls are free and carry no value
+type ethCallArgs struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Data  string `json:"data"`
+	Input string `json:"input"`
+}
+
+// ethFilter - Blocks are transaction times in milliseconds, see ethLog
+type ethFilter struct {
+	Address   json.RawMessage   `json:"address"`
+	Topics    []json.RawMessage `json:"topics"`
+	BlockHash string            `json:"blockHash"`
+	FromBlock string            `json:"fromBlock"`
+	ToBlock   string            `json:"toBlock"`
+}
+
+// ethReceipt
+type ethReceipt struct {
+	TransactionHash   string   `json:"transactionHash"`
+	TransactionIndex  string   `json:"transactionIndex"`
+	BlockHash         string   `json:"blockHash"`
+	BlockNumber       string   `json:"blockNumber"`
+	From              string   `json:"from"`
+	To                *string  `json:"to"`
+	ContractAddress   *string  `json:"contractAddress"`
+	CumulativeGasUsed string   `json:"cumulativeGasUsed"`
+	GasUsed           string   `json:"gasUsed"`
+	Logs              []ethLog `json:"logs"`
+	LogsBloom         string   `json:"logsBloom"`
+	Status            string   `json:"status"`
+}
+
+// ethLog
+type ethLog struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockHash        string   `json:"blockHash"`
+	BlockNumber      string   `json:"blockNumber"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+// jsonRpcHandler - Single and batch requests, notifications are executed without a reply
+func (this *DAPoSService) jsonRpcHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	responseWriter.Header().Set("content-type", "application/json")
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		utils.Error("unable to read HTTP body of request", err)
+		writeJsonRpc(responseWriter, newJsonRpcErrorResponse(nil, jsonRpcInvalidRequest, err.Error()))
+		return
+	}
+	body = bytes.TrimSpace(body)
+
+	// Batch?
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		err = json.Unmarshal(body, &batch)
+		if err != nil {
+			writeJsonRpc(responseWriter, newJsonRpcErrorResponse(nil, jsonRpcParseError, err.Error()))
+			return
+		}
+		if len(batch) == 0 || len(batch) > maxJsonRpcBatch {
+			writeJsonRpc(responseWriter, newJsonRpcErrorResponse(nil, jsonRpcInvalidRequest, fmt.Sprintf("a batch must have between 1 and %d requests", maxJsonRpcBatch)))
+			return
+		}
+		budget := &jsonRpcBudget{scans: maxJsonRpcScan}
+		responses := make([]*jsonRpcResponse, 0, len(batch))
+		for _, message := range batch {
+			response := this.handleJsonRpc(message, budget)
+			if response != nil {
+				responses = append(responses, response)
+			}
+		}
+		if len(responses) > 0 {
+			writeJsonRpc(responseWriter, responses)
+		}
+		return
+	}
+	response := this.handleJsonRpc(body, &jsonRpcBudget{scans: maxJsonRpcScan})
+	if response != nil {
+		writeJsonRpc(responseWriter, response)
+	}
+}
+
+// handleJsonRpc - Nil for a notification
+func (this *DAPoSService) handleJsonRpc(message json.RawMessage, budget *jsonRpcBudget) *jsonRpcResponse {
+	var request jsonRpcRequest
+	err := json.Unmarshal(message, &request)
+	if err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return newJsonRpcErrorResponse(nil, jsonRpcParseError, err.Error())
+		}
+		return newJsonRpcErrorResponse(nil, jsonRpcInvalidRequest, err.Error())
+	}
+	if request.JsonRpc != "2.0" || request.Method == "" {
+		return newJsonRpcErrorResponse(request.Id, jsonRpcInvalidRequest, "invalid JSON-RPC 2.0 request")
+	}
+	result, rpcError := this.callJsonRpc(request, budget)
+	utils.Debug(fmt.Sprintf("JSON-RPC [method=%s, error=%v]", request.Method, rpcError))
+	if request.Id == nil {
+		return nil
+	}
+	if rpcError != nil {
+		return &jsonRpcResponse{JsonRpc: "2.0", Id: request.Id, Error: rpcError}
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		utils.Error("unable to marshal JSON-RPC result", err)
+		return newJsonRpcErrorResponse(request.Id, jsonRpcInternalError, err.Error())
+	}
+	return &jsonRpcResponse{JsonRpc: "2.0", Id: request.Id, Result: payload}
+}
+
+// callJsonRpc
+func (this *DAPoSService) callJsonRpc(request jsonRpcRequest, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	method, ok := jsonRpcMethods[request.Method]
+	if !ok {
+		return nil, &jsonRpcError{Code: jsonRpcMethodNotFound, Message: fmt.Sprintf("the method %s does not exist/is not available", request.Method)}
+	}
+	if method.requiresDelegate && disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		return nil, &jsonRpcError{Code: jsonRpcServerError, Message: types.StatusNotDelegateAsHumanReadable}
+	}
+	params := make([]json.RawMessage, 0)
+	if len(request.Params) > 0 && string(request.Params) != "null" {
+		err := json.Unmarshal(request.Params, &params)
+		if err != nil {
+			return nil, invalidParams("params must be an array")
+		}
+	}
+	return method.call(this, params, budget)
+}
+
+// ethChainId
+func (this *DAPoSService) ethChainId(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	chainId := types.GetConfig().EthChainId
+	if chainId == 0 {
+		chainId = types.EthChainIdDefault
+	}
+	return hexutil.EncodeUint64(uint64(chainId)), nil
+}
+
+// ethGetBalance - [address, block]
+func (this *DAPoSService) ethGetBalance(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	address, rpcError := addressParam(params, 0)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	rpcError = latestBlockParam(params, 1)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	account, err := types.ToAccountByAddress(txn, address)
+	if err == badger.ErrKeyNotFound {
+		return hexutil.EncodeBig(big.NewInt(0)), nil
+	}
+	if err != nil {
+		return nil, &jsonRpcError{Code: jsonRpcInternalError, Message: err.Error()}
+	}
+	if account.Balance == nil {
+		return hexutil.EncodeBig(big.NewInt(0)), nil
+	}
+	return hexutil.EncodeBig(account.Balance), nil
+}
+
+// ethGetCode - [address, block]
+func (this *DAPoSService) ethGetCode(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	address, rpcError := addressParam(params, 0)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	rpcError = latestBlockParam(params, 1)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	code, err := dvm.GetDVMService().GetCode(address)
+	if err != nil {
+		return nil, &jsonRpcError{Code: jsonRpcInternalError, Message: err.Error()}
+	}
+	return hexutil.Encode(code), nil
+}
+
+// ethCall - [call, block]
+func (this *DAPoSService) ethCall(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	if len(params) == 0 {
+		return nil, invalidParams("missing call object")
+	}
+	var args ethCallArgs
+	err := json.Unmarshal(params[0], &args)
+	if err != nil {
+		return nil, invalidParams(err.Error())
+	}
+	rpcError := latestBlockParam(params, 1)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	to, err := toAddress(args.To)
+	if err != nil {
+		return nil, invalidParams(fmt.Sprintf("to: %v", err))
+	}
+	from := ""
+	if args.From != "" {
+		from, err = toAddress(args.From)
+		if err != nil {
+			return nil, invalidParams(fmt.Sprintf("from: %v", err))
+		}
+	}
+	input := args.Input
+	if input == "" {
+		input = args.Data
+	}
+	callData := make([]byte, 0)
+	if input != "" {
+		callData, err = hexutil.Decode(input)
+		if err != nil {
+			return nil, invalidParams(fmt.Sprintf("data: %v", err))
+		}
+	}
+	result, err := dvm.GetDVMService().CallContract(from, to, callData)
+	if err != nil {
+		return nil, &jsonRpcError{Code: jsonRpcServerError, Message: err.Error()}
+	}
+	return hexutil.Encode(result), nil
+}
+
+// ethGetTransactionReceipt - [hash], null while the transaction is not final
+func (this *DAPoSService) ethGetTransactionReceipt(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	hash, rpcError := hashParam(params, 0)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	transaction, err := types.ToTransactionByHash(txn, hash)
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &jsonRpcError{Code: jsonRpcInternalError, Message: err.Error()}
+	}
+	response := this.GetReceipt(hash)
+	receipt, ok := response.Data.(*types.Receipt)
+	if !ok || !isFinalReceiptStatus(receipt.Status) {
+		return nil, nil
+	}
+
+	result := &ethReceipt{
+		TransactionHash:   hexutil.Encode(transaction.GetHashBytes().Bytes()),
+		TransactionIndex:  hexutil.EncodeUint64(0),
+		BlockHash:         hexutil.Encode(transaction.GetHashBytes().Bytes()),
+		BlockNumber:       hexutil.EncodeUint64(uint64(transaction.Time)),
+		From:              "0x" + transaction.From,
+		CumulativeGasUsed: hexutil.EncodeUint64(0),
+		GasUsed:           hexutil.EncodeUint64(0),
+		Logs:              make([]ethLog, 0),
+		LogsBloom:         hexutil.Encode(make([]byte, ethTypes.BloomByteLength)),
+		Status:            hexutil.EncodeUint64(0),
+	}
+	if receipt.Status == types.StatusOk {
+		result.Status = hexutil.EncodeUint64(1)
+	}
+	if transaction.To != "" {
+		to := "0x" + transaction.To
+		result.To = &to
+	}
+	if receipt.ContractAddress != "" && transaction.Type == types.TypeDeploySmartContract {
+		contractAddress := "0x" + receipt.ContractAddress
+		result.ContractAddress = &contractAddress
+	}
+	if transaction.Type == types.TypeDeploySmartContract || transaction.Type == types.TypeExecuteSmartContract {
+		dvmReceipt, err := dvm.GetDVMService().GetEthReceipt(hash)
+		if err == nil {
+			result.CumulativeGasUsed = hexutil.EncodeUint64(dvmReceipt.CumulativeGasUsed)
+			result.GasUsed = hexutil.EncodeUint64(dvmReceipt.GasUsed)
+			result.LogsBloom = hexutil.Encode(dvmReceipt.Bloom.Bytes())
+			for i, log := range dvmReceipt.Logs {
+				result.Logs = append(result.Logs, toEthLog(transaction, log, i))
+			}
+		}
+	}
+	return result, nil
+}
+
+// ethGetLogs - [filter], address is required since logs are looked up by contract
+func (this *DAPoSService) ethGetLogs(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	if len(params) == 0 {
+		return nil, invalidParams("missing filter object")
+	}
+	var filter ethFilter
+	err := json.Unmarshal(params[0], &filter)
+	if err != nil {
+		return nil, invalidParams(err.Error())
+	}
+	addresses, rpcError := toFilterAddresses(filter.Address)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	topics, rpcError := toFilterTopics(filter.Topics)
+	if rpcError != nil {
+		return nil, rpcError
+	}
+	blockHash := ""
+	if filter.BlockHash != "" {
+		blockHash, err = toHash(filter.BlockHash)
+		if err != nil {
+			return nil, invalidParams(fmt.Sprintf("blockHash: %v", err))
+		}
+	}
+	fromTime, err := toFilterTime(filter.FromBlock, 0)
+	if err != nil {
+		return nil, invalidParams(fmt.Sprintf("fromBlock: %v", err))
+	}
+	toTime, err := toFilterTime(filter.ToBlock, ^uint64(0))
+	if err != nil {
+		return nil, invalidParams(fmt.Sprintf("toBlock: %v", err))
+	}
+
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	logs := make([]ethLog, 0)
+	for _, address := range addresses {
+		var rpcError *jsonRpcError
+		read, err := forEachContractTransaction(txn, address, blockHash, toIndexRange(fromTime, toTime), budget.scans, func(transaction *types.Transaction) bool {
+			dvmReceipt, err := dvm.GetDVMService().GetEthReceipt(transaction.Hash)
+			if err != nil {
+				return true
+			}
+			for i, log := range dvmReceipt.Logs {
+				if !matchesFilter(log, address, topics) {
+					continue
+				}
+				if len(logs) == maxJsonRpcLogs {
+					rpcError = &jsonRpcError{Code: jsonRpcServerError, Message: fmt.Sprintf("query returned more than %d results", maxJsonRpcLogs)}
+					return false
+				}
+				logs = append(logs, toEthLog(transaction, log, i))
+			}
+			return true
+		})
+		if err == errScanLimit {
+			budget.scans = 0
+			return nil, &jsonRpcError{Code: jsonRpcServerError, Message: fmt.Sprintf("queries read more than %d transactions, narrow the block range", maxJsonRpcScan)}
+		}
+		if err != nil {
+			return nil, &jsonRpcError{Code: jsonRpcInternalError, Message: err.Error()}
+		}
+		budget.scans -= read
+		if rpcError != nil {
+			return nil, rpcError
+		}
+	}
+	return logs, nil
+}
+
+// ethSendRawTransaction - [data], data is a signed Dispatch transaction as JSON. Ethereum transactions are signed over
+// their RLP encoding, which delegates cannot verify as a Dispatch transaction, so they are not supported: they are decoded
+// only to report their sender with a jsonRpcNotSupported error.
+func (this *DAPoSService) ethSendRawTransaction(params []json.RawMessage, budget *jsonRpcBudget) (interface{}, *jsonRpcError) {
+	if len(params) == 0 {
+		return nil, invalidParams("missing raw transaction")
+	}
+	var raw string
+	err := json.Unmarshal(params[0], &raw)
+	if err != nil {
+		return nil, invalidParams(err.Error())
+	}
+	data, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, invalidParams(err.Error())
+	}
+
+	// Ethereum transaction?
+	if len(data) == 0 || data[0] != '{' {
+		ethTransaction := new(ethTypes.Transaction)
+		err = rlp.DecodeBytes(data, ethTransaction)
+		if err != nil {
+			return nil, invalidParams(fmt.Sprintf("raw transaction is neither a Dispatch transaction nor an Ethereum transaction: %v", err))
+		}
+		var signer ethTypes.Signer = ethTypes.HomesteadSigner{}
+		if ethTransaction.Protected() {
+			signer = ethTypes.NewEIP155Signer(ethTransaction.ChainId())
+		}
+		sender, err := ethTypes.Sender(signer, ethTransaction)
+		if err != nil {
+			return nil, invalidParams(err.Error())
+		}
+		return nil, &jsonRpcError{
+			Code:    jsonRpcNotSupported,
+			Message: fmt.Sprintf("Ethereum signed transactions are not supported, sign a Dispatch transaction with the key of %s and send its JSON instead", hexutil.Encode(sender.Bytes())),
+		}
+	}
+
+	transaction, err := types.ToTransactionFromJson(data)
+	if err != nil {
+		return nil, invalidParams(err.Error())
+	}
+	if transaction.Type == types.TypeReadSmartContract {
+		return nil, invalidParams("read smart contract transactions are not sent, use eth_call")
+	}
+	_, err = prepareTransaction(transaction)
+	if err != nil {
+		return nil, invalidParams(err.Error())
+	}
+	response := this.NewTransaction(transaction)
+	if response.Status != types.StatusPending {
+		return nil, &jsonRpcError{Code: jsonRpcServerError, Message: fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus)}
+	}
+	return hexutil.Encode(transaction.GetHashBytes().Bytes()), nil
+}
+
+// errScanLimit - A query read more transactions than it may
+var errScanLimit = errors.New("scan limit exceeded")
+
+// timeRange - start <= time < end
+type timeRange struct {
+	start int64
+	end   int64
+}
+
+// contains
+func (this timeRange) contains(t int64) bool {
+	return t >= this.start && t < this.end
+}
+
+// toIndexRange - The inclusive block range of a filter as transaction times
+func toIndexRange(fromTime, toTime uint64) timeRange {
+	r := timeRange{start: math.MaxInt64, end: math.MaxInt64}
+	if fromTime < math.MaxInt64 {
+		r.start = int64(fromTime)
+	}
+	if toTime < math.MaxInt64 {
+		r.end = int64(toTime) + 1
+	}
+	return r
+}
+
+// forEachContractTransaction - Calls fn with the deployment and then the executions of a contract in time order until it
+// returns false. Executions are seeked on the index of the transactions sent to the contract so only those in r are read,
+// it returns how many were read and fails with errScanLimit past limit. A block hash reads that transaction alone.
+func forEachContractTransaction(txn *badger.Txn, address string, blockHash string, r timeRange, limit int, fn func(*types.Transaction) bool) (int, error) {
+	read := 0
+	deployment, err := types.ToTransactionByAddress(txn, address)
+	if err != nil {
+		if err == badger.ErrKeyNotFound || err == types.ErrNotFound {
+			return read, nil
+		}
+		return read, err
+	}
+	if deployment.Type != types.TypeDeploySmartContract {
+		return read, nil
+	}
+
+	// Block?
+	if blockHash != "" {
+		transaction := deployment
+		if blockHash != deployment.Hash {
+			transaction, err = types.ToTransactionByHash(txn, blockHash)
+			if err == badger.ErrKeyNotFound {
+				return read, nil
+			}
+			if err != nil {
+				return read, err
+			}
+			if transaction.Type != types.TypeExecuteSmartContract || transaction.To != address {
+				return read, nil
+			}
+		}
+		if r.contains(transaction.Time) {
+			fn(transaction)
+		}
+		return read, nil
+	}
+
+	if r.contains(deployment.Time) && !fn(deployment) {
+		return read, nil
+	}
+	var loadErr error
+	err = forEachTimeIndexKey(txn, fmt.Sprintf("key-transaction-to-%s-", address), r.start, r.end, func(item *badger.Item, t int64) bool {
+		read++
+		if read > limit {
+			loadErr = errScanLimit
+			return false
+		}
+		value, err := item.Value()
+		if err != nil {
+			loadErr = err
+			return false
+		}
+		transaction, err := types.ToTransactionByKey(txn, value)
+		if err != nil {
+			loadErr = err
+			return false
+		}
+		if transaction.Type != types.TypeExecuteSmartContract {
+			return true
+		}
+		return fn(transaction)
+	})
+	if err != nil {
+		return read, err
+	}
+	return read, loadErr
+}
+
+// matchesFilter - A nil topic position matches any topic
+func matchesFilter(log *ethTypes.Log, address string, topics [][]crypto.HashBytes) bool {
+	if hex.EncodeToString(log.Address.Bytes()) != address {
+		return false
+	}
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, alternatives := range topics {
+		if alternatives == nil {
+			continue
+		}
+		matched := false
+		for _, topic := range alternatives {
+			if topic == log.Topics[i] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// toEthLog
+func toEthLog(transaction *types.Transaction, log *ethTypes.Log, index int) ethLog {
+	topics := make([]string, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, hexutil.Encode(topic.Bytes()))
+	}
+	return ethLog{
+		Address:          hexutil.Encode(log.Address.Bytes()),
+		Topics:           topics,
+		Data:             hexutil.Encode(log.Data),
+		BlockHash:        hexutil.Encode(transaction.GetHashBytes().Bytes()),
+		BlockNumber:      hexutil.EncodeUint64(uint64(transaction.Time)),
+		TransactionHash:  hexutil.Encode(transaction.GetHashBytes().Bytes()),
+		TransactionIndex: hexutil.EncodeUint64(0),
+		LogIndex:         hexutil.EncodeUint64(uint64(index)),
+	}
+}
+
+// toFilterAddresses - A single address or a list of addresses
+func toFilterAddresses(raw json.RawMessage) ([]string, *jsonRpcError) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, invalidParams("address is required")
+	}
+	values := make([]string, 0)
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		values = append(values, single)
+	} else if json.Unmarshal(raw, &values) != nil || len(values) == 0 {
+		return nil, invalidParams("address must be an address or a list of addresses")
+	}
+	addresses := make([]string, 0, len(values))
+	for _, value := range values {
+		address, err := toAddress(value)
+		if err != nil {
+			return nil, invalidParams(fmt.Sprintf("address: %v", err))
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// toFilterTopics - Each position is null, a topic or a list of alternative topics
+func toFilterTopics(raw []json.RawMessage) ([][]crypto.HashBytes, *jsonRpcError) {
+	topics := make([][]crypto.HashBytes, 0, len(raw))
+	for _, position := range raw {
+		if len(position) == 0 || string(position) == "null" {
+			topics = append(topics, nil)
+			continue
+		}
+		values := make([]string, 0)
+		var single string
+		if json.Unmarshal(position, &single) == nil {
+			values = append(values, single)
+		} else if json.Unmarshal(position, &values) != nil {
+			return nil, invalidParams("topics must be null, a topic or a list of topics")
+		}
+		alternatives := make([]crypto.HashBytes, 0, len(values))
+		for _, value := range values {
+			topic, err := toHash(value)
+			if err != nil {
+				return nil, invalidParams(fmt.Sprintf("topic: %v", err))
+			}
+			alternatives = append(alternatives, crypto.GetHashBytes(topic))
+		}
+		topics = append(topics, alternatives)
+	}
+	return topics, nil
+}
+
+// toFilterTime - A block number is a time in milliseconds, block tags leave the bound open
+func toFilterTime(block string, open uint64) (uint64, error) {
+	switch block {
+	case "", "latest", "pending", "earliest":
+		return open, nil
+	}
+	return hexutil.DecodeUint64(block)
+}
+
+// addressParam
+func addressParam(params []json.RawMessage, index int) (string, *jsonRpcError) {
+	if len(params) <= index {
+		return "", invalidParams("missing address")
+	}
+	var value string
+	err := json.Unmarshal(params[index], &value)
+	if err != nil {
+		return "", invalidParams(err.Error())
+	}
+	address, err := toAddress(value)
+	if err != nil {
+		return "", invalidParams(err.Error())
+	}
+	return address, nil
+}
+
+// hashParam
+func hashParam(params []json.RawMessage, index int) (string, *jsonRpcError) {
+	if len(params) <= index {
+		return "", invalidParams("missing hash")
+	}
+	var value string
+	err := json.Unmarshal(params[index], &value)
+	if err != nil {
+		return "", invalidParams(err.Error())
+	}
+	hash, err := toHash(value)
+	if err != nil {
+		return "", invalidParams(err.Error())
+	}
+	return hash, nil
+}
+
+// latestBlockParam - The block is optional and can only refer to the latest state
+func latestBlockParam(params []json.RawMessage, index int) *jsonRpcError {
+	if len(params) <= index {
+		return nil
+	}
+	var block string
+	err := json.Unmarshal(params[index], &block)
+	if err != nil {
+		return invalidParams("block must be a block tag")
+	}
+	if block != "latest" && block != "pending" {
+		return invalidParams("only the latest state is available")
+	}
+	return nil
+}
+
+// toAddress - Dispatch form of a 0x prefixed address
+func toAddress(value string) (string, error) {
+	return toHex(value, crypto.AddressLength)
+}
+
+// toHash - Dispatch form of a 0x prefixed hash
+func toHash(value string) (string, error) {
+	return toHex(value, crypto.HashLength)
+}
+
+// toHex
+func toHex(value string, length int) (string, error) {
+	decoded, err := hexutil.Decode(value)
+	if err != nil {
+		return "", err
+	}
+	if len(decoded) != length {
+		return "", fmt.Errorf("must be %d bytes", length)
+	}
+	return hex.EncodeToString(decoded), nil
+}
+
+// invalidParams
+func invalidParams(message string) *jsonRpcError {
+	return &jsonRpcError{Code: jsonRpcInvalidParams, Message: message}
+}
+
+// newJsonRpcErrorResponse
+func newJsonRpcErrorResponse(id json.RawMessage, code int, message string) *jsonRpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &jsonRpcResponse{JsonRpc: "2.0", Id: id, Error: &jsonRpcError{Code: code, Message: message}}
+}
+
+// writeJsonRpc - JSON-RPC errors are replied with HTTP 200
+func writeJsonRpc(responseWriter http.ResponseWriter, value interface{}) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		utils.Error("unable to marshal JSON-RPC response", err)
+		responseWriter.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	responseWriter.Write(payload)
+}
@@ -0,0 +1,194 @@
+package dapos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common/hexutil"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
+)
+
+// TestToIndexRange
+func TestToIndexRange(t *testing.T) {
+	tests := []struct {
+		from     uint64
+		to       uint64
+		expected timeRange
+	}{
+		{0, ^uint64(0), timeRange{0, math.MaxInt64}},
+		{5, 10, timeRange{5, 11}},
+		{^uint64(0), ^uint64(0), timeRange{math.MaxInt64, math.MaxInt64}},
+	}
+	for _, test := range tests {
+		if r := toIndexRange(test.from, test.to); r != test.expected {
+			t.Errorf("toIndexRange(%d, %d) is %v, expected %v", test.from, test.to, r, test.expected)
+		}
+	}
+}
+
+// TestForEachContractTransaction - executions are read in time order across times of different lengths, other
+// transactions to the contract are skipped
+func TestForEachContractTransaction(t *testing.T) {
+	defer deleteTestKeys(t, "table-account-")
+	defer deleteTestKeys(t, "key-account-name-")
+	defer deleteTestKeys(t, "table-transaction-")
+	defer deleteTestKeys(t, "key-transaction-")
+	contract := "c0ffee"
+	transactions := []*types.Transaction{
+		{Hash: "deploy", Type: types.TypeDeploySmartContract, From: "owner", Time: 900},
+		{Hash: "execute-950", Type: types.TypeExecuteSmartContract, From: "owner", To: contract, Time: 950},
+		{Hash: "execute-1001", Type: types.TypeExecuteSmartContract, From: "owner", To: contract, Time: 1001},
+		{Hash: "transfer-1002", Type: types.TypeTransferTokens, From: "owner", To: contract, Time: 1002},
+		{Hash: "execute-1003", Type: types.TypeExecuteSmartContract, From: "owner", To: contract, Time: 1003},
+		{Hash: "execute-10000", Type: types.TypeExecuteSmartContract, From: "owner", To: contract, Time: 10000},
+		{Hash: "other-1004", Type: types.TypeExecuteSmartContract, From: "owner", To: "other", Time: 1004},
+	}
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	account := &types.Account{Address: contract, Balance: big.NewInt(0), TransactionHash: "deploy"}
+	if err := account.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	for _, transaction := range transactions {
+		transaction.Value = big.NewInt(0)
+		if err := transaction.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	all := timeRange{0, math.MaxInt64}
+	tests := []struct {
+		name      string
+		address   string
+		blockHash string
+		r         timeRange
+		limit     int
+		stop      int
+		expected  string
+		read      int
+		err       error
+	}{
+		{"all", contract, "", all, 10, 0, "[deploy execute-950 execute-1001 execute-1003 execute-10000]", 5, nil},
+		{"from and to", contract, "", timeRange{1001, 1004}, 10, 0, "[execute-1001 execute-1003]", 3, nil},
+		{"across lengths", contract, "", timeRange{950, 10001}, 10, 0, "[execute-950 execute-1001 execute-1003 execute-10000]", 5, nil},
+		{"stopped", contract, "", all, 10, 2, "[deploy execute-950]", 1, nil},
+		{"over the limit", contract, "", all, 3, 0, "[deploy execute-950 execute-1001]", 4, errScanLimit},
+		{"block of an execution", contract, "execute-1003", all, 10, 0, "[execute-1003]", 0, nil},
+		{"block of the deployment", contract, "deploy", all, 10, 0, "[deploy]", 0, nil},
+		{"block out of range", contract, "execute-1003", timeRange{0, 1003}, 10, 0, "[]", 0, nil},
+		{"block of a transfer", contract, "transfer-1002", all, 10, 0, "[]", 0, nil},
+		{"block of another contract", contract, "other-1004", all, 10, 0, "[]", 0, nil},
+		{"missing block", contract, "missing", all, 10, 0, "[]", 0, nil},
+		{"not a contract", "owner", "", all, 10, 0, "[]", 0, nil},
+	}
+	for _, test := range tests {
+		txn := services.NewTxn(false)
+		hashes := []string{}
+		read, err := forEachContractTransaction(txn, test.address, test.blockHash, test.r, test.limit, func(transaction *types.Transaction) bool {
+			hashes = append(hashes, transaction.Hash)
+			return len(hashes) != test.stop
+		})
+		txn.Discard()
+		if err != test.err {
+			t.Errorf("%s: returned %v, expected %v", test.name, err, test.err)
+		}
+		if fmt.Sprint(hashes) != test.expected || read != test.read {
+			t.Errorf("%s: read %d %v, expected %d %s", test.name, read, hashes, test.read, test.expected)
+		}
+	}
+}
+
+// TestToIndexTime
+func TestToIndexTime(t *testing.T) {
+	tests := []struct {
+		key    string
+		prefix string
+		time   int64
+		ok     bool
+	}{
+		{"key-transaction-to-c0ffee-1001", "key-transaction-to-c0ffee-", 1001, true},
+		{"key-transaction-time-1001-hash", timeKeyPrefix, 1001, true},
+		{"key-transaction-to-c0ffee-", "key-transaction-to-c0ffee-", 0, false},
+		{"key-transaction-to-c0ffee-x1", "key-transaction-to-c0ffee-", 0, false},
+	}
+	for _, test := range tests {
+		if time, ok := toIndexTime(test.key, test.prefix); time != test.time || ok != test.ok {
+			t.Errorf("toIndexTime(%s) is %d %t, expected %d %t", test.key, time, ok, test.time, test.ok)
+		}
+	}
+}
+
+// TestEthGetLogsBudget - the eth_getLogs calls of one HTTP request share the scan budget
+func TestEthGetLogsBudget(t *testing.T) {
+	defer deleteTestKeys(t, "table-account-")
+	defer deleteTestKeys(t, "key-account-name-")
+	defer deleteTestKeys(t, "table-transaction-")
+	defer deleteTestKeys(t, "key-transaction-")
+	contract := "c0ffeec0ffeec0ffeec0ffeec0ffeec0ffeec0ff"
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	account := &types.Account{Address: contract, Balance: big.NewInt(0), TransactionHash: "deploy"}
+	if err := account.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	transactions := []*types.Transaction{{Hash: "deploy", Type: types.TypeDeploySmartContract, From: "owner", Time: 900}}
+	for i := int64(0); i < 3; i++ {
+		transactions = append(transactions, &types.Transaction{Hash: fmt.Sprintf("execute%d", i), Type: types.TypeExecuteSmartContract, From: "owner", To: contract, Time: 1000 + i})
+	}
+	for _, transaction := range transactions {
+		transaction.Value = big.NewInt(0)
+		if err := transaction.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	params := []json.RawMessage{json.RawMessage(`{"address": "0x` + contract + `"}`)}
+	budget := &jsonRpcBudget{scans: 5}
+	if _, rpcError := GetDAPoSService().ethGetLogs(params, budget); rpcError != nil || budget.scans != 2 {
+		t.Fatalf("first query returned %v, %d scans left", rpcError, budget.scans)
+	}
+	if _, rpcError := GetDAPoSService().ethGetLogs(params, budget); rpcError == nil || rpcError.Code != jsonRpcServerError || budget.scans != 0 {
+		t.Errorf("query over the remaining budget returned %v, %d scans left", rpcError, budget.scans)
+	}
+}
+
+// TestEthSendRawTransactionUnsupported - Ethereum signed transactions are refused as not supported, naming their sender
+func TestEthSendRawTransactionUnsupported(t *testing.T) {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	key, err := crypto.HexToECDSA(hex.EncodeToString(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ethTransaction, err := ethTypes.SignTx(ethTypes.NewTransaction(0, crypto.AddressBytes{}, big.NewInt(0), 21000, big.NewInt(1), nil), ethTypes.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := rlp.EncodeToBytes(ethTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := []json.RawMessage{json.RawMessage(`"` + hexutil.Encode(data) + `"`)}
+	_, rpcError := GetDAPoSService().ethSendRawTransaction(params, &jsonRpcBudget{})
+	if rpcError == nil || rpcError.Code != jsonRpcNotSupported {
+		t.Fatalf("returned %v, expected code %d", rpcError, jsonRpcNotSupported)
+	}
+	sender := hex.EncodeToString(crypto.ToAddress(publicKey))
+	if !strings.Contains(rpcError.Message, sender) {
+		t.Errorf("message %q does not name the sender %s", rpcError.Message, sender)
+	}
+}
@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proto/client.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SubmitTransactionResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	HumanReadableStatus  string   `protobuf:"bytes,2,opt,name=humanReadableStatus,proto3" json:"humanReadableStatus,omitempty"`
+	Hash                 string   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Receipt              *Receipt `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitTransactionResponse) Reset()         { *m = SubmitTransactionResponse{} }
+func (m *SubmitTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitTransactionResponse) ProtoMessage()    {}
+func (*SubmitTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{0}
+}
+
+func (m *SubmitTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitTransactionResponse.Unmarshal(m, b)
+}
+func (m *SubmitTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitTransactionResponse.Merge(m, src)
+}
+func (m *SubmitTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitTransactionResponse.Size(m)
+}
+func (m *SubmitTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitTransactionResponse proto.InternalMessageInfo
+
+func (m *SubmitTransactionResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SubmitTransactionResponse) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *SubmitTransactionResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *SubmitTransactionResponse) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+type GetAccountRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountRequest) Reset()         { *m = GetAccountRequest{} }
+func (m *GetAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountRequest) ProtoMessage()    {}
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{1}
+}
+
+func (m *GetAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountRequest.Unmarshal(m, b)
+}
+func (m *GetAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountRequest.Merge(m, src)
+}
+func (m *GetAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccountRequest.Size(m)
+}
+func (m *GetAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountRequest proto.InternalMessageInfo
+
+func (m *GetAccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type AccountResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	HumanReadableStatus  string   `protobuf:"bytes,2,opt,name=humanReadableStatus,proto3" json:"humanReadableStatus,omitempty"`
+	Account              *Account `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountResponse) Reset()         { *m = AccountResponse{} }
+func (m *AccountResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountResponse) ProtoMessage()    {}
+func (*AccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{2}
+}
+
+func (m *AccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountResponse.Unmarshal(m, b)
+}
+func (m *AccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountResponse.Merge(m, src)
+}
+func (m *AccountResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountResponse.Size(m)
+}
+func (m *AccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountResponse proto.InternalMessageInfo
+
+func (m *AccountResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *AccountResponse) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *AccountResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+type GetTransactionRequest struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{3}
+}
+
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type TransactionResponse struct {
+	Status               string       `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	HumanReadableStatus  string       `protobuf:"bytes,2,opt,name=humanReadableStatus,proto3" json:"humanReadableStatus,omitempty"`
+	Transaction          *Transaction `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Receipt              *Receipt     `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TransactionResponse) Reset()         { *m = TransactionResponse{} }
+func (m *TransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionResponse) ProtoMessage()    {}
+func (*TransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{4}
+}
+
+func (m *TransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionResponse.Unmarshal(m, b)
+}
+func (m *TransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *TransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionResponse.Merge(m, src)
+}
+func (m *TransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_TransactionResponse.Size(m)
+}
+func (m *TransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionResponse proto.InternalMessageInfo
+
+func (m *TransactionResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *TransactionResponse) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+type GetReceiptRequest struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetReceiptRequest) Reset()         { *m = GetReceiptRequest{} }
+func (m *GetReceiptRequest) String() string { return proto.CompactTextString(m) }
+func (*GetReceiptRequest) ProtoMessage()    {}
+func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{5}
+}
+
+func (m *GetReceiptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReceiptRequest.Unmarshal(m, b)
+}
+func (m *GetReceiptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReceiptRequest.Marshal(b, m, deterministic)
+}
+func (m *GetReceiptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReceiptRequest.Merge(m, src)
+}
+func (m *GetReceiptRequest) XXX_Size() int {
+	return xxx_messageInfo_GetReceiptRequest.Size(m)
+}
+func (m *GetReceiptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReceiptRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReceiptRequest proto.InternalMessageInfo
+
+func (m *GetReceiptRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type ReceiptResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	HumanReadableStatus  string   `protobuf:"bytes,2,opt,name=humanReadableStatus,proto3" json:"humanReadableStatus,omitempty"`
+	Receipt              *Receipt `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptResponse) Reset()         { *m = ReceiptResponse{} }
+func (m *ReceiptResponse) String() string { return proto.CompactTextString(m) }
+func (*ReceiptResponse) ProtoMessage()    {}
+func (*ReceiptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{6}
+}
+
+func (m *ReceiptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptResponse.Unmarshal(m, b)
+}
+func (m *ReceiptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptResponse.Marshal(b, m, deterministic)
+}
+func (m *ReceiptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptResponse.Merge(m, src)
+}
+func (m *ReceiptResponse) XXX_Size() int {
+	return xxx_messageInfo_ReceiptResponse.Size(m)
+}
+func (m *ReceiptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptResponse proto.InternalMessageInfo
+
+func (m *ReceiptResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ReceiptResponse) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *ReceiptResponse) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+type ListDelegatesResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	HumanReadableStatus  string   `protobuf:"bytes,2,opt,name=humanReadableStatus,proto3" json:"humanReadableStatus,omitempty"`
+	Delegates            []*Node  `protobuf:"bytes,3,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDelegatesResponse) Reset()         { *m = ListDelegatesResponse{} }
+func (m *ListDelegatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDelegatesResponse) ProtoMessage()    {}
+func (*ListDelegatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{7}
+}
+
+func (m *ListDelegatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDelegatesResponse.Unmarshal(m, b)
+}
+func (m *ListDelegatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDelegatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDelegatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDelegatesResponse.Merge(m, src)
+}
+func (m *ListDelegatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDelegatesResponse.Size(m)
+}
+func (m *ListDelegatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDelegatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDelegatesResponse proto.InternalMessageInfo
+
+func (m *ListDelegatesResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListDelegatesResponse) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *ListDelegatesResponse) GetDelegates() []*Node {
+	if m != nil {
+		return m.Delegates
+	}
+	return nil
+}
+
+type StreamReceiptsRequest struct {
+	Hashes               []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamReceiptsRequest) Reset()         { *m = StreamReceiptsRequest{} }
+func (m *StreamReceiptsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamReceiptsRequest) ProtoMessage()    {}
+func (*StreamReceiptsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a0e6f5862e4b85, []int{8}
+}
+
+func (m *StreamReceiptsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamReceiptsRequest.Unmarshal(m, b)
+}
+func (m *StreamReceiptsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamReceiptsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamReceiptsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamReceiptsRequest.Merge(m, src)
+}
+func (m *StreamReceiptsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamReceiptsRequest.Size(m)
+}
+func (m *StreamReceiptsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamReceiptsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamReceiptsRequest proto.InternalMessageInfo
+
+func (m *StreamReceiptsRequest) GetHashes() []string {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SubmitTransactionResponse)(nil), "proto.SubmitTransactionResponse")
+	proto.RegisterType((*GetAccountRequest)(nil), "proto.GetAccountRequest")
+	proto.RegisterType((*AccountResponse)(nil), "proto.AccountResponse")
+	proto.RegisterType((*GetTransactionRequest)(nil), "proto.GetTransactionRequest")
+	proto.RegisterType((*TransactionResponse)(nil), "proto.TransactionResponse")
+	proto.RegisterType((*GetReceiptRequest)(nil), "proto.GetReceiptRequest")
+	proto.RegisterType((*ReceiptResponse)(nil), "proto.ReceiptResponse")
+	proto.RegisterType((*ListDelegatesResponse)(nil), "proto.ListDelegatesResponse")
+	proto.RegisterType((*StreamReceiptsRequest)(nil), "proto.StreamReceiptsRequest")
+}
+
+func init() { proto.RegisterFile("proto/client.proto", fileDescriptor_f1a0e6f5862e4b85) }
+
+var fileDescriptor_f1a0e6f5862e4b85 = []byte{
+	// 468 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x93, 0x4f, 0x6e, 0xd3, 0x40,
+	0x14, 0xc6, 0x33, 0xb8, 0x24, 0xea, 0x33, 0x2a, 0xca, 0x54, 0x8e, 0x8c, 0xc5, 0xc2, 0x9a, 0x0d,
+	0x46, 0x88, 0xb6, 0x0a, 0x5c, 0xa0, 0xa2, 0x28, 0x1b, 0x54, 0x90, 0xc3, 0x05, 0x26, 0xf6, 0x13,
+	0xb1, 0x14, 0xff, 0xc1, 0x33, 0x5e, 0x70, 0x00, 0x76, 0xdc, 0x82, 0x83, 0x70, 0x03, 0xce, 0x84,
+	0x3c, 0x7f, 0xe2, 0xda, 0x71, 0x2b, 0x21, 0x35, 0xab, 0x64, 0xe6, 0x7b, 0x7e, 0xf3, 0x9b, 0xf7,
+	0xcd, 0x07, 0xb4, 0xaa, 0x4b, 0x59, 0x5e, 0x26, 0xbb, 0x0c, 0x0b, 0x79, 0xa1, 0x16, 0xf4, 0xa9,
+	0xfa, 0x09, 0xe6, 0x5a, 0x4a, 0x79, 0x55, 0x0a, 0xad, 0xb0, 0xdf, 0x04, 0x5e, 0xac, 0x9b, 0x4d,
+	0x9e, 0xc9, 0xaf, 0x35, 0x2f, 0x04, 0x4f, 0x64, 0x56, 0x16, 0x31, 0x8a, 0xaa, 0x2c, 0x04, 0xd2,
+	0x05, 0x4c, 0x85, 0xe4, 0xb2, 0x11, 0x3e, 0x09, 0x49, 0x74, 0x1a, 0x9b, 0x15, 0xbd, 0x82, 0xf3,
+	0x6d, 0x93, 0xf3, 0x22, 0x46, 0x9e, 0xf2, 0xcd, 0x0e, 0xd7, 0xba, 0xe8, 0x89, 0x2a, 0x1a, 0x93,
+	0x28, 0x85, 0x93, 0x2d, 0x17, 0x5b, 0xdf, 0x51, 0x25, 0xea, 0x3f, 0x8d, 0x60, 0x56, 0x63, 0x82,
+	0x59, 0x25, 0xfd, 0x93, 0x90, 0x44, 0xee, 0xf2, 0x4c, 0x43, 0x5d, 0xc4, 0x7a, 0x37, 0xb6, 0x32,
+	0x7b, 0x0b, 0xf3, 0x15, 0xca, 0xeb, 0x24, 0x29, 0x9b, 0x42, 0xc6, 0xf8, 0xbd, 0x41, 0x21, 0xa9,
+	0x0f, 0x33, 0x9e, 0xa6, 0x35, 0x0a, 0x4b, 0x67, 0x97, 0xec, 0x27, 0x81, 0xe7, 0xfb, 0xe2, 0x47,
+	0xbf, 0x4a, 0x04, 0x33, 0xae, 0x9b, 0xfb, 0x4e, 0x0f, 0xdb, 0x1e, 0x69, 0x65, 0xf6, 0x06, 0xbc,
+	0x15, 0xf6, 0x07, 0xab, 0xd1, 0xed, 0x34, 0x48, 0x37, 0x0d, 0xf6, 0x87, 0xc0, 0xf9, 0x71, 0x3d,
+	0x78, 0x0f, 0xae, 0xec, 0x0e, 0x30, 0xf0, 0xd4, 0xc0, 0xdf, 0x3d, 0xfa, 0x6e, 0xd9, 0x7f, 0xb8,
+	0xf4, 0x4a, 0xb9, 0x64, 0xb7, 0x1f, 0xb8, 0x6a, 0xeb, 0xcf, 0xbe, 0xec, 0x18, 0xfe, 0x58, 0x60,
+	0xe7, 0x61, 0xe0, 0x5f, 0x04, 0xbc, 0x4f, 0x99, 0x90, 0x37, 0xb8, 0xc3, 0x6f, 0x5c, 0xa2, 0x38,
+	0x02, 0xcd, 0x6b, 0x38, 0x4d, 0x6d, 0x7b, 0xdf, 0x09, 0x9d, 0xc8, 0x5d, 0xba, 0x86, 0xe7, 0xb6,
+	0x4c, 0x31, 0xee, 0x54, 0x76, 0x09, 0xde, 0x5a, 0xd6, 0xc8, 0x73, 0x03, 0x2a, 0xec, 0x0c, 0x17,
+	0x30, 0x6d, 0xe7, 0x86, 0x2d, 0x8d, 0xd3, 0xd2, 0xe8, 0xd5, 0xf2, 0xaf, 0x03, 0xf0, 0x41, 0xe5,
+	0x7c, 0x55, 0x57, 0x09, 0xfd, 0x0c, 0xde, 0x41, 0x94, 0x95, 0x30, 0xe2, 0x71, 0x10, 0x9a, 0xbd,
+	0x7b, 0xc3, 0xcf, 0x26, 0xf4, 0x06, 0xce, 0xba, 0xd8, 0xa9, 0x4e, 0xbe, 0xf9, 0xea, 0x20, 0x8d,
+	0xc1, 0x62, 0x10, 0x82, 0xae, 0xcb, 0x17, 0xa0, 0xfd, 0x14, 0xa8, 0x4e, 0x2f, 0xbb, 0x4e, 0x87,
+	0x01, 0x09, 0x82, 0x91, 0x57, 0x39, 0xe4, 0x32, 0x53, 0x1a, 0x72, 0xf5, 0xdf, 0xdf, 0x9e, 0x6b,
+	0xf0, 0xde, 0xd8, 0x84, 0x5e, 0xc3, 0xbc, 0x67, 0xbe, 0x6a, 0xf4, 0xcc, 0x94, 0x7f, 0xcc, 0x2b,
+	0xf9, 0x23, 0xb0, 0x90, 0xa3, 0x8f, 0x84, 0x4d, 0xe8, 0x2d, 0xd0, 0xbe, 0x63, 0xbd, 0xab, 0x8d,
+	0x9a, 0x79, 0x3f, 0xd0, 0x15, 0xd9, 0x4c, 0x95, 0xf4, 0xee, 0xdf, 0x00, 0x52, 0x83, 0xd3, 0x6d,
+	0xc4, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ClientGrpcClient is the client API for ClientGrpc service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClientGrpcClient interface {
+	SubmitTransactionGrpc(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*SubmitTransactionResponse, error)
+	GetAccountGrpc(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	GetTransactionGrpc(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	GetReceiptGrpc(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error)
+	ListDelegatesGrpc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListDelegatesResponse, error)
+	StreamReceiptsGrpc(ctx context.Context, in *StreamReceiptsRequest, opts ...grpc.CallOption) (ClientGrpc_StreamReceiptsGrpcClient, error)
+}
+
+type clientGrpcClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClientGrpcClient(cc *grpc.ClientConn) ClientGrpcClient {
+	return &clientGrpcClient{cc}
+}
+
+func (c *clientGrpcClient) SubmitTransactionGrpc(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*SubmitTransactionResponse, error) {
+	out := new(SubmitTransactionResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClientGrpc/SubmitTransactionGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientGrpcClient) GetAccountGrpc(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClientGrpc/GetAccountGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientGrpcClient) GetTransactionGrpc(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClientGrpc/GetTransactionGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientGrpcClient) GetReceiptGrpc(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error) {
+	out := new(ReceiptResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClientGrpc/GetReceiptGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientGrpcClient) ListDelegatesGrpc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListDelegatesResponse, error) {
+	out := new(ListDelegatesResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClientGrpc/ListDelegatesGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientGrpcClient) StreamReceiptsGrpc(ctx context.Context, in *StreamReceiptsRequest, opts ...grpc.CallOption) (ClientGrpc_StreamReceiptsGrpcClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ClientGrpc_serviceDesc.Streams[0], "/proto.ClientGrpc/StreamReceiptsGrpc", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clientGrpcStreamReceiptsGrpcClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClientGrpc_StreamReceiptsGrpcClient interface {
+	Recv() (*ReceiptResponse, error)
+	grpc.ClientStream
+}
+
+type clientGrpcStreamReceiptsGrpcClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientGrpcStreamReceiptsGrpcClient) Recv() (*ReceiptResponse, error) {
+	m := new(ReceiptResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ClientGrpcServer is the server API for ClientGrpc service.
+type ClientGrpcServer interface {
+	SubmitTransactionGrpc(context.Context, *Transaction) (*SubmitTransactionResponse, error)
+	GetAccountGrpc(context.Context, *GetAccountRequest) (*AccountResponse, error)
+	GetTransactionGrpc(context.Context, *GetTransactionRequest) (*TransactionResponse, error)
+	GetReceiptGrpc(context.Context, *GetReceiptRequest) (*ReceiptResponse, error)
+	ListDelegatesGrpc(context.Context, *Empty) (*ListDelegatesResponse, error)
+	StreamReceiptsGrpc(*StreamReceiptsRequest, ClientGrpc_StreamReceiptsGrpcServer) error
+}
+
+func RegisterClientGrpcServer(s *grpc.Server, srv ClientGrpcServer) {
+	s.RegisterService(&_ClientGrpc_serviceDesc, srv)
+}
+
+func _ClientGrpc_SubmitTransactionGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Transaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientGrpcServer).SubmitTransactionGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClientGrpc/SubmitTransactionGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientGrpcServer).SubmitTransactionGrpc(ctx, req.(*Transaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientGrpc_GetAccountGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientGrpcServer).GetAccountGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClientGrpc/GetAccountGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientGrpcServer).GetAccountGrpc(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientGrpc_GetTransactionGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientGrpcServer).GetTransactionGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClientGrpc/GetTransactionGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientGrpcServer).GetTransactionGrpc(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientGrpc_GetReceiptGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientGrpcServer).GetReceiptGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClientGrpc/GetReceiptGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientGrpcServer).GetReceiptGrpc(ctx, req.(*GetReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientGrpc_ListDelegatesGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientGrpcServer).ListDelegatesGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClientGrpc/ListDelegatesGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientGrpcServer).ListDelegatesGrpc(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientGrpc_StreamReceiptsGrpc_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReceiptsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClientGrpcServer).StreamReceiptsGrpc(m, &clientGrpcStreamReceiptsGrpcServer{stream})
+}
+
+type ClientGrpc_StreamReceiptsGrpcServer interface {
+	Send(*ReceiptResponse) error
+	grpc.ServerStream
+}
+
+type clientGrpcStreamReceiptsGrpcServer struct {
+	grpc.ServerStream
+}
+
+func (x *clientGrpcStreamReceiptsGrpcServer) Send(m *ReceiptResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ClientGrpc_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ClientGrpc",
+	HandlerType: (*ClientGrpcServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitTransactionGrpc",
+			Handler:    _ClientGrpc_SubmitTransactionGrpc_Handler,
+		},
+		{
+			MethodName: "GetAccountGrpc",
+			Handler:    _ClientGrpc_GetAccountGrpc_Handler,
+		},
+		{
+			MethodName: "GetTransactionGrpc",
+			Handler:    _ClientGrpc_GetTransactionGrpc_Handler,
+		},
+		{
+			MethodName: "GetReceiptGrpc",
+			Handler:    _ClientGrpc_GetReceiptGrpc_Handler,
+		},
+		{
+			MethodName: "ListDelegatesGrpc",
+			Handler:    _ClientGrpc_ListDelegatesGrpc_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamReceiptsGrpc",
+			Handler:       _ClientGrpc_StreamReceiptsGrpc_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/client.proto",
+}
@@ -56,6 +56,8 @@ var xxx_messageInfo_Empty proto.InternalMessageInfo
 type Request struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Payload              string   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	ProtocolVersion      int64    `protobuf:"varint,3,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Capabilities         []string `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -100,8 +102,24 @@ func (m *Request) GetPayload() string {
 	return ""
 }
 
+func (m *Request) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Request) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type Response struct {
 	Payload              string   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	ProtocolVersion      int64    `protobuf:"varint,2,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Capabilities         []string `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -139,6 +157,20 @@ func (m *Response) GetPayload() string {
 	return ""
 }
 
+func (m *Response) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Response) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type Item struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -195,6 +227,7 @@ type Account struct {
 	Created              int64    `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
 	Updated              int64    `protobuf:"varint,7,opt,name=updated,proto3" json:"updated,omitempty"`
 	Nonce                uint64   `protobuf:"varint,8,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	PrivateKey           string   `protobuf:"bytes,9,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -281,6 +314,13 @@ func (m *Account) GetNonce() uint64 {
 	return 0
 }
 
+func (m *Account) GetPrivateKey() string {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return ""
+}
+
 type Transaction struct {
 	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Type                 int32    `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
@@ -296,6 +336,7 @@ type Transaction struct {
 	Hertz                uint64   `protobuf:"varint,12,opt,name=hertz,proto3" json:"hertz,omitempty"`
 	FromName             string   `protobuf:"bytes,13,opt,name=fromName,proto3" json:"fromName,omitempty"`
 	ToName               string   `protobuf:"bytes,14,opt,name=toName,proto3" json:"toName,omitempty"`
+	Amount               string   `protobuf:"bytes,15,opt,name=amount,proto3" json:"amount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -424,6 +465,13 @@ func (m *Transaction) GetToName() string {
 	return ""
 }
 
+func (m *Transaction) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
 type Rumor struct {
 	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
@@ -496,11 +544,12 @@ func (m *Rumor) GetSignature() string {
 }
 
 type Gossip struct {
-	TxHash               string   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
-	Rumors               []*Rumor `protobuf:"bytes,2,rep,name=rumors,proto3" json:"rumors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TxHash               string       `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Rumors               []*Rumor     `protobuf:"bytes,2,rep,name=rumors,proto3" json:"rumors,omitempty"`
+	Transaction          *Transaction `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Gossip) Reset()         { *m = Gossip{} }
@@ -542,6 +591,297 @@ func (m *Gossip) GetRumors() []*Rumor {
 	return nil
 }
 
+func (m *Gossip) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type Receipt struct {
+	TransactionHash      string   `protobuf:"bytes,1,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	HumanReadableStatus  string   `protobuf:"bytes,3,opt,name=humanReadableStatus,proto3" json:"humanReadableStatus,omitempty"`
+	ContractAddress      string   `protobuf:"bytes,4,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	ContractResult       []byte   `protobuf:"bytes,5,opt,name=contractResult,proto3" json:"contractResult,omitempty"`
+	Created              int64    `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{8}
+}
+
+func (m *Receipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Receipt.Unmarshal(m, b)
+}
+func (m *Receipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Receipt.Marshal(b, m, deterministic)
+}
+func (m *Receipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Receipt.Merge(m, src)
+}
+func (m *Receipt) XXX_Size() int {
+	return xxx_messageInfo_Receipt.Size(m)
+}
+func (m *Receipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_Receipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Receipt proto.InternalMessageInfo
+
+func (m *Receipt) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *Receipt) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Receipt) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *Receipt) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *Receipt) GetContractResult() []byte {
+	if m != nil {
+		return m.ContractResult
+	}
+	return nil
+}
+
+func (m *Receipt) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type Endpoint struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port                 int64    `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Endpoint) Reset()         { *m = Endpoint{} }
+func (m *Endpoint) String() string { return proto.CompactTextString(m) }
+func (*Endpoint) ProtoMessage()    {}
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{9}
+}
+
+func (m *Endpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endpoint.Unmarshal(m, b)
+}
+func (m *Endpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endpoint.Marshal(b, m, deterministic)
+}
+func (m *Endpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endpoint.Merge(m, src)
+}
+func (m *Endpoint) XXX_Size() int {
+	return xxx_messageInfo_Endpoint.Size(m)
+}
+func (m *Endpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endpoint proto.InternalMessageInfo
+
+func (m *Endpoint) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Endpoint) GetPort() int64 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type Version struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	BuildTime            string   `protobuf:"bytes,2,opt,name=buildTime,proto3" json:"buildTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Version) Reset()         { *m = Version{} }
+func (m *Version) String() string { return proto.CompactTextString(m) }
+func (*Version) ProtoMessage()    {}
+func (*Version) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{10}
+}
+
+func (m *Version) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Version.Unmarshal(m, b)
+}
+func (m *Version) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Version.Marshal(b, m, deterministic)
+}
+func (m *Version) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Version.Merge(m, src)
+}
+func (m *Version) XXX_Size() int {
+	return xxx_messageInfo_Version.Size(m)
+}
+func (m *Version) XXX_DiscardUnknown() {
+	xxx_messageInfo_Version.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Version proto.InternalMessageInfo
+
+func (m *Version) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Version) GetBuildTime() string {
+	if m != nil {
+		return m.BuildTime
+	}
+	return ""
+}
+
+type Node struct {
+	Address              string    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	GrpcEndpoint         *Endpoint `protobuf:"bytes,2,opt,name=grpcEndpoint,proto3" json:"grpcEndpoint,omitempty"`
+	HttpEndpoint         *Endpoint `protobuf:"bytes,3,opt,name=httpEndpoint,proto3" json:"httpEndpoint,omitempty"`
+	LocalHttpApiPort     int64     `protobuf:"varint,4,opt,name=localHttpApiPort,proto3" json:"localHttpApiPort,omitempty"`
+	Type                 string    `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Status               string    `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	StatusTime           int64     `protobuf:"varint,7,opt,name=statusTime,proto3" json:"statusTime,omitempty"`
+	Version              *Version  `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	ProtocolVersion      int64     `protobuf:"varint,9,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Capabilities         []string  `protobuf:"bytes,10,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{11}
+}
+
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node.Unmarshal(m, b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+}
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
+}
+func (m *Node) XXX_Size() int {
+	return xxx_messageInfo_Node.Size(m)
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node proto.InternalMessageInfo
+
+func (m *Node) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Node) GetGrpcEndpoint() *Endpoint {
+	if m != nil {
+		return m.GrpcEndpoint
+	}
+	return nil
+}
+
+func (m *Node) GetHttpEndpoint() *Endpoint {
+	if m != nil {
+		return m.HttpEndpoint
+	}
+	return nil
+}
+
+func (m *Node) GetLocalHttpApiPort() int64 {
+	if m != nil {
+		return m.LocalHttpApiPort
+	}
+	return 0
+}
+
+func (m *Node) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Node) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Node) GetStatusTime() int64 {
+	if m != nil {
+		return m.StatusTime
+	}
+	return 0
+}
+
+func (m *Node) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *Node) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Node) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type SynchronizeRequest struct {
 	Index                int64    `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -553,7 +893,7 @@ func (m *SynchronizeRequest) Reset()         { *m = SynchronizeRequest{} }
 func (m *SynchronizeRequest) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeRequest) ProtoMessage()    {}
 func (*SynchronizeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0530f8dac0ca745e, []int{8}
+	return fileDescriptor_0530f8dac0ca745e, []int{12}
 }
 
 func (m *SynchronizeRequest) XXX_Unmarshal(b []byte) error {
@@ -592,7 +932,7 @@ func (m *SynchronizeResponse) Reset()         { *m = SynchronizeResponse{} }
 func (m *SynchronizeResponse) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeResponse) ProtoMessage()    {}
 func (*SynchronizeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0530f8dac0ca745e, []int{9}
+	return fileDescriptor_0530f8dac0ca745e, []int{13}
 }
 
 func (m *SynchronizeResponse) XXX_Unmarshal(b []byte) error {
@@ -621,17 +961,19 @@ func (m *SynchronizeResponse) GetItems() []*Item {
 }
 
 type SynchronizeAccountsResponse struct {
-	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Accounts             []*Account       `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Items                []*Item          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Checkpoint           *StateCheckpoint `protobuf:"bytes,3,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *SynchronizeAccountsResponse) Reset()         { *m = SynchronizeAccountsResponse{} }
 func (m *SynchronizeAccountsResponse) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeAccountsResponse) ProtoMessage()    {}
 func (*SynchronizeAccountsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0530f8dac0ca745e, []int{10}
+	return fileDescriptor_0530f8dac0ca745e, []int{14}
 }
 
 func (m *SynchronizeAccountsResponse) XXX_Unmarshal(b []byte) error {
@@ -659,6 +1001,107 @@ func (m *SynchronizeAccountsResponse) GetAccounts() []*Account {
 	return nil
 }
 
+func (m *SynchronizeAccountsResponse) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *SynchronizeAccountsResponse) GetCheckpoint() *StateCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+type StateCheckpoint struct {
+	StateRoot            []byte   `protobuf:"bytes,1,opt,name=stateRoot,proto3" json:"stateRoot,omitempty"`
+	AccountCount         int64    `protobuf:"varint,2,opt,name=accountCount,proto3" json:"accountCount,omitempty"`
+	ItemCount            int64    `protobuf:"varint,3,opt,name=itemCount,proto3" json:"itemCount,omitempty"`
+	Time                 int64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	TransactionsRoot     []byte   `protobuf:"bytes,5,opt,name=transactionsRoot,proto3" json:"transactionsRoot,omitempty"`
+	TransactionCount     int64    `protobuf:"varint,6,opt,name=transactionCount,proto3" json:"transactionCount,omitempty"`
+	Executed             []string `protobuf:"bytes,7,rep,name=executed,proto3" json:"executed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateCheckpoint) Reset()         { *m = StateCheckpoint{} }
+func (m *StateCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*StateCheckpoint) ProtoMessage()    {}
+func (*StateCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{15}
+}
+
+func (m *StateCheckpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateCheckpoint.Unmarshal(m, b)
+}
+func (m *StateCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateCheckpoint.Marshal(b, m, deterministic)
+}
+func (m *StateCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateCheckpoint.Merge(m, src)
+}
+func (m *StateCheckpoint) XXX_Size() int {
+	return xxx_messageInfo_StateCheckpoint.Size(m)
+}
+func (m *StateCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateCheckpoint proto.InternalMessageInfo
+
+func (m *StateCheckpoint) GetStateRoot() []byte {
+	if m != nil {
+		return m.StateRoot
+	}
+	return nil
+}
+
+func (m *StateCheckpoint) GetAccountCount() int64 {
+	if m != nil {
+		return m.AccountCount
+	}
+	return 0
+}
+
+func (m *StateCheckpoint) GetItemCount() int64 {
+	if m != nil {
+		return m.ItemCount
+	}
+	return 0
+}
+
+func (m *StateCheckpoint) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *StateCheckpoint) GetTransactionsRoot() []byte {
+	if m != nil {
+		return m.TransactionsRoot
+	}
+	return nil
+}
+
+func (m *StateCheckpoint) GetTransactionCount() int64 {
+	if m != nil {
+		return m.TransactionCount
+	}
+	return 0
+}
+
+func (m *StateCheckpoint) GetExecuted() []string {
+	if m != nil {
+		return m.Executed
+	}
+	return nil
+}
+
 type SynchronizeTransactionsResponse struct {
 	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -670,7 +1113,7 @@ func (m *SynchronizeTransactionsResponse) Reset()         { *m = SynchronizeTran
 func (m *SynchronizeTransactionsResponse) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeTransactionsResponse) ProtoMessage()    {}
 func (*SynchronizeTransactionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0530f8dac0ca745e, []int{11}
+	return fileDescriptor_0530f8dac0ca745e, []int{16}
 }
 
 func (m *SynchronizeTransactionsResponse) XXX_Unmarshal(b []byte) error {
@@ -709,7 +1152,7 @@ func (m *SynchronizeGossipResponse) Reset()         { *m = SynchronizeGossipResp
 func (m *SynchronizeGossipResponse) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeGossipResponse) ProtoMessage()    {}
 func (*SynchronizeGossipResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0530f8dac0ca745e, []int{12}
+	return fileDescriptor_0530f8dac0ca745e, []int{17}
 }
 
 func (m *SynchronizeGossipResponse) XXX_Unmarshal(b []byte) error {
@@ -737,6 +1180,288 @@ func (m *SynchronizeGossipResponse) GetGossips() []*Gossip {
 	return nil
 }
 
+type Range struct {
+	Start                int64    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Root                 []byte   `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	Count                int64    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	After                string   `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Range) Reset()         { *m = Range{} }
+func (m *Range) String() string { return proto.CompactTextString(m) }
+func (*Range) ProtoMessage()    {}
+func (*Range) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{18}
+}
+
+func (m *Range) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Range.Unmarshal(m, b)
+}
+func (m *Range) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Range.Marshal(b, m, deterministic)
+}
+func (m *Range) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Range.Merge(m, src)
+}
+func (m *Range) XXX_Size() int {
+	return xxx_messageInfo_Range.Size(m)
+}
+func (m *Range) XXX_DiscardUnknown() {
+	xxx_messageInfo_Range.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Range proto.InternalMessageInfo
+
+func (m *Range) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *Range) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *Range) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *Range) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Range) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+type SynchronizeRangesRequest struct {
+	Ranges               []*Range `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SynchronizeRangesRequest) Reset()         { *m = SynchronizeRangesRequest{} }
+func (m *SynchronizeRangesRequest) String() string { return proto.CompactTextString(m) }
+func (*SynchronizeRangesRequest) ProtoMessage()    {}
+func (*SynchronizeRangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{19}
+}
+
+func (m *SynchronizeRangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SynchronizeRangesRequest.Unmarshal(m, b)
+}
+func (m *SynchronizeRangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SynchronizeRangesRequest.Marshal(b, m, deterministic)
+}
+func (m *SynchronizeRangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronizeRangesRequest.Merge(m, src)
+}
+func (m *SynchronizeRangesRequest) XXX_Size() int {
+	return xxx_messageInfo_SynchronizeRangesRequest.Size(m)
+}
+func (m *SynchronizeRangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronizeRangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronizeRangesRequest proto.InternalMessageInfo
+
+func (m *SynchronizeRangesRequest) GetRanges() []*Range {
+	if m != nil {
+		return m.Ranges
+	}
+	return nil
+}
+
+type SynchronizeRangesResponse struct {
+	Ranges               []*Range `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SynchronizeRangesResponse) Reset()         { *m = SynchronizeRangesResponse{} }
+func (m *SynchronizeRangesResponse) String() string { return proto.CompactTextString(m) }
+func (*SynchronizeRangesResponse) ProtoMessage()    {}
+func (*SynchronizeRangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{20}
+}
+
+func (m *SynchronizeRangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SynchronizeRangesResponse.Unmarshal(m, b)
+}
+func (m *SynchronizeRangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SynchronizeRangesResponse.Marshal(b, m, deterministic)
+}
+func (m *SynchronizeRangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronizeRangesResponse.Merge(m, src)
+}
+func (m *SynchronizeRangesResponse) XXX_Size() int {
+	return xxx_messageInfo_SynchronizeRangesResponse.Size(m)
+}
+func (m *SynchronizeRangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronizeRangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronizeRangesResponse proto.InternalMessageInfo
+
+func (m *SynchronizeRangesResponse) GetRanges() []*Range {
+	if m != nil {
+		return m.Ranges
+	}
+	return nil
+}
+
+type SynchronizeRangeHashesResponse struct {
+	Hashes               []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	Next                 string   `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SynchronizeRangeHashesResponse) Reset()         { *m = SynchronizeRangeHashesResponse{} }
+func (m *SynchronizeRangeHashesResponse) String() string { return proto.CompactTextString(m) }
+func (*SynchronizeRangeHashesResponse) ProtoMessage()    {}
+func (*SynchronizeRangeHashesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{21}
+}
+
+func (m *SynchronizeRangeHashesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SynchronizeRangeHashesResponse.Unmarshal(m, b)
+}
+func (m *SynchronizeRangeHashesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SynchronizeRangeHashesResponse.Marshal(b, m, deterministic)
+}
+func (m *SynchronizeRangeHashesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronizeRangeHashesResponse.Merge(m, src)
+}
+func (m *SynchronizeRangeHashesResponse) XXX_Size() int {
+	return xxx_messageInfo_SynchronizeRangeHashesResponse.Size(m)
+}
+func (m *SynchronizeRangeHashesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronizeRangeHashesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronizeRangeHashesResponse proto.InternalMessageInfo
+
+func (m *SynchronizeRangeHashesResponse) GetHashes() []string {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *SynchronizeRangeHashesResponse) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+type SynchronizeByHashesRequest struct {
+	Hashes               []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SynchronizeByHashesRequest) Reset()         { *m = SynchronizeByHashesRequest{} }
+func (m *SynchronizeByHashesRequest) String() string { return proto.CompactTextString(m) }
+func (*SynchronizeByHashesRequest) ProtoMessage()    {}
+func (*SynchronizeByHashesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{22}
+}
+
+func (m *SynchronizeByHashesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SynchronizeByHashesRequest.Unmarshal(m, b)
+}
+func (m *SynchronizeByHashesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SynchronizeByHashesRequest.Marshal(b, m, deterministic)
+}
+func (m *SynchronizeByHashesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronizeByHashesRequest.Merge(m, src)
+}
+func (m *SynchronizeByHashesRequest) XXX_Size() int {
+	return xxx_messageInfo_SynchronizeByHashesRequest.Size(m)
+}
+func (m *SynchronizeByHashesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronizeByHashesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronizeByHashesRequest proto.InternalMessageInfo
+
+func (m *SynchronizeByHashesRequest) GetHashes() []string {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type SynchronizeByHashesResponse struct {
+	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Gossips              []*Gossip      `protobuf:"bytes,2,rep,name=gossips,proto3" json:"gossips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SynchronizeByHashesResponse) Reset()         { *m = SynchronizeByHashesResponse{} }
+func (m *SynchronizeByHashesResponse) String() string { return proto.CompactTextString(m) }
+func (*SynchronizeByHashesResponse) ProtoMessage()    {}
+func (*SynchronizeByHashesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0530f8dac0ca745e, []int{23}
+}
+
+func (m *SynchronizeByHashesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SynchronizeByHashesResponse.Unmarshal(m, b)
+}
+func (m *SynchronizeByHashesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SynchronizeByHashesResponse.Marshal(b, m, deterministic)
+}
+func (m *SynchronizeByHashesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronizeByHashesResponse.Merge(m, src)
+}
+func (m *SynchronizeByHashesResponse) XXX_Size() int {
+	return xxx_messageInfo_SynchronizeByHashesResponse.Size(m)
+}
+func (m *SynchronizeByHashesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronizeByHashesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronizeByHashesResponse proto.InternalMessageInfo
+
+func (m *SynchronizeByHashesResponse) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *SynchronizeByHashesResponse) GetGossips() []*Gossip {
+	if m != nil {
+		return m.Gossips
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "proto.Empty")
 	proto.RegisterType((*Request)(nil), "proto.Request")
@@ -746,61 +1471,111 @@ func init() {
 	proto.RegisterType((*Transaction)(nil), "proto.Transaction")
 	proto.RegisterType((*Rumor)(nil), "proto.Rumor")
 	proto.RegisterType((*Gossip)(nil), "proto.Gossip")
+	proto.RegisterType((*Receipt)(nil), "proto.Receipt")
+	proto.RegisterType((*Endpoint)(nil), "proto.Endpoint")
+	proto.RegisterType((*Version)(nil), "proto.Version")
+	proto.RegisterType((*Node)(nil), "proto.Node")
 	proto.RegisterType((*SynchronizeRequest)(nil), "proto.SynchronizeRequest")
 	proto.RegisterType((*SynchronizeResponse)(nil), "proto.SynchronizeResponse")
 	proto.RegisterType((*SynchronizeAccountsResponse)(nil), "proto.SynchronizeAccountsResponse")
+	proto.RegisterType((*StateCheckpoint)(nil), "proto.StateCheckpoint")
 	proto.RegisterType((*SynchronizeTransactionsResponse)(nil), "proto.SynchronizeTransactionsResponse")
 	proto.RegisterType((*SynchronizeGossipResponse)(nil), "proto.SynchronizeGossipResponse")
+	proto.RegisterType((*Range)(nil), "proto.Range")
+	proto.RegisterType((*SynchronizeRangesRequest)(nil), "proto.SynchronizeRangesRequest")
+	proto.RegisterType((*SynchronizeRangesResponse)(nil), "proto.SynchronizeRangesResponse")
+	proto.RegisterType((*SynchronizeRangeHashesResponse)(nil), "proto.SynchronizeRangeHashesResponse")
+	proto.RegisterType((*SynchronizeByHashesRequest)(nil), "proto.SynchronizeByHashesRequest")
+	proto.RegisterType((*SynchronizeByHashesResponse)(nil), "proto.SynchronizeByHashesResponse")
 }
 
 func init() { proto.RegisterFile("proto/dapos.proto", fileDescriptor_0530f8dac0ca745e) }
 
 var fileDescriptor_0530f8dac0ca745e = []byte{
-	// 702 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x51, 0x6e, 0xd3, 0x4c,
-	0x10, 0x8e, 0xe3, 0x38, 0x89, 0x27, 0x69, 0xfa, 0xff, 0x4b, 0x01, 0xb7, 0x20, 0x11, 0x56, 0x55,
-	0x89, 0xfa, 0xd0, 0x4a, 0x45, 0x02, 0x5e, 0x2b, 0x15, 0xda, 0xf2, 0x80, 0xd0, 0x16, 0x21, 0xc1,
-	0xdb, 0xc6, 0x5e, 0x1a, 0x8b, 0xd8, 0x6b, 0xec, 0x4d, 0xd5, 0xf4, 0x16, 0xdc, 0x80, 0xab, 0x70,
-	0x20, 0xee, 0x80, 0x76, 0x76, 0x9d, 0xd8, 0x49, 0x50, 0x9f, 0x3c, 0xdf, 0xcc, 0xec, 0x37, 0xdf,
-	0xcc, 0x78, 0xe0, 0xff, 0x2c, 0x97, 0x4a, 0x1e, 0x47, 0x3c, 0x93, 0xc5, 0x11, 0xda, 0xc4, 0xc3,
-	0x0f, 0xed, 0x80, 0xf7, 0x36, 0xc9, 0xd4, 0x9c, 0xbe, 0x86, 0x0e, 0x13, 0x3f, 0x66, 0xa2, 0x50,
-	0x84, 0x40, 0x4b, 0xcd, 0x33, 0x11, 0x38, 0x43, 0x67, 0xe4, 0x33, 0xb4, 0x49, 0x00, 0x9d, 0x8c,
-	0xcf, 0xa7, 0x92, 0x47, 0x41, 0x13, 0xdd, 0x25, 0xa4, 0xfb, 0xd0, 0x65, 0xa2, 0xc8, 0x64, 0x5a,
-	0xd4, 0xb2, 0x9c, 0x7a, 0xd6, 0x11, 0xb4, 0x2e, 0x95, 0x48, 0xc8, 0x7f, 0xe0, 0x7e, 0x17, 0x73,
-	0x1b, 0xd5, 0x26, 0xd9, 0x01, 0xef, 0x86, 0x4f, 0x67, 0x02, 0x79, 0xfb, 0xcc, 0x00, 0xfa, 0xc7,
-	0x81, 0xce, 0x69, 0x18, 0xca, 0x59, 0xaa, 0x34, 0x2b, 0x8f, 0xa2, 0x5c, 0x14, 0x45, 0xc9, 0x6a,
-	0xa1, 0x56, 0x9a, 0xf2, 0x44, 0x58, 0x49, 0x68, 0xeb, 0xec, 0x31, 0x9f, 0xf2, 0x34, 0x14, 0x81,
-	0x6b, 0xb2, 0x2d, 0x24, 0x07, 0x30, 0x98, 0x88, 0x5c, 0xdd, 0x9d, 0xde, 0xf0, 0x78, 0xca, 0xc7,
-	0x53, 0x11, 0xb4, 0x86, 0xce, 0xa8, 0xc5, 0x56, 0xbc, 0x64, 0x04, 0xdb, 0x2a, 0xe7, 0x69, 0xc1,
-	0x43, 0x15, 0xcb, 0xf4, 0x82, 0x17, 0x93, 0xc0, 0x43, 0xa6, 0x55, 0xb7, 0xae, 0x15, 0xe6, 0x82,
-	0x2b, 0x11, 0x05, 0xed, 0xa1, 0x33, 0x72, 0x59, 0x09, 0x75, 0x64, 0x96, 0x45, 0x18, 0xe9, 0x98,
-	0x88, 0x85, 0xba, 0xdf, 0x54, 0x6a, 0x75, 0x5d, 0x2c, 0x6e, 0x00, 0xfd, 0xdd, 0x84, 0xde, 0xa7,
-	0x25, 0xbb, 0xee, 0x6c, 0xa2, 0x0b, 0xdb, 0x1d, 0x68, 0x7b, 0xb1, 0x17, 0xdd, 0xad, 0x67, 0xf7,
-	0x42, 0xa0, 0xf5, 0x2d, 0x97, 0x89, 0x6d, 0x15, 0x6d, 0x32, 0x80, 0xa6, 0x92, 0xd8, 0x9b, 0xcf,
-	0x9a, 0x4a, 0x2e, 0x27, 0xec, 0xa1, 0x12, 0x03, 0xf4, 0xcb, 0x50, 0x46, 0x02, 0x85, 0xfb, 0x0c,
-	0x6d, 0xbd, 0x1d, 0x3e, 0x8e, 0x51, 0xb1, 0xcf, 0xb4, 0x49, 0x1e, 0x41, 0x3b, 0x11, 0x6a, 0x22,
-	0x23, 0x94, 0xeb, 0x33, 0x8b, 0xb4, 0x3f, 0xe3, 0x39, 0x4f, 0x8a, 0xc0, 0x37, 0x7e, 0x83, 0x50,
-	0x63, 0x9c, 0x88, 0x00, 0xb0, 0x14, 0xda, 0xe4, 0x29, 0xf8, 0x45, 0x7c, 0x9d, 0x72, 0x35, 0xcb,
-	0x45, 0xd0, 0xc3, 0xf4, 0xa5, 0x43, 0xab, 0xc3, 0xf9, 0x07, 0x7d, 0x33, 0x0f, 0x04, 0x64, 0x0f,
-	0xba, 0xba, 0x97, 0x0f, 0x7a, 0xbb, 0x5b, 0xf8, 0x64, 0x81, 0x75, 0x6d, 0x25, 0x31, 0x32, 0x30,
-	0xb5, 0x0d, 0xa2, 0x3f, 0x1d, 0xf0, 0xd8, 0x2c, 0x91, 0xf9, 0xc6, 0xe9, 0x55, 0xfe, 0xa2, 0x66,
-	0xfd, 0x2f, 0xda, 0xb0, 0x6f, 0x77, 0xf3, 0xbe, 0xcb, 0xee, 0x5a, 0xff, 0xea, 0xce, 0x5b, 0xe9,
-	0x8e, 0xbe, 0x83, 0xf6, 0xb9, 0x2c, 0x8a, 0x38, 0x43, 0xd5, 0xb7, 0x17, 0x4b, 0x55, 0x16, 0x91,
-	0x7d, 0x68, 0xe7, 0x5a, 0xb4, 0x96, 0xe5, 0x8e, 0x7a, 0x27, 0x7d, 0x73, 0xa0, 0x47, 0xd8, 0x09,
-	0xb3, 0x31, 0x7a, 0x08, 0xe4, 0x6a, 0x9e, 0x86, 0x93, 0x5c, 0xa6, 0xf1, 0x9d, 0x28, 0x2f, 0x75,
-	0x07, 0xbc, 0xcb, 0x34, 0x12, 0xb7, 0x48, 0xe9, 0x32, 0x03, 0xe8, 0x1b, 0x78, 0x50, 0xcb, 0xb5,
-	0xc7, 0xf9, 0x1c, 0x3c, 0x7d, 0x82, 0xfa, 0x88, 0x74, 0x9d, 0x9e, 0xad, 0xa3, 0x7d, 0xcc, 0x44,
-	0xe8, 0x25, 0x3c, 0xa9, 0xbc, 0xb4, 0xf7, 0x57, 0x2c, 0x18, 0x0e, 0xa1, 0xcb, 0xad, 0xcf, 0x92,
-	0x0c, 0x2c, 0x89, 0x4d, 0x65, 0x8b, 0x38, 0xfd, 0x02, 0xcf, 0x2a, 0x54, 0x95, 0x5f, 0x7b, 0x49,
-	0xf7, 0x0a, 0xfa, 0x95, 0x01, 0x97, 0x94, 0xc4, 0x52, 0x56, 0x9e, 0xb0, 0x5a, 0x1e, 0x3d, 0x83,
-	0xdd, 0x0a, 0xb5, 0x19, 0xef, 0x82, 0xf4, 0x05, 0x74, 0xae, 0xd1, 0x53, 0xf2, 0x6d, 0x59, 0x3e,
-	0x9b, 0x57, 0x46, 0x4f, 0x7e, 0xb9, 0xe0, 0x9f, 0x9d, 0x7e, 0x94, 0x57, 0xe7, 0x79, 0x16, 0x92,
-	0xf7, 0xb0, 0x5d, 0xe5, 0xd4, 0xae, 0x5d, 0xfb, 0x70, 0x7d, 0xee, 0x7b, 0x7b, 0x9b, 0x42, 0x46,
-	0x00, 0x6d, 0x90, 0xaf, 0xf0, 0x78, 0xc3, 0x14, 0xef, 0xe3, 0xa4, 0xeb, 0xa1, 0xd5, 0x05, 0xd0,
-	0x06, 0x19, 0xd7, 0x36, 0x54, 0x1d, 0xeb, 0x7d, 0xfc, 0x07, 0xeb, 0xa1, 0x4d, 0x5b, 0xa1, 0x0d,
-	0xf2, 0x19, 0x1e, 0xae, 0xcd, 0xf7, 0x3e, 0xf6, 0xe1, 0x7a, 0xa8, 0xbe, 0x18, 0xda, 0x20, 0xc7,
-	0x00, 0x15, 0xb2, 0xf2, 0xd7, 0x29, 0x19, 0xb6, 0x17, 0xb8, 0x7c, 0x30, 0x6e, 0xa3, 0xe7, 0xe5,
-	0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x53, 0xd7, 0x1e, 0x2e, 0xbf, 0x06, 0x00, 0x00,
+	// 1318 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x8e, 0xd3, 0xc6,
+	0x17, 0xdf, 0x7c, 0x6f, 0x4e, 0xc2, 0x2e, 0xff, 0x01, 0x16, 0x13, 0xfe, 0x82, 0x65, 0xd4, 0xd2,
+	0x68, 0x2f, 0xa0, 0x5a, 0x10, 0xea, 0x65, 0x53, 0x40, 0x40, 0x5b, 0x21, 0x34, 0x8b, 0x90, 0x5a,
+	0xf5, 0x66, 0x62, 0x0f, 0x1b, 0x8b, 0xc4, 0xe3, 0xda, 0x93, 0xd5, 0x06, 0x55, 0xbd, 0xeb, 0x65,
+	0x2f, 0xfa, 0x0e, 0x7d, 0x87, 0x3e, 0x4b, 0x1f, 0xa1, 0x8f, 0xd0, 0x5e, 0x55, 0xe7, 0xcc, 0xd8,
+	0xb1, 0x1d, 0x87, 0x45, 0xea, 0x55, 0xe6, 0xfc, 0xce, 0xf1, 0xf9, 0xfe, 0x08, 0xfc, 0x2f, 0x4e,
+	0xb4, 0xd1, 0xf7, 0x03, 0x19, 0xeb, 0xf4, 0x1e, 0xbd, 0x59, 0x87, 0x7e, 0x78, 0x0f, 0x3a, 0x4f,
+	0x17, 0xb1, 0x59, 0xf1, 0x5f, 0x1a, 0xd0, 0x13, 0xea, 0xc7, 0xa5, 0x4a, 0x0d, 0x63, 0xd0, 0x36,
+	0xab, 0x58, 0x79, 0x8d, 0xc3, 0xc6, 0xb8, 0x2f, 0xe8, 0xcd, 0x3c, 0xe8, 0xc5, 0x72, 0x35, 0xd7,
+	0x32, 0xf0, 0x9a, 0x04, 0x67, 0x24, 0x1b, 0xc3, 0x3e, 0xe9, 0xf2, 0xf5, 0xfc, 0x8d, 0x4a, 0xd2,
+	0x50, 0x47, 0x5e, 0xeb, 0xb0, 0x31, 0x6e, 0x89, 0x2a, 0xcc, 0x38, 0x0c, 0x7d, 0x19, 0xcb, 0x69,
+	0x38, 0x0f, 0x4d, 0xa8, 0x52, 0xaf, 0x7d, 0xd8, 0x1a, 0xf7, 0x45, 0x09, 0xe3, 0x09, 0xec, 0x0a,
+	0x95, 0xc6, 0x3a, 0x4a, 0x4b, 0x36, 0x1b, 0x17, 0xda, 0x6c, 0x7e, 0x9c, 0xcd, 0x56, 0x8d, 0xcd,
+	0x7b, 0xd0, 0x7e, 0x61, 0xd4, 0x82, 0x5d, 0x86, 0xd6, 0x3b, 0xb5, 0x72, 0xb6, 0xf0, 0xc9, 0xae,
+	0x42, 0xe7, 0x4c, 0xce, 0x97, 0x8a, 0xb4, 0x0f, 0x85, 0x25, 0xf8, 0xaf, 0x4d, 0xe8, 0x4d, 0x7c,
+	0x5f, 0x2f, 0x23, 0x83, 0x3e, 0xca, 0x20, 0x48, 0x54, 0x9a, 0x66, 0x3e, 0x3a, 0x12, 0xb3, 0x18,
+	0xc9, 0x85, 0x72, 0xe9, 0xa2, 0x37, 0x4a, 0x4f, 0xe5, 0x5c, 0x46, 0xbe, 0xa2, 0x1c, 0xf5, 0x45,
+	0x46, 0xb2, 0xbb, 0xb0, 0x37, 0x53, 0x89, 0x79, 0x3f, 0x39, 0x93, 0xe1, 0x5c, 0x4e, 0xe7, 0xca,
+	0x6b, 0x1f, 0x36, 0xc6, 0x6d, 0x51, 0x41, 0x31, 0x72, 0x93, 0xc8, 0x28, 0x95, 0xbe, 0x09, 0x75,
+	0xf4, 0x5c, 0xa6, 0x33, 0xaf, 0x43, 0x9a, 0xaa, 0x30, 0xda, 0xf2, 0x13, 0x25, 0x8d, 0x0a, 0xbc,
+	0x2e, 0xe5, 0x26, 0x23, 0x91, 0xb3, 0x8c, 0x03, 0xe2, 0xf4, 0x2c, 0xc7, 0x91, 0x18, 0x6f, 0xa4,
+	0xd1, 0xbb, 0x5d, 0x32, 0x6e, 0x09, 0x76, 0x0b, 0x20, 0x4e, 0xc2, 0x33, 0x69, 0xd4, 0x37, 0x6a,
+	0xe5, 0xf5, 0xc9, 0x5c, 0x01, 0xe1, 0x7f, 0x36, 0x61, 0xf0, 0x7a, 0x6d, 0x1d, 0x23, 0x9f, 0xa1,
+	0x63, 0xae, 0x7f, 0xf0, 0x9d, 0xf7, 0x14, 0x66, 0xa3, 0xe3, 0x7a, 0x8a, 0x41, 0xfb, 0x6d, 0xa2,
+	0x17, 0x2e, 0x15, 0xf4, 0x66, 0x7b, 0xd0, 0x34, 0x9a, 0x62, 0xef, 0x8b, 0xa6, 0xd1, 0xeb, 0x0a,
+	0x74, 0xc8, 0x53, 0x4b, 0xe0, 0x97, 0xbe, 0x0e, 0x14, 0x05, 0xd6, 0x17, 0xf4, 0xc6, 0xea, 0xc9,
+	0x69, 0x48, 0x11, 0xf5, 0x05, 0x3e, 0xd9, 0x01, 0x74, 0x17, 0xca, 0xcc, 0x74, 0x40, 0xe1, 0xf4,
+	0x85, 0xa3, 0x10, 0x8f, 0x65, 0x22, 0x17, 0xa9, 0x8b, 0xc5, 0x51, 0xe4, 0x63, 0xb8, 0x50, 0x1e,
+	0x90, 0x29, 0x7a, 0xb3, 0xff, 0x43, 0x3f, 0x0d, 0x4f, 0x23, 0x69, 0x96, 0x89, 0xf2, 0x06, 0x24,
+	0xbe, 0x06, 0xd0, 0x3b, 0xaa, 0x8f, 0x37, 0xb4, 0xf9, 0x22, 0x82, 0x8d, 0x60, 0x17, 0x63, 0x79,
+	0x89, 0xd5, 0xbf, 0x44, 0x9f, 0xe4, 0x34, 0xda, 0x36, 0x9a, 0x38, 0x7b, 0xd6, 0xb6, 0xd1, 0x19,
+	0x2e, 0x17, 0xd8, 0x51, 0xde, 0xbe, 0xc5, 0x2d, 0xc5, 0x7f, 0x6b, 0x40, 0x47, 0x2c, 0x17, 0x3a,
+	0xa9, 0xcd, 0x6a, 0xa1, 0xfb, 0x9a, 0xe5, 0xee, 0xab, 0xe9, 0x93, 0x56, 0x7d, 0x9f, 0x64, 0x51,
+	0xb7, 0xb7, 0x45, 0xdd, 0xa9, 0x44, 0xcd, 0x7f, 0x82, 0xee, 0x33, 0x9d, 0xa6, 0x61, 0x4c, 0xd1,
+	0x9c, 0x3f, 0x5f, 0x7b, 0xe5, 0x28, 0xf6, 0x09, 0x74, 0x13, 0x74, 0x1a, 0xdd, 0x6a, 0x8d, 0x07,
+	0xc7, 0x43, 0xbb, 0x75, 0xee, 0x51, 0x24, 0xc2, 0xf1, 0xd8, 0x43, 0x18, 0x14, 0x9c, 0x21, 0xff,
+	0x06, 0xc7, 0xcc, 0x89, 0x16, 0x1a, 0x4a, 0x14, 0xc5, 0xf8, 0x5f, 0xb4, 0xa9, 0x7c, 0x15, 0xc6,
+	0xa6, 0x2e, 0xca, 0x46, 0x7d, 0x94, 0x07, 0xd0, 0x4d, 0x8d, 0x34, 0xcb, 0x2c, 0x51, 0x8e, 0x62,
+	0x9f, 0xc3, 0x95, 0xd9, 0x72, 0x21, 0x23, 0xa1, 0x64, 0x80, 0x03, 0x76, 0x62, 0x85, 0x6c, 0xae,
+	0xea, 0x58, 0x68, 0xd3, 0xd7, 0x91, 0x49, 0xa4, 0x6f, 0x26, 0x2e, 0xf7, 0xb6, 0x5d, 0xab, 0x30,
+	0xce, 0x74, 0x06, 0x09, 0x95, 0x2e, 0xe7, 0x86, 0x52, 0x39, 0x14, 0x15, 0x74, 0xfb, 0xa4, 0xf2,
+	0x63, 0xd8, 0x7d, 0x1a, 0x05, 0xb1, 0x0e, 0x23, 0xda, 0xca, 0x33, 0x9d, 0x9a, 0xbc, 0xfe, 0xda,
+	0x6e, 0xea, 0x58, 0x27, 0xc6, 0x2d, 0x3f, 0x7a, 0xf3, 0x09, 0xf4, 0xb2, 0xe5, 0xe7, 0x41, 0xef,
+	0xcc, 0x3e, 0xb3, 0xe5, 0xe4, 0x48, 0x2c, 0xf0, 0x74, 0x19, 0xce, 0x83, 0xd7, 0x61, 0xbe, 0xa1,
+	0xd6, 0x00, 0xff, 0xa7, 0x09, 0xed, 0x97, 0x38, 0x53, 0xdb, 0xb7, 0xdb, 0x03, 0x18, 0x9e, 0x26,
+	0xb1, 0x9f, 0x79, 0x47, 0x3a, 0x06, 0xc7, 0xfb, 0xae, 0x78, 0x19, 0x2c, 0x4a, 0x42, 0xf8, 0xd1,
+	0xcc, 0x98, 0x38, 0xff, 0xa8, 0xb5, 0xe5, 0xa3, 0xa2, 0x10, 0x3b, 0x82, 0xcb, 0x73, 0xed, 0xcb,
+	0xf9, 0x73, 0x63, 0xe2, 0x49, 0x1c, 0xbe, 0xc2, 0x78, 0x6d, 0xaf, 0x6e, 0xe0, 0xf9, 0x96, 0xe9,
+	0x14, 0x2e, 0xd7, 0xba, 0xf2, 0xdd, 0x52, 0xe5, 0x6f, 0x01, 0xd8, 0x17, 0xe5, 0xc0, 0x2e, 0xc2,
+	0x02, 0xc2, 0xc6, 0xeb, 0xe4, 0xed, 0x92, 0x9f, 0x7b, 0xce, 0x4f, 0x97, 0xdd, 0x75, 0x32, 0x6b,
+	0xae, 0x51, 0xff, 0xe3, 0xae, 0x11, 0xd4, 0x5c, 0xa3, 0x23, 0x60, 0x27, 0xab, 0xc8, 0x9f, 0x25,
+	0x3a, 0x0a, 0xdf, 0xab, 0xec, 0x26, 0x5f, 0x85, 0xce, 0x8b, 0x28, 0x50, 0xe7, 0x54, 0x87, 0x96,
+	0xb0, 0x04, 0xff, 0x02, 0xae, 0x94, 0x64, 0xdd, 0xe1, 0xbc, 0x03, 0x1d, 0x3c, 0x68, 0x58, 0x34,
+	0x9c, 0xbe, 0x81, 0x73, 0x1c, 0x31, 0x61, 0x39, 0xfc, 0xf7, 0x06, 0xdc, 0x2c, 0x7c, 0xea, 0xce,
+	0x59, 0x9a, 0xab, 0x38, 0x82, 0x5d, 0xe9, 0x30, 0xa7, 0x25, 0x0b, 0xdf, 0x89, 0x8a, 0x9c, 0x8f,
+	0xe6, 0x42, 0x32, 0xd7, 0xac, 0x31, 0x47, 0x1c, 0xf6, 0x08, 0xc0, 0x9f, 0x29, 0xff, 0x5d, 0xb1,
+	0xee, 0x07, 0x4e, 0x0e, 0xe7, 0x4a, 0x3d, 0xce, 0xb9, 0xa2, 0x20, 0xc9, 0xff, 0x6e, 0xc0, 0x7e,
+	0x85, 0x4f, 0xcb, 0x09, 0x21, 0xa1, 0xb5, 0x9d, 0x86, 0xa1, 0x58, 0x03, 0x98, 0x62, 0xe7, 0xd8,
+	0x63, 0xbd, 0x74, 0x8d, 0xd9, 0x12, 0x25, 0x0c, 0x35, 0xa0, 0x5b, 0x56, 0xc0, 0xfe, 0x59, 0x59,
+	0x03, 0xb5, 0x0b, 0xf1, 0x08, 0x2e, 0x17, 0x36, 0x4a, 0x4a, 0xa6, 0xed, 0x30, 0x6f, 0xe0, 0x15,
+	0x59, 0x6b, 0xc4, 0xce, 0xf5, 0x06, 0x8e, 0xa7, 0x42, 0x9d, 0x2b, 0x7f, 0x69, 0x6f, 0x31, 0x36,
+	0x43, 0x4e, 0xf3, 0xef, 0xe0, 0x76, 0xa1, 0x42, 0xaf, 0x8b, 0x66, 0xb2, 0x2a, 0x3d, 0x82, 0x61,
+	0xd1, 0xbc, 0xab, 0x54, 0xdd, 0x0a, 0x2d, 0xc9, 0xf1, 0x27, 0x70, 0xa3, 0xa0, 0xda, 0x2e, 0xf3,
+	0x5c, 0xe9, 0x67, 0xd0, 0x3b, 0x25, 0x24, 0xd3, 0x77, 0xc9, 0xe9, 0x73, 0x72, 0x19, 0x97, 0x6b,
+	0xe8, 0x08, 0x19, 0x9d, 0xd2, 0x19, 0x4c, 0x8d, 0x4c, 0x4c, 0xd6, 0x9c, 0x44, 0xe0, 0x41, 0x56,
+	0x51, 0xe0, 0x0a, 0x80, 0x4f, 0xcc, 0x6c, 0xa2, 0xb5, 0x4d, 0xf9, 0x50, 0xd0, 0x1b, 0xbf, 0xa5,
+	0xca, 0xb8, 0x74, 0x5b, 0x02, 0x51, 0xf9, 0xd6, 0xa8, 0xc4, 0x4d, 0xb2, 0x25, 0xf8, 0x97, 0xe0,
+	0x15, 0xdb, 0x1d, 0x6d, 0xa7, 0xd9, 0x80, 0xe0, 0xc9, 0x21, 0xc0, 0x6b, 0x94, 0x4f, 0x0e, 0x82,
+	0xc2, 0xf1, 0xf8, 0xa4, 0x14, 0x78, 0xa6, 0xc1, 0x05, 0xfe, 0x71, 0x2a, 0xbe, 0x85, 0x5b, 0x55,
+	0x15, 0x78, 0x61, 0x0a, 0x7a, 0x0e, 0xa0, 0x3b, 0x23, 0x84, 0xf4, 0xf4, 0x85, 0xa3, 0x30, 0xfc,
+	0x48, 0x9d, 0x9b, 0xfc, 0x1f, 0xa1, 0x3a, 0x37, 0xfc, 0x21, 0x8c, 0x0a, 0xda, 0xbe, 0x5a, 0x65,
+	0xaa, 0x6c, 0x50, 0x5b, 0x34, 0xf1, 0x9f, 0xe1, 0x66, 0xed, 0x57, 0xff, 0xad, 0x2d, 0x8a, 0x95,
+	0x6f, 0x7e, 0xa8, 0xf2, 0xc7, 0x7f, 0x74, 0xa0, 0xff, 0x64, 0xf2, 0x4a, 0x9f, 0x3c, 0x4b, 0x62,
+	0x9f, 0x7d, 0x0d, 0xfb, 0xc5, 0x6e, 0x42, 0xe8, 0x46, 0x36, 0xdb, 0x1b, 0x9b, 0x6c, 0x34, 0xaa,
+	0x63, 0x59, 0xc7, 0xf9, 0x0e, 0xfb, 0x1e, 0xae, 0xd7, 0xac, 0xa5, 0x8b, 0x74, 0xf2, 0x4d, 0x56,
+	0x75, 0xa3, 0xf1, 0x1d, 0x36, 0x2d, 0x65, 0xad, 0x38, 0x50, 0x17, 0xe9, 0xbf, 0xbb, 0xc9, 0xaa,
+	0x9b, 0x47, 0xbe, 0xc3, 0xde, 0xc0, 0xb5, 0x8d, 0xc9, 0xba, 0x48, 0xfb, 0xe1, 0x26, 0xab, 0x3c,
+	0x92, 0x7c, 0x87, 0xdd, 0x07, 0x28, 0x28, 0xcb, 0x76, 0x71, 0xa6, 0x61, 0x3f, 0xa7, 0xf3, 0x0f,
+	0x7e, 0x80, 0x6b, 0xd5, 0x36, 0xb5, 0x61, 0xde, 0xae, 0x71, 0xa4, 0x38, 0x49, 0xa3, 0xc3, 0xed,
+	0x02, 0xb9, 0xf6, 0x13, 0x18, 0x55, 0xd9, 0xb6, 0x07, 0xc9, 0x44, 0x69, 0x70, 0x46, 0x9f, 0x6e,
+	0xd1, 0x57, 0x6e, 0x5a, 0xaa, 0xcf, 0xf5, 0x9a, 0xae, 0x26, 0x8d, 0x77, 0x36, 0x75, 0x54, 0x66,
+	0x65, 0xc4, 0x3f, 0x24, 0x92, 0xd9, 0x98, 0x76, 0x49, 0xe8, 0xc1, 0xbf, 0x03, 0x00, 0x61, 0xf8,
+	0xd0, 0x9b, 0x13, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -820,6 +1595,9 @@ type DAPoSGrpcClient interface {
 	SynchronizeTransactionsGrpc(ctx context.Context, in *SynchronizeRequest, opts ...grpc.CallOption) (*SynchronizeTransactionsResponse, error)
 	SynchronizeGossipGrpc(ctx context.Context, in *SynchronizeRequest, opts ...grpc.CallOption) (*SynchronizeGossipResponse, error)
 	GossipGrpc(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	SynchronizeRangesGrpc(ctx context.Context, in *SynchronizeRangesRequest, opts ...grpc.CallOption) (*SynchronizeRangesResponse, error)
+	SynchronizeRangeHashesGrpc(ctx context.Context, in *Range, opts ...grpc.CallOption) (*SynchronizeRangeHashesResponse, error)
+	SynchronizeByHashesGrpc(ctx context.Context, in *SynchronizeByHashesRequest, opts ...grpc.CallOption) (*SynchronizeByHashesResponse, error)
 }
 
 type dAPoSGrpcClient struct {
@@ -875,13 +1653,43 @@ func (c *dAPoSGrpcClient) GossipGrpc(ctx context.Context, in *Request, opts ...g
 	return out, nil
 }
 
+func (c *dAPoSGrpcClient) SynchronizeRangesGrpc(ctx context.Context, in *SynchronizeRangesRequest, opts ...grpc.CallOption) (*SynchronizeRangesResponse, error) {
+	out := new(SynchronizeRangesResponse)
+	err := c.cc.Invoke(ctx, "/proto.DAPoSGrpc/SynchronizeRangesGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAPoSGrpcClient) SynchronizeRangeHashesGrpc(ctx context.Context, in *Range, opts ...grpc.CallOption) (*SynchronizeRangeHashesResponse, error) {
+	out := new(SynchronizeRangeHashesResponse)
+	err := c.cc.Invoke(ctx, "/proto.DAPoSGrpc/SynchronizeRangeHashesGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAPoSGrpcClient) SynchronizeByHashesGrpc(ctx context.Context, in *SynchronizeByHashesRequest, opts ...grpc.CallOption) (*SynchronizeByHashesResponse, error) {
+	out := new(SynchronizeByHashesResponse)
+	err := c.cc.Invoke(ctx, "/proto.DAPoSGrpc/SynchronizeByHashesGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DAPoSGrpcServer is the server API for DAPoSGrpc service.
 type DAPoSGrpcServer interface {
 	SynchronizeGrpc(context.Context, *SynchronizeRequest) (*SynchronizeResponse, error)
-	//SynchronizeAccountsGrpc(context.Context, *SynchronizeRequest) (*SynchronizeAccountsResponse, error)
+	SynchronizeAccountsGrpc(context.Context, *SynchronizeRequest) (*SynchronizeAccountsResponse, error)
 	SynchronizeTransactionsGrpc(context.Context, *SynchronizeRequest) (*SynchronizeTransactionsResponse, error)
 	SynchronizeGossipGrpc(context.Context, *SynchronizeRequest) (*SynchronizeGossipResponse, error)
 	GossipGrpc(context.Context, *Request) (*Response, error)
+	SynchronizeRangesGrpc(context.Context, *SynchronizeRangesRequest) (*SynchronizeRangesResponse, error)
+	SynchronizeRangeHashesGrpc(context.Context, *Range) (*SynchronizeRangeHashesResponse, error)
+	SynchronizeByHashesGrpc(context.Context, *SynchronizeByHashesRequest) (*SynchronizeByHashesResponse, error)
 }
 
 func RegisterDAPoSGrpcServer(s *grpc.Server, srv DAPoSGrpcServer) {
@@ -906,23 +1714,23 @@ func _DAPoSGrpc_SynchronizeGrpc_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-//func _DAPoSGrpc_SynchronizeAccountsGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-//	in := new(SynchronizeRequest)
-//	if err := dec(in); err != nil {
-//		return nil, err
-//	}
-//	if interceptor == nil {
-//		return srv.(DAPoSGrpcServer).SynchronizeAccountsGrpc(ctx, in)
-//	}
-//	info := &grpc.UnaryServerInfo{
-//		Server:     srv,
-//		FullMethod: "/proto.DAPoSGrpc/SynchronizeAccountsGrpc",
-//	}
-//	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-//		return srv.(DAPoSGrpcServer).SynchronizeAccountsGrpc(ctx, req.(*SynchronizeRequest))
-//	}
-//	return interceptor(ctx, in, info, handler)
-//}
+func _DAPoSGrpc_SynchronizeAccountsGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynchronizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAPoSGrpcServer).SynchronizeAccountsGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.DAPoSGrpc/SynchronizeAccountsGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAPoSGrpcServer).SynchronizeAccountsGrpc(ctx, req.(*SynchronizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
 func _DAPoSGrpc_SynchronizeTransactionsGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SynchronizeRequest)
@@ -978,6 +1786,60 @@ func _DAPoSGrpc_GossipGrpc_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DAPoSGrpc_SynchronizeRangesGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynchronizeRangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAPoSGrpcServer).SynchronizeRangesGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.DAPoSGrpc/SynchronizeRangesGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAPoSGrpcServer).SynchronizeRangesGrpc(ctx, req.(*SynchronizeRangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAPoSGrpc_SynchronizeRangeHashesGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Range)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAPoSGrpcServer).SynchronizeRangeHashesGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.DAPoSGrpc/SynchronizeRangeHashesGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAPoSGrpcServer).SynchronizeRangeHashesGrpc(ctx, req.(*Range))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAPoSGrpc_SynchronizeByHashesGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynchronizeByHashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAPoSGrpcServer).SynchronizeByHashesGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.DAPoSGrpc/SynchronizeByHashesGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAPoSGrpcServer).SynchronizeByHashesGrpc(ctx, req.(*SynchronizeByHashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DAPoSGrpc_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.DAPoSGrpc",
 	HandlerType: (*DAPoSGrpcServer)(nil),
@@ -986,10 +1848,10 @@ var _DAPoSGrpc_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SynchronizeGrpc",
 			Handler:    _DAPoSGrpc_SynchronizeGrpc_Handler,
 		},
-		//{
-		//	MethodName: "SynchronizeAccountsGrpc",
-		//	Handler:    _DAPoSGrpc_SynchronizeAccountsGrpc_Handler,
-		//},
+		{
+			MethodName: "SynchronizeAccountsGrpc",
+			Handler:    _DAPoSGrpc_SynchronizeAccountsGrpc_Handler,
+		},
 		{
 			MethodName: "SynchronizeTransactionsGrpc",
 			Handler:    _DAPoSGrpc_SynchronizeTransactionsGrpc_Handler,
@@ -1002,6 +1864,18 @@ var _DAPoSGrpc_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GossipGrpc",
 			Handler:    _DAPoSGrpc_GossipGrpc_Handler,
 		},
+		{
+			MethodName: "SynchronizeRangesGrpc",
+			Handler:    _DAPoSGrpc_SynchronizeRangesGrpc_Handler,
+		},
+		{
+			MethodName: "SynchronizeRangeHashesGrpc",
+			Handler:    _DAPoSGrpc_SynchronizeRangeHashesGrpc_Handler,
+		},
+		{
+			MethodName: "SynchronizeByHashesGrpc",
+			Handler:    _DAPoSGrpc_SynchronizeByHashesGrpc_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/dapos.proto",
@@ -0,0 +1,249 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ErrStaleDelegateSet - The update is not newer than the accepted set
+var ErrStaleDelegateSet = errors.New("stale delegate set")
+
+// maxDelegateSetHistory - Delegate sets served to a syncing node
+const maxDelegateSetHistory = 1000
+
+// signDelegateSet - Seeds sign a new epoch when the delegates differ from the latest set, otherwise the latest set is reused.
+// The latest set may have been signed by another seed, its epoch is continued
+func (this *DisGoverService) signDelegateSet(delegates []*types.Node) (*types.DelegateSet, error) {
+	this.delegateSetMutex.Lock()
+	defer this.delegateSetMutex.Unlock()
+	if this.delegateSet != nil && this.delegateSet.HasSameDelegates(&types.DelegateSet{Delegates: delegates}) {
+		return this.delegateSet, nil
+	}
+	var epoch int64 = 1
+	if this.delegateSet != nil {
+		epoch = this.delegateSet.Epoch + 1
+	}
+	delegateSet, err := types.NewDelegateSet(types.GetKey(), this.ThisNode.Address, epoch, delegates)
+	if err != nil {
+		return nil, err
+	}
+	err = services.GetDb().Update(func(txn *badger.Txn) error {
+		return delegateSet.Persist(txn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	this.delegateSet = delegateSet
+	utils.Info(fmt.Sprintf("signed delegate set [epoch=%d, delegates=%d]", delegateSet.Epoch, len(delegateSet.Delegates)))
+	return delegateSet, nil
+}
+
+// acceptDelegateSet - Verifies a set signed by a seed and records it, the set must be newer than the accepted one. Returns
+// false when the set is the one already accepted (seeds answer a ping and then push the same set).
+func (this *DisGoverService) acceptDelegateSet(delegateSet *types.DelegateSet) (bool, error) {
+	err := delegateSet.Verify(getSeedAddresses())
+	if err != nil {
+		return false, err
+	}
+	this.delegateSetMutex.Lock()
+	defer this.delegateSetMutex.Unlock()
+	if this.delegateSet != nil {
+		if delegateSet.Epoch == this.delegateSet.Epoch && delegateSet.Hash == this.delegateSet.Hash {
+			return false, nil
+		}
+		if !delegateSet.IsNewerThan(this.delegateSet) {
+			return false, errors.Wrapf(ErrStaleDelegateSet, "epoch %d from %s, accepted %d from %s", delegateSet.Epoch, delegateSet.Seed, this.delegateSet.Epoch, this.delegateSet.Seed)
+		}
+	}
+	err = services.GetDb().Update(func(txn *badger.Txn) error {
+		return delegateSet.Persist(txn)
+	})
+	if err != nil {
+		return false, err
+	}
+	this.delegateSet = delegateSet
+	utils.Info(fmt.Sprintf("accepted delegate set [epoch=%d, seed=%s, delegates=%d]", delegateSet.Epoch, delegateSet.Seed, len(delegateSet.Delegates)))
+	return true, nil
+}
+
+// loadDelegateSet - The latest accepted set from a previous run
+func (this *DisGoverService) loadDelegateSet() {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	delegateSet, err := types.ToLatestDelegateSet(txn)
+	if err != nil {
+		if err != types.ErrNotFound {
+			utils.Error("unable to load delegate set", err)
+		}
+		return
+	}
+	this.delegateSetMutex.Lock()
+	defer this.delegateSetMutex.Unlock()
+	this.delegateSet = delegateSet
+}
+
+// GetDelegateSet - The latest accepted delegate set, nil without one
+func (this *DisGoverService) GetDelegateSet() *types.DelegateSet {
+	this.delegateSetMutex.RLock()
+	defer this.delegateSetMutex.RUnlock()
+	return this.delegateSet
+}
+
+// GetDelegateSets - Accepted delegate sets, newest first
+func (this *DisGoverService) GetDelegateSets(limit int) ([]*types.DelegateSet, error) {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	return types.ToDelegateSets(txn, limit)
+}
+
+// DelegateSetsGrpc - The accepted delegate sets, newest first, syncing nodes count the rumor quorums of old transactions against them
+func (this *DisGoverService) DelegateSetsGrpc(ctx context.Context, empty *proto.Empty) (*proto.DelegateSets, error) {
+	delegateSets, err := this.GetDelegateSets(maxDelegateSetHistory)
+	if err != nil {
+		utils.Error("unable to read delegate sets", err)
+		return nil, err
+	}
+	response := &proto.DelegateSets{}
+	for _, delegateSet := range delegateSets {
+		response.Sets = append(response.Sets, convertToProtoUpdate(delegateSet, nil))
+	}
+	return response, nil
+}
+
+// SynchronizeDelegateSets - Records the delegate set history of the first seed that answers
+func (this *DisGoverService) SynchronizeDelegateSets() error {
+	var lastErr = errors.New("no seed nodes configured")
+	for _, seed := range types.GetConfig().Seeds {
+		if seed.GrpcEndpoint == nil {
+			continue
+		}
+		delegateSets, err := getSeedDelegateSets(seed)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to get delegate sets from seed [address=%s]", seed.Address), err)
+			lastErr = err
+			continue
+		}
+		return recordDelegateSets(delegateSets)
+	}
+	return lastErr
+}
+
+// getSeedDelegateSets
+func getSeedDelegateSets(seed *types.Node) ([]*types.DelegateSet, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", seed.GrpcEndpoint.Host, seed.GrpcEndpoint.Port), services.GetGrpcDialOptions(seed.Address)...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	response, err := proto.NewDisgoverGrpcClient(conn).DelegateSetsGrpc(ctx, &proto.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	delegateSets := make([]*types.DelegateSet, 0, len(response.Sets))
+	for _, update := range response.Sets {
+		delegateSets = append(delegateSets, convertToDomainDelegateSet(update))
+	}
+	return delegateSets, nil
+}
+
+// recordDelegateSets - Persists the sets signed by a seed that this node does not have as history, the latest accepted set
+// only changes through acceptDelegateSet
+func recordDelegateSets(delegateSets []*types.DelegateSet) error {
+	seeds := getSeedAddresses()
+	recorded := 0
+	err := services.GetDb().Update(func(txn *badger.Txn) error {
+		for _, delegateSet := range delegateSets {
+			err := delegateSet.Verify(seeds)
+			if err != nil {
+				utils.Warn(fmt.Sprintf("skipped delegate set [epoch=%d]", delegateSet.Epoch), err)
+				continue
+			}
+			_, err = txn.Get([]byte(delegateSet.Key()))
+			if err == nil {
+				continue
+			} else if err != badger.ErrKeyNotFound {
+				return err
+			}
+			err = delegateSet.Persist(txn)
+			if err != nil {
+				return err
+			}
+			recorded++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	utils.Info(fmt.Sprintf("recorded delegate set history [sets=%d, new=%d]", len(delegateSets), recorded))
+	return nil
+}
+
+// getSeedAddresses
+func getSeedAddresses() []string {
+	addresses := make([]string, 0, len(types.GetConfig().Seeds))
+	for _, seed := range types.GetConfig().Seeds {
+		addresses = append(addresses, seed.Address)
+	}
+	return addresses
+}
+
+// convertToDomainDelegateSet
+func convertToDomainDelegateSet(update *proto.Update) *types.DelegateSet {
+	delegates := make([]*types.Node, 0, len(update.Delegates))
+	for _, delegate := range update.Delegates {
+		delegates = append(delegates, convertToDomainNode(delegate))
+	}
+	return &types.DelegateSet{
+		Epoch:     update.Epoch,
+		Time:      update.Time,
+		Seed:      update.Seed,
+		Delegates: delegates,
+		Hash:      update.Hash,
+		Signature: update.Signature,
+	}
+}
+
+// convertToProtoUpdate
+func convertToProtoUpdate(delegateSet *types.DelegateSet, authentication *types.Authentication) *proto.Update {
+	delegates := make([]*proto.Node, 0, len(delegateSet.Delegates))
+	for _, delegate := range delegateSet.Delegates {
+		delegates = append(delegates, convertToProtoNode(delegate))
+	}
+	return &proto.Update{
+		Authentication: convertToProtoAuthentication(authentication),
+		Delegates:      delegates,
+		Epoch:          delegateSet.Epoch,
+		Time:           delegateSet.Time,
+		Seed:           delegateSet.Seed,
+		Hash:           delegateSet.Hash,
+		Signature:      delegateSet.Signature,
+	}
+}
@@ -0,0 +1,87 @@
+package disgover
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/pkg/errors"
+)
+
+// testSeed - a seed that signs delegate sets
+type testSeed struct {
+	node       *types.Node
+	privateKey string
+}
+
+// newTestSigningSeeds
+func newTestSigningSeeds(count int) []*testSeed {
+	seeds := make([]*testSeed, 0, count)
+	for i := 0; i < count; i++ {
+		publicKey, privateKey := crypto.GenerateKeyPair()
+		seeds = append(seeds, &testSeed{newTestSeed(hex.EncodeToString(crypto.ToAddress(publicKey))), hex.EncodeToString(privateKey)})
+	}
+	return seeds
+}
+
+// sign
+func (this *testSeed) sign(t *testing.T, epoch int64, delegates ...string) *types.DelegateSet {
+	delegateSet, err := types.NewDelegateSet(this.privateKey, this.node.Address, epoch, newTestDelegates(delegates...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delegateSet
+}
+
+// TestAcceptDelegateSet - sets from different seeds are ordered by epoch then seed, both are kept as history
+func TestAcceptDelegateSet(t *testing.T) {
+	seeds := newTestSigningSeeds(2)
+	if seeds[0].node.Address > seeds[1].node.Address {
+		seeds[0], seeds[1] = seeds[1], seeds[0]
+	}
+	_, restore := setTestSeeds([]*types.Node{seeds[0].node, seeds[1].node}, nil)
+	defer restore()
+	service := newTestService(t, nil)
+
+	tests := []struct {
+		delegateSet *types.DelegateSet
+		accepted    bool
+		stale       bool
+	}{
+		{seeds[1].sign(t, 2, "a"), true, false},
+		{seeds[0].sign(t, 2, "b"), false, true}, // Same epoch, lower seed
+		{seeds[0].sign(t, 1, "c"), false, true},
+		{seeds[0].sign(t, 3, "d"), true, false},
+		{seeds[1].sign(t, 3, "e"), true, false}, // Same epoch, higher seed
+	}
+	for i, test := range tests {
+		accepted, err := service.acceptDelegateSet(test.delegateSet)
+		if accepted != test.accepted || (errors.Cause(err) == ErrStaleDelegateSet) != test.stale {
+			t.Errorf("%d: accepted %t, %v", i, accepted, err)
+		}
+	}
+	if accepted, err := service.acceptDelegateSet(tests[4].delegateSet); accepted || err != nil {
+		t.Errorf("the accepted set was accepted again [%t, %v]", accepted, err)
+	}
+	if latest := service.GetDelegateSet(); latest.Epoch != 3 || latest.Seed != seeds[1].node.Address {
+		t.Errorf("latest set is %d from %s", latest.Epoch, latest.Seed)
+	}
+
+	delegateSets, err := service.GetDelegateSets(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delegateSets) != 3 || delegateSets[0].Hash != tests[4].delegateSet.Hash || delegateSets[1].Hash != tests[3].delegateSet.Hash {
+		t.Errorf("%d sets of the same epoch were not both kept", len(delegateSets))
+	}
+
+	// A seed continues the epoch of a set signed by another seed.
+	delegateSet, err := service.signDelegateSet(newTestDelegates("f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delegateSet.Epoch != 4 || !delegateSet.IsNewerThan(tests[4].delegateSet) {
+		t.Errorf("signed epoch %d", delegateSet.Epoch)
+	}
+}
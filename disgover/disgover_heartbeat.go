@@ -0,0 +1,171 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Delegates are pinged every heartbeat, a node is unhealthy after heartbeatUnhealthyStreak consecutive failures.
+const (
+	heartbeatInterval        = 15 * time.Second
+	heartbeatUnhealthyStreak = 3
+	heartbeatLatencyWeight   = 0.2                    // Of the newest sample in the latency moving average
+	heartbeatLatencyScale    = 500 * time.Millisecond // Latency that halves the score
+)
+
+// NodeHealth - Liveness of a node as seen by this node
+type NodeHealth struct {
+	Address       string    `json:"address"`
+	Healthy       bool      `json:"healthy"`
+	Score         float64   `json:"score"`   // 0 to 1, uptime discounted by latency
+	Latency       int64     `json:"latency"` // Moving average of successful pings (ms)
+	FailureStreak int       `json:"failureStreak"`
+	Successes     int64     `json:"successes"`
+	Failures      int64     `json:"failures"`
+	Uptime        float64   `json:"uptime"` // Share of successful heartbeats
+	LastSeen      time.Time `json:"lastSeen,omitempty"`
+	LastFailure   time.Time `json:"lastFailure,omitempty"`
+	Since         time.Time `json:"since"`
+
+	latency time.Duration
+}
+
+// update - caller holds the lock
+func (this *NodeHealth) update() {
+	total := this.Successes + this.Failures
+	if total > 0 {
+		this.Uptime = float64(this.Successes) / float64(total)
+	}
+	this.Latency = int64(this.latency / time.Millisecond)
+	this.Healthy = this.FailureStreak < heartbeatUnhealthyStreak
+	this.Score = 0
+	if this.Healthy {
+		this.Score = this.Uptime / (1 + float64(this.latency)/float64(heartbeatLatencyScale))
+	}
+}
+
+// RecordSuccess - A call to the node succeeded
+func (this *DisGoverService) RecordSuccess(address string, latency time.Duration) {
+	this.healthMutex.Lock()
+	defer this.healthMutex.Unlock()
+	health := this.getOrCreateHealth(address)
+	if health.Successes == 0 {
+		health.latency = latency
+	} else {
+		health.latency = time.Duration(heartbeatLatencyWeight*float64(latency) + (1-heartbeatLatencyWeight)*float64(health.latency))
+	}
+	health.Successes++
+	health.FailureStreak = 0
+	health.LastSeen = time.Now()
+	health.update()
+}
+
+// RecordFailure - A call to the node failed
+func (this *DisGoverService) RecordFailure(address string) {
+	this.healthMutex.Lock()
+	defer this.healthMutex.Unlock()
+	health := this.getOrCreateHealth(address)
+	health.Failures++
+	health.FailureStreak++
+	health.LastFailure = time.Now()
+	health.update()
+}
+
+// IsHealthy - Nodes without heartbeats yet are considered healthy
+func (this *DisGoverService) IsHealthy(address string) bool {
+	this.healthMutex.RLock()
+	defer this.healthMutex.RUnlock()
+	health, ok := this.health[address]
+	return !ok || health.Healthy
+}
+
+// GetHealth - A copy of the health of a node, nil without heartbeats yet
+func (this *DisGoverService) GetHealth(address string) *NodeHealth {
+	this.healthMutex.RLock()
+	defer this.healthMutex.RUnlock()
+	health, ok := this.health[address]
+	if !ok {
+		return nil
+	}
+	result := *health
+	return &result
+}
+
+// getOrCreateHealth - caller holds the lock
+func (this *DisGoverService) getOrCreateHealth(address string) *NodeHealth {
+	health, ok := this.health[address]
+	if !ok {
+		health = &NodeHealth{Address: address, Since: time.Now()}
+		this.health[address] = health
+	}
+	return health
+}
+
+// heartbeatWorker
+func (this *DisGoverService) heartbeatWorker() {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		this.heartbeat()
+		this.pruneReputations()
+	}
+}
+
+// heartbeat - Pings every delegate in parallel and forgets nodes that are no longer delegates
+func (this *DisGoverService) heartbeat() {
+	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	addresses := map[string]bool{}
+	var waitGroup sync.WaitGroup
+	for _, delegate := range delegates {
+		addresses[delegate.Address] = true
+		if delegate.Address == this.ThisNode.Address || delegate.GrpcEndpoint == nil {
+			continue
+		}
+		waitGroup.Add(1)
+		go func(delegate *types.Node) {
+			defer waitGroup.Done()
+			start := time.Now()
+			_, err := this.peerPingGrpc(delegate)
+			if err != nil {
+				utils.Debug(fmt.Sprintf("heartbeat failed [address=%s, host=%s, port=%d]", delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
+				this.RecordFailure(delegate.Address)
+				return
+			}
+			this.RecordSuccess(delegate.Address, time.Since(start))
+		}(delegate)
+	}
+	waitGroup.Wait()
+
+	this.healthMutex.Lock()
+	defer this.healthMutex.Unlock()
+	for address := range this.health {
+		if !addresses[address] {
+			delete(this.health, address)
+		}
+	}
+}
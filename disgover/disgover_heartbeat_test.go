@@ -0,0 +1,64 @@
+package disgover
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// TestRecordHealth - failures lower the uptime and a streak of them makes a node unhealthy until it answers again
+func TestRecordHealth(t *testing.T) {
+	service := newTestService(t, nil)
+	if !service.IsHealthy("node") || service.GetHealth("node") != nil {
+		t.Error("a node without heartbeats is not healthy")
+	}
+
+	service.RecordSuccess("node", 100*time.Millisecond)
+	health := service.GetHealth("node")
+	if !health.Healthy || health.Latency != 100 || math.Abs(health.Score-1/1.2) > 1e-9 {
+		t.Errorf("unexpected health after a success %+v", health)
+	}
+	service.RecordSuccess("node", 600*time.Millisecond)
+	if health = service.GetHealth("node"); health.Latency != 200 {
+		t.Errorf("latency average is %dms, expected 200ms", health.Latency)
+	}
+
+	for i := 1; i <= heartbeatUnhealthyStreak; i++ {
+		service.RecordFailure("node")
+		health = service.GetHealth("node")
+		if health.FailureStreak != i || health.Healthy != (i < heartbeatUnhealthyStreak) {
+			t.Errorf("failure %d: unexpected health %+v", i, health)
+		}
+	}
+	if health.Score != 0 || service.IsHealthy("node") || math.Abs(health.Uptime-2.0/5) > 1e-9 {
+		t.Errorf("unexpected health after a failure streak %+v", health)
+	}
+
+	service.RecordSuccess("node", 200*time.Millisecond)
+	health = service.GetHealth("node")
+	if !health.Healthy || health.FailureStreak != 0 || health.Score <= 0 || health.Successes != 3 || health.Failures != 3 {
+		t.Errorf("unexpected health after recovering %+v", health)
+	}
+}
+
+// TestHeartbeatForgetsDepartedDelegates
+func TestHeartbeatForgetsDepartedDelegates(t *testing.T) {
+	service := newTestService(t, nil)
+	delegate := &types.Node{Address: "heartbeat-delegate", Type: types.TypeDelegate}
+	delegate.Cache(services.GetCache())
+	defer services.GetCache().Delete(delegate.Key())
+	defer services.GetCache().Delete(delegate.TypeKey())
+	service.RecordSuccess(delegate.Address, time.Millisecond)
+	service.RecordFailure("heartbeat-departed")
+
+	service.heartbeat()
+	if service.GetHealth(delegate.Address) == nil {
+		t.Error("health of a current delegate was forgotten")
+	}
+	if service.GetHealth("heartbeat-departed") != nil {
+		t.Error("health of a departed delegate was kept")
+	}
+}
@@ -0,0 +1,408 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// Kademlia parameters, k contacts per bucket and alpha parallel queries per lookup round
+const (
+	kademliaBucketSize                    = 20
+	kademliaAlpha                         = 3
+	kademliaMaxLatency                    = 2 * time.Second
+	kademliaTimeout                       = 5 * time.Second
+	kademliaRefreshInterval               = 10 * time.Minute
+	kademliaMaxAuthenticationAge    int64 = 30000 // ms
+	kademliaRandomLookupsPerRefresh       = 3
+)
+
+// PingGrpc - PROTO - Adds the authenticated sender to the routing table and answers with this node
+func (this *DisGoverService) PingGrpc(ctx context.Context, ping *proto.Ping) (*proto.Pong, error) {
+	node, authentication, err := authenticatePeer(ping.Authentication, ping.Node)
+	if err != nil {
+		return nil, err
+	}
+	this.addContact(node, authentication)
+
+	authentication, err = types.NewNodeAuthentication(this.ThisNode)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	return &proto.Pong{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode)}, nil
+}
+
+// FindNodeGrpc - PROTO - Returns the k contacts closest to the target, this node included when it is the target
+func (this *DisGoverService) FindNodeGrpc(ctx context.Context, findNode *proto.FindNode) (*proto.Nodes, error) {
+	node, authentication, err := authenticatePeer(findNode.Authentication, findNode.Node)
+	if err != nil {
+		return nil, err
+	}
+	this.addContact(node, authentication)
+
+	nodes := make([]*proto.Node, 0, kademliaBucketSize)
+	if findNode.Target == this.ThisNode.Address {
+		nodes = append(nodes, convertToProtoNode(this.ThisNode))
+	}
+	for _, contact := range this.nearestContacts(findNode.Target, kademliaBucketSize) {
+		if contact.Address != node.Address {
+			nodes = append(nodes, convertToProtoNode(contact))
+		}
+	}
+
+	authentication, err = types.NewNodeAuthentication(this.ThisNode)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	return &proto.Nodes{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode), Nodes: nodes}, nil
+}
+
+// FindNode - Returns the contact information of a node, looking it up in the network if it is not in the routing table, a node found
+// by a lookup is returned once it answers a ping as itself
+func (this *DisGoverService) FindNode(address string) (*types.Node, error) {
+	if address == this.ThisNode.Address {
+		return this.ThisNode, nil
+	}
+	if contact := this.getContact(address); contact != nil {
+		return contact, nil
+	}
+	for _, node := range this.lookup(address) {
+		if node.Address == address {
+			contact, err := this.peerPingGrpc(node)
+			if err != nil {
+				return nil, err
+			}
+			return contact, nil
+		}
+	}
+	return nil, types.ErrNotFound
+}
+
+// GetContacts - Returns the nodes in the routing table
+func (this *DisGoverService) GetContacts() []*types.Node {
+	this.contactsMutex.RLock()
+	defer this.contactsMutex.RUnlock()
+	nodes := make([]*types.Node, 0, len(this.contacts))
+	for _, contact := range this.contacts {
+		nodes = append(nodes, contact)
+	}
+	return nodes
+}
+
+// authenticatePeer - the sender of a request or response must sign its node's fields with the key of the address it claims
+func authenticatePeer(protoAuthentication *proto.Authentication, protoNode *proto.Node) (*types.Node, *types.Authentication, error) {
+	if protoAuthentication == nil || protoNode == nil {
+		return nil, nil, errors.New("unable to authenticate you")
+	}
+	node := convertToDomainNode(protoNode)
+	if node.GrpcEndpoint == nil {
+		return nil, nil, errors.New("missing grpc endpoint")
+	}
+	authentication := convertToDomainAuthentication(protoAuthentication)
+	err := authentication.VerifyNode(node, kademliaMaxAuthenticationAge)
+	if err != nil {
+		return nil, nil, errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
+	}
+	return node, authentication, nil
+}
+
+// addContact - Adds or refreshes a node in the routing table if the authentication vouches for its fields
+func (this *DisGoverService) addContact(node *types.Node, authentication *types.Authentication) error {
+	if node == nil || authentication == nil {
+		return errors.New("unable to authenticate contact")
+	}
+	err := authentication.VerifyNode(node, kademliaMaxAuthenticationAge)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("unable to authenticate contact [address=%s]", node.Address))
+	}
+	this.updateContact(node)
+	return nil
+}
+
+// updateContact - Adds or refreshes an authenticated node in the routing table
+func (this *DisGoverService) updateContact(node *types.Node) {
+	if node == nil || node.Address == "" || node.Address == this.ThisNode.Address || node.GrpcEndpoint == nil {
+		return
+	}
+	this.contactsMutex.Lock()
+	this.contacts[node.Address] = node
+	this.contactsMutex.Unlock()
+
+	id := peer.ID(node.Address)
+	this.kdht.Update(id)
+
+	// Too slow for the routing table?
+	if this.kdht.Find(id) == "" {
+		this.contactsMutex.Lock()
+		delete(this.contacts, node.Address)
+		this.contactsMutex.Unlock()
+	}
+}
+
+// removeContact
+func (this *DisGoverService) removeContact(address string) {
+	this.kdht.Remove(peer.ID(address))
+}
+
+// contactRemoved - Called by the routing table when a node is evicted from its bucket
+func (this *DisGoverService) contactRemoved(id peer.ID) {
+	this.contactsMutex.Lock()
+	defer this.contactsMutex.Unlock()
+	delete(this.contacts, string(id))
+}
+
+// getContact
+func (this *DisGoverService) getContact(address string) *types.Node {
+	this.contactsMutex.RLock()
+	defer this.contactsMutex.RUnlock()
+	return this.contacts[address]
+}
+
+// nearestContacts - Returns up to count contacts ordered by XOR distance to the target
+func (this *DisGoverService) nearestContacts(target string, count int) []*types.Node {
+	nodes := make([]*types.Node, 0, count)
+	for _, id := range this.kdht.NearestPeers(kbucket.ConvertPeerID(peer.ID(target)), count) {
+		if contact := this.getContact(string(id)); contact != nil {
+			nodes = append(nodes, contact)
+		}
+	}
+	return nodes
+}
+
+// lookup - Iterative Kademlia node lookup, queries alpha of the closest unqueried nodes per round until the k closest have answered
+func (this *DisGoverService) lookup(target string) []*types.Node {
+	return this.lookupWith(target, this.peerFindNodeGrpc)
+}
+
+// lookupWith - lookup asking each node with findNode, only the nodes that answered are returned
+func (this *DisGoverService) lookupWith(target string, findNode func(node *types.Node, target string) ([]*types.Node, error)) []*types.Node {
+	candidates := map[string]*types.Node{}
+	for _, contact := range this.nearestContacts(target, kademliaBucketSize) {
+		candidates[contact.Address] = contact
+	}
+	queried := map[string]bool{}
+	answered := map[string]bool{}
+	for {
+		closest := closestNodes(candidates, target, kademliaBucketSize)
+		round := make([]*types.Node, 0, kademliaAlpha)
+		for _, node := range closest {
+			if !queried[node.Address] {
+				round = append(round, node)
+				if len(round) == kademliaAlpha {
+					break
+				}
+			}
+		}
+		if len(round) == 0 {
+			break
+		}
+
+		results := make([][]*types.Node, len(round))
+		var waitGroup sync.WaitGroup
+		for i, node := range round {
+			queried[node.Address] = true
+			waitGroup.Add(1)
+			go func(i int, node *types.Node) {
+				defer waitGroup.Done()
+				nodes, err := findNode(node, target)
+				if err != nil {
+					utils.Debug(fmt.Sprintf("unable to find node [address=%s, host=%s, port=%d]", node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), err)
+					return
+				}
+				results[i] = nodes
+			}(i, node)
+		}
+		waitGroup.Wait()
+
+		for i, nodes := range results {
+			if nodes == nil {
+				delete(candidates, round[i].Address)
+				continue
+			}
+			answered[round[i].Address] = true
+			for _, node := range nodes {
+				if node.Address == this.ThisNode.Address || node.GrpcEndpoint == nil {
+					continue
+				}
+				if _, ok := candidates[node.Address]; !ok {
+					candidates[node.Address] = node
+				}
+			}
+		}
+	}
+
+	nodes := make([]*types.Node, 0, kademliaBucketSize)
+	for _, node := range closestNodes(candidates, target, len(candidates)) {
+		if answered[node.Address] {
+			nodes = append(nodes, node)
+		}
+		if len(nodes) == kademliaBucketSize {
+			break
+		}
+	}
+	return nodes
+}
+
+// closestNodes - Returns up to count nodes ordered by XOR distance to the target
+func closestNodes(nodes map[string]*types.Node, target string, count int) []*types.Node {
+	ids := make([]peer.ID, 0, len(nodes))
+	for address := range nodes {
+		ids = append(ids, peer.ID(address))
+	}
+	closest := make([]*types.Node, 0, count)
+	for _, id := range kbucket.SortClosestPeers(ids, kbucket.ConvertPeerID(peer.ID(target))) {
+		if len(closest) == count {
+			break
+		}
+		closest = append(closest, nodes[string(id)])
+	}
+	return closest
+}
+
+// bootstrap - Adds the seeds and known delegates to the routing table, then looks up this node to fill the buckets
+func (this *DisGoverService) bootstrap(nodes []*types.Node) {
+	for _, node := range nodes {
+		if node.Address == this.ThisNode.Address || node.GrpcEndpoint == nil {
+			continue
+		}
+		_, err := this.peerPingGrpc(node)
+		if err != nil {
+			utils.Debug(fmt.Sprintf("unable to ping node [address=%s, host=%s, port=%d]", node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), err)
+		}
+	}
+	nodes = this.lookup(this.ThisNode.Address)
+	utils.Info(fmt.Sprintf("bootstrapped routing table [contacts=%d, closest=%d]", this.kdht.Size(), len(nodes)))
+}
+
+// refreshWorker - Periodically refreshes the buckets of the routing table
+func (this *DisGoverService) refreshWorker() {
+	ticker := time.NewTicker(kademliaRefreshInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		this.refresh()
+	}
+}
+
+// refresh - Evicts contacts that no longer answer a ping, then looks up this node and random targets. Bucket ranges are in the hashed
+// keyspace so a target inside a given bucket cannot be chosen, random targets spread across the buckets instead.
+func (this *DisGoverService) refresh() {
+	for _, contact := range this.GetContacts() {
+		_, err := this.peerPingGrpc(contact)
+		if err != nil {
+			utils.Info(fmt.Sprintf("removed unresponsive node from routing table [address=%s]", contact.Address))
+			this.removeContact(contact.Address)
+		}
+	}
+	this.lookup(this.ThisNode.Address)
+	for i := 0; i < kademliaRandomLookupsPerRefresh; i++ {
+		target := make([]byte, 20)
+		_, err := rand.Read(target)
+		if err != nil {
+			utils.Error(err)
+			return
+		}
+		this.lookup(hex.EncodeToString(target))
+	}
+	utils.Debug(fmt.Sprintf("refreshed routing table [contacts=%d]", this.kdht.Size()))
+}
+
+// peerPingGrpc - Pings a node and adds it to the routing table if it answers as the address it was reached by
+func (this *DisGoverService) peerPingGrpc(node *types.Node) (*types.Node, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), services.GetGrpcDialOptions(node.Address)...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), kademliaTimeout)
+	defer cancel()
+
+	authentication, err := types.NewNodeAuthentication(this.ThisNode)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	response, err := proto.NewDisgoverGrpcClient(conn).PingGrpc(ctx, &proto.Ping{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode)})
+	if err != nil {
+		return nil, err
+	}
+	contact, authentication, err := authenticatePeer(response.Authentication, response.Node)
+	if err != nil {
+		return nil, err
+	}
+	if contact.Address != node.Address {
+		return nil, errors.New(fmt.Sprintf("node answered as %s", contact.Address))
+	}
+	this.metrics.RecordLatency(peer.ID(contact.Address), time.Since(start))
+	err = this.addContact(contact, authentication)
+	if err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+// peerFindNodeGrpc - Asks a node for the contacts it knows closest to the target, the node is added to the routing table if it answers
+func (this *DisGoverService) peerFindNodeGrpc(node *types.Node, target string) ([]*types.Node, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), services.GetGrpcDialOptions(node.Address)...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), kademliaTimeout)
+	defer cancel()
+
+	authentication, err := types.NewNodeAuthentication(this.ThisNode)
+	if err != nil {
+		return nil, err
+	}
+	response, err := proto.NewDisgoverGrpcClient(conn).FindNodeGrpc(ctx, &proto.FindNode{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode), Target: target})
+	if err != nil {
+		this.removeContact(node.Address)
+		return nil, err
+	}
+	contact, authentication, err := authenticatePeer(response.Authentication, response.Node)
+	if err != nil {
+		return nil, err
+	}
+	if contact.Address != node.Address {
+		return nil, errors.New(fmt.Sprintf("node answered as %s", contact.Address))
+	}
+	this.addContact(contact, authentication)
+
+	nodes := make([]*types.Node, 0, len(response.Nodes))
+	for _, protoNode := range response.Nodes {
+		if protoNode != nil {
+			nodes = append(nodes, convertToDomainNode(protoNode))
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,173 @@
+package disgover
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+// testPeer
+type testPeer struct {
+	node       *types.Node
+	privateKey string
+}
+
+// newTestPeers
+func newTestPeers(count int) []*testPeer {
+	peers := make([]*testPeer, 0, count)
+	for i := 0; i < count; i++ {
+		publicKey, privateKey := crypto.GenerateKeyPair()
+		node := &types.Node{Address: hex.EncodeToString(crypto.ToAddress(publicKey)), GrpcEndpoint: &types.Endpoint{Host: "127.0.0.1", Port: int64(2000 + i)}}
+		peers = append(peers, &testPeer{node: node, privateKey: hex.EncodeToString(privateKey)})
+	}
+	return peers
+}
+
+// authenticate
+func (this *testPeer) authenticate(t *testing.T) *types.Authentication {
+	authentication, err := types.NewNodeAuthenticationWithKey(this.privateKey, this.node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authentication
+}
+
+// newTestService
+func newTestService(t *testing.T, contacts []*testPeer) *DisGoverService {
+	service := newDisGoverService(newTestPeers(1)[0].node)
+	for _, contact := range contacts {
+		if err := service.addContact(contact.node, contact.authenticate(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return service
+}
+
+// TestAddContact
+func TestAddContact(t *testing.T) {
+	peers := newTestPeers(2)
+	service := newTestService(t, nil)
+
+	// Replayed with another endpoint.
+	authentication := peers[0].authenticate(t)
+	moved := *peers[0].node
+	moved.GrpcEndpoint = &types.Endpoint{Host: "10.0.0.1", Port: 1973}
+	if service.addContact(&moved, authentication) == nil || service.getContact(moved.Address) != nil {
+		t.Error("contact authenticated for another endpoint was added")
+	}
+	if service.addContact(peers[1].node, authentication) == nil || service.getContact(peers[1].node.Address) != nil {
+		t.Error("contact authenticated by another node was added")
+	}
+	if err := service.addContact(peers[0].node, authentication); err != nil {
+		t.Fatal(err)
+	}
+	if contact := service.getContact(peers[0].node.Address); contact == nil || contact.GrpcEndpoint.Host != "127.0.0.1" {
+		t.Error("authenticated contact was not added")
+	}
+	service.removeContact(peers[0].node.Address)
+	if service.getContact(peers[0].node.Address) != nil {
+		t.Error("removed contact is still known")
+	}
+}
+
+// TestRoutingTableBuckets - contacts evicted from a full bucket are forgotten and the nearest contacts are in XOR order
+func TestRoutingTableBuckets(t *testing.T) {
+	peers := newTestPeers(4 * kademliaBucketSize)
+	service := newTestService(t, peers)
+
+	contacts := service.GetContacts()
+	if len(contacts) != service.kdht.Size() {
+		t.Fatalf("%d contacts for %d nodes in the routing table", len(contacts), service.kdht.Size())
+	}
+	if len(contacts) == len(peers) {
+		t.Error("no contact was evicted from a full bucket")
+	}
+	for _, contact := range contacts {
+		if service.kdht.Find(peer.ID(contact.Address)) == "" {
+			t.Errorf("contact %s is not in the routing table", contact.Address)
+		}
+	}
+
+	known := map[string]*types.Node{}
+	for _, contact := range contacts {
+		known[contact.Address] = contact
+	}
+	target := peers[0].node.Address
+	nearest := service.nearestContacts(target, 5)
+	expected := closestNodes(known, target, 5)
+	if len(nearest) != len(expected) {
+		t.Fatalf("%d nearest contacts, expected %d", len(nearest), len(expected))
+	}
+	for i := range nearest {
+		if nearest[i].Address != expected[i].Address {
+			t.Errorf("nearest contact %d is %s, expected %s", i, nearest[i].Address, expected[i].Address)
+		}
+	}
+}
+
+// testNetwork - every node answers with the closest nodes it knows, down nodes do not answer
+type testNetwork struct {
+	nodes   map[string]*types.Node
+	down    map[string]bool
+	queried map[string]bool
+}
+
+// findNode
+func (this *testNetwork) findNode(node *types.Node, target string) ([]*types.Node, error) {
+	this.queried[node.Address] = true
+	if this.down[node.Address] {
+		return nil, errors.New("unavailable")
+	}
+	known := map[string]*types.Node{}
+	for address, other := range this.nodes {
+		if address != node.Address {
+			known[address] = other
+		}
+	}
+	return closestNodes(known, target, kademliaBucketSize), nil
+}
+
+// TestLookup
+func TestLookup(t *testing.T) {
+	peers := newTestPeers(100)
+	network := &testNetwork{nodes: map[string]*types.Node{}, down: map[string]bool{}, queried: map[string]bool{}}
+	for i, peer := range peers {
+		network.nodes[peer.node.Address] = peer.node
+		if i%10 == 9 {
+			network.down[peer.node.Address] = true
+		}
+	}
+	service := newTestService(t, peers[:3])
+
+	target := peers[50].node.Address
+	nodes := service.lookupWith(target, network.findNode)
+	if len(nodes) == 0 || nodes[0].Address != target {
+		t.Fatal("lookup did not find the target")
+	}
+	for i, node := range nodes {
+		if network.down[node.Address] || !network.queried[node.Address] {
+			t.Errorf("lookup returned %s which never answered", node.Address)
+		}
+		if i > 0 && !closer(target, nodes[i-1].Address, node.Address) {
+			t.Errorf("lookup result %d is not in XOR order", i)
+		}
+	}
+
+	// A target that is known to the network but never answers is not returned.
+	down := peers[59].node.Address
+	for _, node := range service.lookupWith(down, network.findNode) {
+		if node.Address == down {
+			t.Error("lookup returned a target that never answered")
+		}
+	}
+}
+
+// closer - whether a is at most as far from target as b
+func closer(target, a, b string) bool {
+	closest := closestNodes(map[string]*types.Node{a: {Address: a}, b: {Address: b}}, target, 1)
+	return closest[0].Address == a
+}
@@ -0,0 +1,220 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// Seeds publish the artifacts in releaseUpdateDirectory described by releaseFile ({"version": "1.2.0", "rollout": 25}). Raising
+// the rollout and saving releaseFile again pushes a new manifest for the same artifacts.
+const (
+	releaseUpdateDirectory = "update"
+	releaseFile            = "release.json"
+	releaseCheckInterval   = 30 * time.Second
+	releaseTimeout         = 5 * time.Minute
+	releaseMaxMessageSize  = 1024 * 1024 * 1024
+)
+
+// release - What a seed operator asks to publish
+type release struct {
+	Version string `json:"version"`
+	Rollout int64  `json:"rollout"`
+}
+
+// ReleaseGrpc - Stages a release signed by a seed when this node is in its rollout
+func (this *DisGoverService) ReleaseGrpc(ctx context.Context, protoRelease *proto.Release) (*proto.Empty, error) {
+
+	// Verify seed node is authentic?
+	err := this.verifySeedNode(protoRelease.Authentication)
+	if err != nil {
+		return &proto.Empty{}, err
+	}
+
+	manifest, artifacts := convertToDomainRelease(protoRelease)
+	err = manifest.Verify(getSeedAddresses())
+	if err != nil {
+		utils.Warn("rejected release manifest", err)
+		return &proto.Empty{}, err
+	}
+	if manifest.Version == types.GetVersion().Version || manifest.Version == types.GetStagedRelease() {
+		return &proto.Empty{}, nil
+	}
+	if types.IsFailedRelease(manifest.Version) {
+		return &proto.Empty{}, errors.Errorf("release %s was rolled back on this node", manifest.Version)
+	}
+	if !manifest.InRollout(this.ThisNode.Address) {
+		utils.Info(fmt.Sprintf("not in release rollout [version=%s, rollout=%d%%]", manifest.Version, manifest.Rollout))
+		return &proto.Empty{}, nil
+	}
+
+	directory, err := types.StageRelease(manifest, artifacts)
+	if err != nil {
+		utils.Error("unable to stage release", err)
+		return &proto.Empty{}, err
+	}
+	utils.Info(fmt.Sprintf("staged release [version=%s, directory=%s, artifacts=%d]", manifest.Version, directory, len(manifest.Artifacts)))
+	utils.Events().Raise(types.Events.ReleaseStaged)
+	return &proto.Empty{}, nil
+}
+
+// releaseWorker - Publishes the release in the update directory whenever its release file changes
+func (this *DisGoverService) releaseWorker() {
+	var published string
+	ticker := time.NewTicker(releaseCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		bytes, err := ioutil.ReadFile(filepath.Join(releaseUpdateDirectory, releaseFile))
+		if err != nil {
+			continue
+		}
+		hash := crypto.NewHash(bytes)
+		if string(hash[:]) == published {
+			continue
+		}
+		manifest, artifacts, err := newRelease(bytes)
+		if err != nil {
+			utils.Error("unable to publish release", err)
+			continue
+		}
+		published = string(hash[:])
+		utils.Info(fmt.Sprintf("publishing release [version=%s, rollout=%d%%, artifacts=%d]", manifest.Version, manifest.Rollout, len(manifest.Artifacts)))
+		this.peerReleaseGrpc(manifest, artifacts)
+	}
+}
+
+// newRelease - Signs a manifest for the artifacts in the update directory, nil while an artifact is still uploading
+func newRelease(bytes []byte) (*types.ReleaseManifest, map[string][]byte, error) {
+	release := &release{}
+	err := json.Unmarshal(bytes, release)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := ioutil.ReadDir(releaseUpdateDirectory)
+	if err != nil {
+		return nil, nil, err
+	}
+	artifacts := map[string][]byte{}
+	for _, file := range files {
+		if file.IsDir() || file.Name() == releaseFile {
+			continue
+		}
+		if strings.HasSuffix(file.Name(), ".LCK") {
+			return nil, nil, errors.Errorf("waiting for update file to upload [lockFile=%s]", file.Name())
+		}
+		data, err := ioutil.ReadFile(filepath.Join(releaseUpdateDirectory, file.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		artifacts[file.Name()] = data
+	}
+	manifest, err := types.NewReleaseManifest(types.GetKey(), types.GetAccount().Address, release.Version, release.Rollout, artifacts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, artifacts, nil
+}
+
+// peerReleaseGrpc - Sends the release to every delegate, each decides whether it is in the rollout
+func (this *DisGoverService) peerReleaseGrpc(manifest *types.ReleaseManifest, artifacts map[string][]byte) {
+	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	for _, delegate := range delegates {
+		if delegate.GrpcEndpoint == nil {
+			continue
+		}
+		err = this.releaseTo(delegate, manifest, artifacts)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to send release [address=%s, host=%s, port=%d]", delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
+		}
+	}
+}
+
+// releaseTo
+func (this *DisGoverService) releaseTo(delegate *types.Node, manifest *types.ReleaseManifest, artifacts map[string][]byte) error {
+	options := append(services.GetGrpcDialOptions(delegate.Address), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(releaseMaxMessageSize), grpc.MaxCallSendMsgSize(releaseMaxMessageSize)))
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := proto.NewDisgoverGrpcClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	// New authentication, per delegate as sending a release can take longer than an authentication is valid.
+	authentication, err := types.NewAuthentication()
+	if err != nil {
+		return err
+	}
+	_, err = client.ReleaseGrpc(ctx, convertToProtoRelease(manifest, artifacts, authentication))
+	return err
+}
+
+// convertToDomainRelease
+func convertToDomainRelease(protoRelease *proto.Release) (*types.ReleaseManifest, map[string][]byte) {
+	manifest := &types.ReleaseManifest{
+		Version:   protoRelease.Version,
+		Time:      protoRelease.Time,
+		Seed:      protoRelease.Seed,
+		Rollout:   protoRelease.Rollout,
+		Artifacts: make([]*types.ReleaseArtifact, 0, len(protoRelease.Artifacts)),
+		Hash:      protoRelease.Hash,
+		Signature: protoRelease.Signature,
+	}
+	artifacts := map[string][]byte{}
+	for _, artifact := range protoRelease.Artifacts {
+		manifest.Artifacts = append(manifest.Artifacts, &types.ReleaseArtifact{Name: artifact.Name, Hash: artifact.Hash, Size: artifact.Size})
+		artifacts[artifact.Name] = artifact.Data
+	}
+	return manifest, artifacts
+}
+
+// convertToProtoRelease
+func convertToProtoRelease(manifest *types.ReleaseManifest, artifacts map[string][]byte, authentication *types.Authentication) *proto.Release {
+	protoRelease := &proto.Release{
+		Authentication: convertToProtoAuthentication(authentication),
+		Version:        manifest.Version,
+		Time:           manifest.Time,
+		Seed:           manifest.Seed,
+		Rollout:        manifest.Rollout,
+		Artifacts:      make([]*proto.ReleaseArtifact, 0, len(manifest.Artifacts)),
+		Hash:           manifest.Hash,
+		Signature:      manifest.Signature,
+	}
+	for _, artifact := range manifest.Artifacts {
+		protoRelease.Artifacts = append(protoRelease.Artifacts, &proto.ReleaseArtifact{Name: artifact.Name, Hash: artifact.Hash, Size: artifact.Size, Data: artifacts[artifact.Name]})
+	}
+	return protoRelease
+}
@@ -0,0 +1,230 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Offenses lower a peer's score, a peer reaching reputationBanScore is refused for Config.PeerBanSeconds. Scores recover towards zero
+// by reputationRecoveryPerMinute, hosts back at zero and not banned are forgotten.
+const (
+	OffenseInvalidGossip = "InvalidGossip"
+	OffenseForgedRumor   = "ForgedRumor"
+	OffenseTimeout       = "Timeout"
+	OffenseContradiction = "Contradiction"
+
+	reputationBanScore          int64 = -100
+	reputationRecoveryPerMinute int64 = 1
+	reputationDefaultBanSeconds       = 600
+)
+
+var offensePenalties = map[string]int64{
+	OffenseInvalidGossip: 25,
+	OffenseForgedRumor:   50,
+	OffenseTimeout:       5,
+	OffenseContradiction: 20,
+}
+
+// Reputation - Standing of a peer host, addresses are the delegates known to use it
+type Reputation struct {
+	Host        string           `json:"host"`
+	Addresses   []string         `json:"addresses,omitempty"`
+	Score       int64            `json:"score"`
+	Offenses    map[string]int64 `json:"offenses"`
+	Bans        int64            `json:"bans"`
+	BannedUntil time.Time        `json:"bannedUntil,omitempty"`
+	Updated     time.Time        `json:"updated"`
+}
+
+// recover - caller holds the lock
+func (this *Reputation) recover(now time.Time) {
+	minutes := int64(now.Sub(this.Updated) / time.Minute)
+	if minutes <= 0 {
+		return
+	}
+	this.Score += minutes * reputationRecoveryPerMinute
+	if this.Score > 0 {
+		this.Score = 0
+	}
+	this.Updated = this.Updated.Add(time.Duration(minutes) * time.Minute)
+}
+
+// isBanned
+func (this *Reputation) isBanned(now time.Time) bool {
+	return now.Before(this.BannedUntil)
+}
+
+// Penalize - Lowers the score of the peer host for an offense, banning it when the score is too low
+func (this *DisGoverService) Penalize(host string, address string, offense string) {
+	if host == "" {
+		return
+	}
+	this.reputationsMutex.Lock()
+	defer this.reputationsMutex.Unlock()
+	now := time.Now()
+	reputation, ok := this.reputations[host]
+	if !ok {
+		reputation = &Reputation{Host: host, Offenses: map[string]int64{}, Updated: now}
+		this.reputations[host] = reputation
+	}
+	reputation.recover(now)
+	if address != "" && address != host {
+		known := false
+		for _, existing := range reputation.Addresses {
+			known = known || existing == address
+		}
+		if !known {
+			reputation.Addresses = append(reputation.Addresses, address)
+		}
+	}
+	reputation.Offenses[offense]++
+	reputation.Score -= offensePenalties[offense]
+	if reputation.Score <= reputationBanScore && !reputation.isBanned(now) {
+		reputation.Bans++
+		reputation.BannedUntil = now.Add(getBanDuration())
+		reputation.Score = 0
+		utils.Warn(fmt.Sprintf("banned peer [host=%s, addresses=%v, until=%s]", host, reputation.Addresses, reputation.BannedUntil.Format(time.RFC3339)))
+	}
+}
+
+// PenalizePeer - Penalizes the host a gRPC call came from
+func (this *DisGoverService) PenalizePeer(ctx context.Context, offense string) {
+	this.Penalize(getPeerHost(ctx), "", offense)
+}
+
+// PenalizeAddress - Penalizes the host of a known node
+func (this *DisGoverService) PenalizeAddress(address string, offense string) {
+	this.Penalize(this.getHost(address), address, offense)
+}
+
+// IsBanned - Whether the host of a known node is banned
+func (this *DisGoverService) IsBanned(address string) bool {
+	return this.isHostBanned(this.getHost(address))
+}
+
+// isHostBanned
+func (this *DisGoverService) isHostBanned(host string) bool {
+	this.reputationsMutex.RLock()
+	defer this.reputationsMutex.RUnlock()
+	reputation, ok := this.reputations[host]
+	return ok && reputation.isBanned(time.Now())
+}
+
+// GetReputations - The score table, lowest score first
+func (this *DisGoverService) GetReputations() []*Reputation {
+	this.reputationsMutex.Lock()
+	defer this.reputationsMutex.Unlock()
+	now := time.Now()
+	this.forgetRecovered(now)
+	reputations := make([]*Reputation, 0, len(this.reputations))
+	for _, reputation := range this.reputations {
+		result := *reputation
+		result.Addresses = append([]string{}, reputation.Addresses...)
+		result.Offenses = make(map[string]int64, len(reputation.Offenses))
+		for offense, count := range reputation.Offenses {
+			result.Offenses[offense] = count
+		}
+		reputations = append(reputations, &result)
+	}
+	sort.Slice(reputations, func(i, j int) bool {
+		if reputations[i].Score == reputations[j].Score {
+			return reputations[i].Host < reputations[j].Host
+		}
+		return reputations[i].Score < reputations[j].Score
+	})
+	return reputations
+}
+
+// pruneReputations - Forgets the hosts whose score recovered to zero and that are not banned
+func (this *DisGoverService) pruneReputations() {
+	this.reputationsMutex.Lock()
+	defer this.reputationsMutex.Unlock()
+	this.forgetRecovered(time.Now())
+}
+
+// forgetRecovered - caller holds the lock
+func (this *DisGoverService) forgetRecovered(now time.Time) {
+	for host, reputation := range this.reputations {
+		reputation.recover(now)
+		if reputation.Score == 0 && !reputation.isBanned(now) {
+			delete(this.reputations, host)
+		}
+	}
+}
+
+// reputationUnaryInterceptor - Refuses calls from banned hosts
+func (this *DisGoverService) reputationUnaryInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if host := getPeerHost(ctx); this.isHostBanned(host) {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("peer %s is banned", host))
+	}
+	return handler(ctx, request)
+}
+
+// reputationStreamInterceptor - Refuses streams from banned hosts
+func (this *DisGoverService) reputationStreamInterceptor(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if host := getPeerHost(stream.Context()); this.isHostBanned(host) {
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("peer %s is banned", host))
+	}
+	return handler(server, stream)
+}
+
+// getHost - The gRPC host of a known node, the address itself when unknown
+func (this *DisGoverService) getHost(address string) string {
+	if contact := this.getContact(address); contact != nil && contact.GrpcEndpoint != nil {
+		return contact.GrpcEndpoint.Host
+	}
+	node, err := types.ToNodeFromCache(services.GetCache(), address)
+	if err == nil && node.GrpcEndpoint != nil {
+		return node.GrpcEndpoint.Host
+	}
+	return address
+}
+
+// getPeerHost - The host a gRPC call came from
+func getPeerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// getBanDuration
+func getBanDuration() time.Duration {
+	seconds := types.GetConfig().PeerBanSeconds
+	if seconds <= 0 {
+		seconds = reputationDefaultBanSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
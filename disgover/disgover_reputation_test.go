@@ -0,0 +1,89 @@
+package disgover
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// TestPenalize - penalties add up until the host is banned, the ban resets the score
+func TestPenalize(t *testing.T) {
+	service := newTestService(t, nil)
+	service.Penalize("10.0.0.1", "delegate", OffenseInvalidGossip)
+	service.Penalize("10.0.0.1", "delegate", OffenseTimeout)
+	reputations := service.GetReputations()
+	if len(reputations) != 1 || reputations[0].Score != -30 || reputations[0].Offenses[OffenseInvalidGossip] != 1 || reputations[0].Offenses[OffenseTimeout] != 1 {
+		t.Fatalf("unexpected reputations %+v", reputations)
+	}
+	if len(reputations[0].Addresses) != 1 || reputations[0].Addresses[0] != "delegate" {
+		t.Errorf("addresses are %v", reputations[0].Addresses)
+	}
+	if service.isHostBanned("10.0.0.1") {
+		t.Fatal("host banned above the ban score")
+	}
+
+	for score := int64(-30); score > reputationBanScore; score -= offensePenalties[OffenseForgedRumor] {
+		service.Penalize("10.0.0.1", "delegate", OffenseForgedRumor)
+	}
+	if !service.isHostBanned("10.0.0.1") || !service.IsBanned("10.0.0.1") {
+		t.Fatal("host was not banned at the ban score")
+	}
+	reputations = service.GetReputations()
+	if reputations[0].Bans != 1 || reputations[0].Score != 0 || !reputations[0].BannedUntil.After(time.Now()) {
+		t.Errorf("unexpected banned reputation %+v", reputations[0])
+	}
+	if service.isHostBanned("10.0.0.2") {
+		t.Error("another host is banned")
+	}
+}
+
+// TestReputationRecovery - scores recover every minute, hosts back at zero are forgotten unless banned
+func TestReputationRecovery(t *testing.T) {
+	service := newTestService(t, nil)
+	now := time.Now()
+	service.reputations["recovering"] = &Reputation{Host: "recovering", Score: -50, Offenses: map[string]int64{}, Updated: now.Add(-30 * time.Minute)}
+	service.reputations["recovered"] = &Reputation{Host: "recovered", Score: -5, Offenses: map[string]int64{}, Updated: now.Add(-10 * time.Minute)}
+	service.reputations["banned"] = &Reputation{Host: "banned", Offenses: map[string]int64{}, Bans: 1, BannedUntil: now.Add(time.Minute), Updated: now}
+
+	reputations := service.GetReputations()
+	if len(reputations) != 2 || reputations[0].Host != "recovering" || reputations[0].Score != -20 || reputations[1].Host != "banned" {
+		t.Fatalf("unexpected reputations %+v", reputations)
+	}
+
+	service.reputations["banned"].BannedUntil = now.Add(-time.Second)
+	service.reputations["recovering"].Updated = now.Add(-time.Hour)
+	service.pruneReputations()
+	if len(service.reputations) != 0 {
+		t.Errorf("%d recovered reputations were kept", len(service.reputations))
+	}
+}
+
+// TestReputationUnaryInterceptor - calls from a banned host are refused before the handler
+func TestReputationUnaryInterceptor(t *testing.T) {
+	service := newTestService(t, nil)
+	service.reputations["10.0.0.1"] = &Reputation{Host: "10.0.0.1", Offenses: map[string]int64{}, BannedUntil: time.Now().Add(time.Minute), Updated: time.Now()}
+	tests := []struct {
+		host string
+		code codes.Code
+	}{
+		{"10.0.0.1", codes.PermissionDenied},
+		{"10.0.0.2", codes.OK},
+	}
+	for _, test := range tests {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(test.host), Port: 1973}})
+		called := false
+		_, err := service.reputationUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, request interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		if status.Code(err) != test.code || called != (test.code == codes.OK) {
+			t.Errorf("%s: returned %v, handler called %t", test.host, err, called)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Seeds are retried with exponential backoff, a node that knows delegates from a previous run starts without them.
+const (
+	seedAttempts       = 5
+	seedInitialBackoff = time.Second
+	seedMaxBackoff     = 30 * time.Second
+	seedRetryInterval  = time.Minute
+)
+
+// pingSeedsWithBackoff - Tries every seed, backing off between rounds
+func (this *DisGoverService) pingSeedsWithBackoff(attempts int) ([]*types.Node, error) {
+	backoff := seedInitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var delegates []*types.Node
+		delegates, err = this.peerPingSeedGrpc()
+		if err == nil {
+			return delegates, nil
+		}
+		if attempt >= attempts {
+			break
+		}
+		utils.Warn(fmt.Sprintf("unable to ping any seed node, retrying in %s [attempt=%d/%d]", backoff, attempt, attempts))
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > seedMaxBackoff {
+			backoff = seedMaxBackoff
+		}
+	}
+	return nil, err
+}
+
+// connectSeeds - The delegates from a seed, persisted for the next start, or those known from the last run when no seed
+// answers. fromSeed is false in the latter case.
+func (this *DisGoverService) connectSeeds(attempts int) ([]*types.Node, bool, error) {
+	delegates, err := this.pingSeedsWithBackoff(attempts)
+	if err == nil {
+		err = persistDelegates(delegates)
+		if err != nil {
+			utils.Error("unable to persist delegates", err)
+		}
+		return delegates, true, nil
+	}
+	utils.Error(err)
+	delegates, err = loadDelegates()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(delegates) == 0 {
+		return nil, false, errors.New("no delegates are known from a previous run")
+	}
+	return delegates, false, nil
+}
+
+// seedWorker - Keeps pinging the seeds after a seed-less start until one answers
+func (this *DisGoverService) seedWorker() {
+	for {
+		time.Sleep(seedRetryInterval)
+		delegates, err := this.pingSeedsWithBackoff(seedAttempts)
+		if err != nil {
+			continue
+		}
+		this.setDelegates(delegates)
+		err = persistDelegates(delegates)
+		if err != nil {
+			utils.Error("unable to persist delegates", err)
+		}
+		utils.Info(fmt.Sprintf("reconnected to seed node [delegates=%d]", len(delegates)))
+		return
+	}
+}
+
+// setDelegates - Caches the delegates and takes this node's type from them
+func (this *DisGoverService) setDelegates(delegates []*types.Node) {
+	forgetDepartedDelegates(delegates)
+	for _, delegate := range delegates {
+		delegate.Cache(services.GetCache())
+		if delegate.Address == this.ThisNode.Address {
+			this.ThisNode.Type = delegate.Type
+		}
+	}
+}
+
+// forgetDepartedDelegates - Uncaches the delegates that are not in the new list and closes their connections
+func forgetDepartedDelegates(delegates []*types.Node) {
+	cached, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	current := map[string]bool{}
+	for _, delegate := range delegates {
+		current[delegate.Address] = true
+	}
+	for _, delegate := range cached {
+		if current[delegate.Address] {
+			continue
+		}
+		utils.Info(fmt.Sprintf("delegate left [address=%s]", delegate.Address))
+		services.GetCache().Delete(delegate.TypeKey())
+		services.CloseGrpcConnection(delegate.Address)
+	}
+}
+
+// persistDelegates - Replaces the persisted delegates with the given list
+func persistDelegates(delegates []*types.Node) error {
+	return services.GetDb().Update(func(txn *badger.Txn) error {
+		persisted, err := types.ToNodesByType(txn, types.TypeDelegate)
+		if err != nil {
+			return err
+		}
+		current := map[string]bool{}
+		for _, delegate := range delegates {
+			current[delegate.Address] = true
+		}
+		for _, delegate := range persisted {
+			if current[delegate.Address] {
+				continue
+			}
+			err = txn.Delete([]byte(delegate.TypeKey()))
+			if err != nil {
+				return err
+			}
+			err = txn.Delete([]byte(delegate.Key()))
+			if err != nil {
+				return err
+			}
+		}
+		for _, delegate := range delegates {
+			err = delegate.Persist(txn)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// loadDelegates - The delegates persisted by the last successful seed ping or update
+func loadDelegates() ([]*types.Node, error) {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	return types.ToNodesByType(txn, types.TypeDelegate)
+}
@@ -0,0 +1,138 @@
+package disgover
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// setTestSeeds - seeds answer with their delegates or fail when they have none, returns the addresses pinged so far
+// and a function restoring the configured seeds
+func setTestSeeds(seeds []*types.Node, delegates map[string][]*types.Node) (*[]string, func()) {
+	previousSeeds := types.GetConfig().Seeds
+	previousPing := pingSeedNode
+	pinged := make([]string, 0)
+	types.GetConfig().Seeds = seeds
+	pingSeedNode = func(this *DisGoverService, seed *types.Node) ([]*types.Node, error) {
+		pinged = append(pinged, seed.Address)
+		if answer, ok := delegates[seed.Address]; ok {
+			return answer, nil
+		}
+		return nil, fmt.Errorf("seed %s is down", seed.Address)
+	}
+	return &pinged, func() {
+		types.GetConfig().Seeds = previousSeeds
+		pingSeedNode = previousPing
+	}
+}
+
+// newTestSeed
+func newTestSeed(address string) *types.Node {
+	return &types.Node{Address: address, GrpcEndpoint: &types.Endpoint{Host: "127.0.0.1", Port: 1973}, Type: types.TypeSeed}
+}
+
+// newTestDelegates
+func newTestDelegates(addresses ...string) []*types.Node {
+	delegates := make([]*types.Node, 0, len(addresses))
+	for _, address := range addresses {
+		delegates = append(delegates, &types.Node{Address: address, GrpcEndpoint: &types.Endpoint{Host: "127.0.0.1", Port: 1973}, Type: types.TypeDelegate})
+	}
+	return delegates
+}
+
+// getDelegateAddresses - sorted
+func getDelegateAddresses(delegates []*types.Node) []string {
+	addresses := make([]string, 0, len(delegates))
+	for _, delegate := range delegates {
+		addresses = append(addresses, delegate.Address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// TestPingSeedsWithBackoff - a seed that is down fails over to the next, every seed is retried each round
+func TestPingSeedsWithBackoff(t *testing.T) {
+	service := newTestService(t, nil)
+	seeds := []*types.Node{newTestSeed("seed-a"), {Address: "seed-b"}, newTestSeed("seed-c")}
+	pinged, restore := setTestSeeds(seeds, map[string][]*types.Node{"seed-c": newTestDelegates("delegate-1")})
+	defer restore()
+
+	delegates, err := service.pingSeedsWithBackoff(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(getDelegateAddresses(delegates), []string{"delegate-1"}) {
+		t.Errorf("returned delegates %v", getDelegateAddresses(delegates))
+	}
+	if !reflect.DeepEqual(*pinged, []string{"seed-a", "seed-c"}) {
+		t.Errorf("pinged %v, the seed without an endpoint should be skipped", *pinged)
+	}
+
+	pinged, restore = setTestSeeds(seeds, nil)
+	defer restore()
+	if _, err = service.pingSeedsWithBackoff(2); err == nil {
+		t.Error("no seed answered but no error was returned")
+	}
+	if !reflect.DeepEqual(*pinged, []string{"seed-a", "seed-c", "seed-a", "seed-c"}) {
+		t.Errorf("pinged %v over two rounds", *pinged)
+	}
+}
+
+// TestPersistDelegates - persisting a delegate list drops the delegates that left
+func TestPersistDelegates(t *testing.T) {
+	defer persistDelegates(nil)
+	if err := persistDelegates(newTestDelegates("delegate-1", "delegate-2", "delegate-3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := persistDelegates(newTestDelegates("delegate-2", "delegate-3", "delegate-4")); err != nil {
+		t.Fatal(err)
+	}
+	delegates, err := loadDelegates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(getDelegateAddresses(delegates), []string{"delegate-2", "delegate-3", "delegate-4"}) {
+		t.Errorf("loaded delegates %v", getDelegateAddresses(delegates))
+	}
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	if _, err = types.ToNodeByAddress(txn, "delegate-1"); err == nil {
+		t.Error("departed delegate is still persisted")
+	}
+}
+
+// TestConnectSeeds - a restart with no seed answering starts from the delegates persisted by the last run
+func TestConnectSeeds(t *testing.T) {
+	defer persistDelegates(nil)
+	service := newTestService(t, nil)
+	seeds := []*types.Node{newTestSeed("seed-a")}
+	_, restore := setTestSeeds(seeds, map[string][]*types.Node{"seed-a": newTestDelegates("delegate-1", "delegate-2")})
+	delegates, fromSeed, err := service.connectSeeds(1)
+	restore()
+	if err != nil || !fromSeed || len(delegates) != 2 {
+		t.Fatalf("connecting to an answering seed returned %d delegates, fromSeed=%t: %v", len(delegates), fromSeed, err)
+	}
+
+	// Restart, no seed answers.
+	_, restore = setTestSeeds(seeds, nil)
+	defer restore()
+	delegates, fromSeed, err = service.connectSeeds(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromSeed || !reflect.DeepEqual(getDelegateAddresses(delegates), []string{"delegate-1", "delegate-2"}) {
+		t.Errorf("restart returned delegates %v, fromSeed=%t", getDelegateAddresses(delegates), fromSeed)
+	}
+
+	if err = persistDelegates(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = service.connectSeeds(1); err == nil {
+		t.Error("a restart with no seed and no known delegates did not fail")
+	}
+}
+
@@ -27,10 +27,6 @@ import (
 	"github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p-peer"
 	"github.com/libp2p/go-libp2p-peerstore"
-	"time"
-	"os"
-	"io/ioutil"
-	"strings"
 )
 
 var disGoverServiceInstance *DisGoverService
@@ -39,33 +35,50 @@ var disGoverServiceOnce sync.Once
 // GetDisGoverService
 func GetDisGoverService() *DisGoverService {
 	disGoverServiceOnce.Do(func() {
-		disGoverServiceInstance = &DisGoverService{
-			ThisNode: &types.Node{
-				Address:      types.GetAccount().Address,
-				GrpcEndpoint: types.GetConfig().GrpcEndpoint,
-				HttpEndpoint: types.GetConfig().HttpEndpoint,
-				Type:         types.TypeNode,
-				Version:      types.GetVersion(),
-			},
-			// lruCache: lCache,
-			kdht: kbucket.NewRoutingTable(
-				1000,
-				kbucket.ConvertPeerID(peer.ID(types.GetAccount().Address)),
-				1000,
-				peerstore.NewMetrics(),
-			),
-			running: false,
-		}
+		disGoverServiceInstance = newDisGoverService(&types.Node{
+			Address:         types.GetAccount().Address,
+			GrpcEndpoint:    types.GetConfig().GrpcEndpoint,
+			HttpEndpoint:    types.GetConfig().HttpEndpoint,
+			Type:            types.TypeNode,
+			Version:         types.GetVersion(),
+			ProtocolVersion: types.ProtocolVersion,
+			Capabilities:    types.GetCapabilities(),
+		})
 	})
 	utils.Debug(fmt.Sprintf("This node: Address: %s, Version: %s", disGoverServiceInstance.ThisNode.Address, disGoverServiceInstance.ThisNode.Version))
 	return disGoverServiceInstance
 }
 
+// newDisGoverService
+func newDisGoverService(thisNode *types.Node) *DisGoverService {
+	metrics := peerstore.NewMetrics()
+	service := &DisGoverService{
+		ThisNode:    thisNode,
+		metrics:     metrics,
+		contacts:    map[string]*types.Node{},
+		health:      map[string]*NodeHealth{},
+		reputations: map[string]*Reputation{},
+		running:     false,
+	}
+	service.kdht = kbucket.NewRoutingTable(kademliaBucketSize, kbucket.ConvertPeerID(peer.ID(thisNode.Address)), kademliaMaxLatency, metrics)
+	service.kdht.PeerRemoved = service.contactRemoved
+	return service
+}
+
 // DisGoverService
 type DisGoverService struct {
-	ThisNode *types.Node
-	kdht     *kbucket.RoutingTable
-	running  bool
+	ThisNode         *types.Node
+	kdht             *kbucket.RoutingTable
+	metrics          peerstore.Metrics
+	contacts         map[string]*types.Node // Contact information of the nodes in kdht
+	contactsMutex    sync.RWMutex
+	health           map[string]*NodeHealth
+	healthMutex      sync.RWMutex
+	reputations      map[string]*Reputation // By host
+	reputationsMutex sync.RWMutex
+	delegateSet      *types.DelegateSet // Latest accepted or, on a seed, signed
+	delegateSetMutex sync.RWMutex
+	running          bool
 }
 
 // IsRunning - Returns the status if service is running
@@ -88,83 +101,30 @@ func (this *DisGoverService) Go() {
 		this.ThisNode.Type = types.TypeSeed
 	}
 
+	this.loadDelegateSet()
+
 	// Cache delegates?
 	if this.ThisNode.Type != types.TypeSeed {
-		delegates, err := this.peerPingSeedGrpc()
-		fmt.Println("list of delegates returned by seed is len:", len(delegates))
-
+		delegates, fromSeed, err := this.connectSeeds(seedAttempts)
 		if err != nil {
-			utils.Error(err)
 			services.GetDbService().Close()
-			seeds := types.GetConfig().Seeds
-			utils.Fatal(fmt.Sprintf("unable to connect to seed node (%s:%d)...please try again later", seeds[0].GrpcEndpoint.Host, seeds[0].GrpcEndpoint.Port))
+			utils.Fatal("unable to connect to any seed node and no delegates are known from a previous run...please try again later")
 		}
-		for _, delegate := range delegates {
-			delegate.Cache(services.GetCache())
-			if delegate.Address == this.ThisNode.Address {
-				this.ThisNode.Type = delegate.Type
-			}
+		if !fromSeed {
+			utils.Warn(fmt.Sprintf("no seed node answered, starting from the last known delegates [count=%d]", len(delegates)))
+			go this.seedWorker()
 		}
+		this.setDelegates(delegates)
+		this.bootstrap(append(append([]*types.Node{}, types.GetConfig().Seeds...), delegates...))
 	}
+	go this.refreshWorker()
+	go this.heartbeatWorker()
 
-	// Start update thread.
+	// Publish releases.
 	if this.ThisNode.Type == types.TypeSeed {
-		//go this.updateWorker()
+		go this.releaseWorker()
 	}
 
 	utils.Info(fmt.Sprintf("running as %s", this.ThisNode.Type))
 	utils.Events().Raise(types.Events.DisGoverServiceInitFinished)
 }
-
-// updateWorker
-func (this DisGoverService) updateWorker() {
-	for {
-		timer := time.NewTimer(30 * time.Second)
-		select {
-		case <-timer.C:
-
-			// Any files to update?
-			updateDirectory := "." + string(os.PathSeparator) + "update"
-			files, err := ioutil.ReadDir(updateDirectory)
-			if err != nil {
-				continue
-			}
-
-			// Has lock file?
-			if hasLockFile(files) {
-				continue
-			}
-
-			for _, file := range files {
-				// Read file?
-				fileName := updateDirectory + string(os.PathSeparator) + file.Name()
-				bytes, err := ioutil.ReadFile(fileName)
-				if err != nil {
-					utils.Error(fmt.Sprintf("unable to read file %s", file.Name()), err)
-					continue
-				}
-				utils.Info(fmt.Sprintf("found software to update [file=%s]", fileName))
-
-				// Update software.
-				this.peerUpdateSoftwareGrpc(file.Name(), bytes)
-
-				// Delete file.
-				err = os.Remove(fileName)
-				if err != nil {
-					utils.Warn(fmt.Sprintf("unable to delete file %s", fileName), err)
-				}
-			}
-		}
-	}
-}
-
-// hasLockFile
-func hasLockFile(files []os.FileInfo) bool {
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".LCK") {
-			utils.Info(fmt.Sprintf("waiting for update file to upload [lockFile=%s]", file.Name()))
-			return true
-		}
-	}
-	return false;
-}
@@ -0,0 +1,29 @@
+package disgover
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+)
+
+// TestMain - the DB and config are created in a temporary working directory
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "disgo-disgover")
+	if err != nil {
+		panic(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	services.GetDb().Close()
+	os.Chdir(wd)
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -18,16 +18,8 @@ package disgover
 
 import (
 	"fmt"
-
 	"time"
 
-	"bytes"
-	"encoding/hex"
-	"io/ioutil"
-	"os"
-	"os/exec"
-
-	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/services"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
@@ -43,6 +35,9 @@ import (
 // WithGrpc - Runs the DisGover service with GRPC transport
 func (this *DisGoverService) WithGrpc() *DisGoverService {
 	proto.RegisterDisgoverGrpcServer(services.GetGrpcService().Server, this)
+	services.GetGrpcService().AddUnaryInterceptor(this.reputationUnaryInterceptor)
+	services.GetGrpcService().AddStreamInterceptor(this.reputationStreamInterceptor)
+	services.GetGrpcService().RestrictMethods([]string{types.TypeSeed}, "/disgover.DisgoverGrpc/UpdateGrpc", "/disgover.DisgoverGrpc/ReleaseGrpc")
 	return this
 }
 
@@ -54,41 +49,42 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 		return nil, errors.New("you pinged a non-seed node")
 	}
 
+	if pingSeed.Node == nil || pingSeed.Node.GrpcEndpoint == nil || pingSeed.Authentication == nil {
+		return nil, errors.New("invalid ping")
+	}
 	node := convertToDomainNode(pingSeed.Node)
 	authentication := convertToDomainAuthentication(pingSeed.Authentication)
-	authenticationAddress, err := authentication.GetDerivedAddress()
+	err := authentication.VerifyNode(node, kademliaMaxAuthenticationAge)
 	if err != nil {
 		return nil, errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
 	}
-	if authenticationAddress != node.Address {
-		return nil, errors.New("unable to authenticate you")
-	}
 
 	// Persist and cache node.
 	txn := services.NewTxn(true)
 	defer txn.Discard()
 
-	// If delegate addresses is not set all nodes other than seed become a delegate (making it easy for testing and production).
-	if len(types.GetConfig().DelegateAddresses) == 0 {
+	// Nodes below the minimum protocol version may run but are never delegates.
+	if !node.IsCompatible() {
+		utils.Warn(fmt.Sprintf("demoted node below the minimum protocol version [address=%s, protocolVersion=%d, minProtocolVersion=%d]", node.Address, node.ProtocolVersion, types.GetMinProtocolVersion()))
+		node.Type = types.TypeNode
+		delegate := types.Node{Address: node.Address, Type: types.TypeDelegate}
+		services.GetCache().Delete(delegate.TypeKey())
+		txn.Delete([]byte(delegate.TypeKey()))
+	} else if len(types.GetConfig().DelegateAddresses) == 0 {
+		// If delegate addresses is not set all nodes other than seed become a delegate (making it easy for testing and production).
 		node.Type = types.TypeDelegate
 	} else {
 		for _, delegateAddress := range types.GetConfig().DelegateAddresses {
 
-			// Is this a delegate node?
+			// Is this a delegate node? The authentication was verified above.
 			if delegateAddress == node.Address {
-
-				// Is this an authentic delegate?
-				err := authentication.Verify(services.GetCache(), node.Address)
-				if err != nil {
-					utils.Warn(fmt.Sprintf("unable to authenticate delegate [address=%s]", node.Address))
-					return nil, errors.New("unable to authenticate you as a delegate")
-				}
 				node.Type = types.TypeDelegate
 				break
 			}
 		}
 	}
 	node.Set(txn, services.GetCache())
+	this.addContact(node, authentication)
 
 	// Get cached delegates.
 	cDelegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
@@ -112,17 +108,21 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 	keys := make(map[string]bool)
 	delegates := []*types.Node{}
 	for _, node := range sDelegates {
+		if !node.IsCompatible() {
+			continue
+		}
 		if _, value := keys[node.Address]; !value {
 			keys[node.Address] = true
 			delegates = append(delegates, node)
 		}
 	}
 
-	var nodes = make([]*proto.Node, 0)
-	for _, delegate := range delegates {
-		nodes = append(nodes, convertToProtoNode(delegate))
+	delegateSet, err := this.signDelegateSet(delegates)
+	if err != nil {
+		utils.Error("unable to sign delegate set", err)
+		return nil, err
 	}
-	utils.Info(fmt.Sprintf("received ping [address=%s, host=%s, port=%d, delegates=%d]", node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port, len(delegates)))
+	utils.Info(fmt.Sprintf("received ping [address=%s, host=%s, port=%d, delegates=%d, epoch=%d]", node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port, len(delegates), delegateSet.Epoch))
 
 	// New authentication.
 	authentication, err = types.NewAuthentication()
@@ -137,238 +137,130 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 		this.peerUpdateGrpc()
 	}()
 
-	return &proto.Update{Authentication: convertToProtoAuthentication(authentication), Delegates: nodes}, nil
+	return convertToProtoUpdate(delegateSet, authentication), nil
 }
 
-// peerPingSeedGrpc //caller
+// peerPingSeedGrpc - Pings each seed until one answers with the delegates
 func (this *DisGoverService) peerPingSeedGrpc() ([]*types.Node, error) {
-
-	var delegates = make([]*types.Node, 0)
-	for _, seedEndpoint := range types.GetConfig().Seeds {
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", seedEndpoint.GrpcEndpoint.Host, seedEndpoint.GrpcEndpoint.Port), grpc.WithInsecure())
-		if err != nil {
-			utils.Fatal(fmt.Sprintf("cannot dial seed [host=%s, port=%d]", seedEndpoint.GrpcEndpoint.Host, seedEndpoint.GrpcEndpoint.Port), err)
-			return nil, err
-		}
-		client := proto.NewDisgoverGrpcClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-
-		// New authentication.
-		authentication, err := types.NewAuthentication()
-		if err != nil {
-			return nil, err
-		}
-
-		// Ping seed.
-		protoNode := convertToProtoNode(this.ThisNode)
-		response, err := client.PingSeedGrpc(ctx, &proto.PingSeed{Authentication: convertToProtoAuthentication(authentication), Node: protoNode})
-		if err != nil {
-			conn.Close()
-			cancel()
-			utils.Error(err)
-			return nil, err
-		}
-		conn.Close()
-		cancel()
-
-		// Response?
-		if response == nil {
-			utils.Error("unable to ping seed node")
-			return nil, errors.New("unable to ping seed node")
+	var lastErr = errors.New("no seed nodes configured")
+	for _, seed := range types.GetConfig().Seeds {
+		if seed.GrpcEndpoint == nil {
+			continue
 		}
-
-		// Verify seed node is authentic?
-		err = this.verifySeedNode(response.Authentication)
+		delegates, err := pingSeedNode(this, seed)
 		if err != nil {
-			return nil, err
-		}
-
-		utils.Info(fmt.Sprintf("pinged seed node [delegates=%d]", len(response.Delegates)))
-
-		for _, delegate := range response.Delegates {
-			delegates = append(delegates, convertToDomainNode(delegate))
+			utils.Warn(fmt.Sprintf("unable to ping seed [address=%s, host=%s, port=%d]", seed.Address, seed.GrpcEndpoint.Host, seed.GrpcEndpoint.Port), err)
+			lastErr = err
+			continue
 		}
 		return delegates, nil
 	}
-
-	return nil, errors.New("unable to ping any seed delegates")
-}
-
-// UpdateGrpc
-func (this *DisGoverService) UpdateGrpc(ctx context.Context, update *proto.Update) (*proto.Empty, error) {
-//
-//	// Verify seed node is authentic?
-//	err := this.verifySeedNode(update.Authentication)
-//	if err != nil {
-//		return &proto.Empty{}, err
-//	}
-//
-	// Cache delegates.
-	for _, delegate := range update.Delegates {
-		convertToDomainNode(delegate).Cache(services.GetCache())
-		utils.Info(fmt.Sprintf("delegates updated [count=%d] %s : %s:%d", len(update.Delegates), delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port))
-	}
-	return &proto.Empty{}, nil
+	return nil, lastErr
 }
 
-// peerUpdateGrpc
-func (this *DisGoverService) peerUpdateGrpc() {
+// pingSeedNode - replaced in tests
+var pingSeedNode = (*DisGoverService).pingSeed
 
-	// Get delegates in cache.
-	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+// pingSeed
+func (this *DisGoverService) pingSeed(seed *types.Node) ([]*types.Node, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", seed.GrpcEndpoint.Host, seed.GrpcEndpoint.Port), services.GetGrpcDialOptions(seed.Address)...)
 	if err != nil {
-		utils.Error(err)
-		return
-	}
-	var protoDelegates = make([]*proto.Node, 0)
-	for _, delegate := range delegates {
-		protoDelegates = append(protoDelegates, convertToProtoNode(delegate))
+		return nil, err
 	}
+	defer conn.Close()
+	client := proto.NewDisgoverGrpcClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	// New authentication.
-	authentication, err := types.NewAuthentication()
+	authentication, err := types.NewNodeAuthentication(this.ThisNode)
 	if err != nil {
-		utils.Error(err)
-		return
+		return nil, err
 	}
 
-	txn := services.NewTxn(true)
-	defer txn.Discard()
-
-	for _, delegate := range delegates {
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), grpc.WithInsecure())
-		if err != nil {
-			utils.Error(fmt.Sprintf("cannot dial node [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-			err = delegate.Unset(txn, services.GetCache())
-			if err != nil {
-				utils.Error(err)
-			}
-			continue
-		}
-		client := proto.NewDisgoverGrpcClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-
-		// Update.
-		_, err = client.UpdateGrpc(ctx, &proto.Update{Authentication: convertToProtoAuthentication(authentication), Delegates: protoDelegates})
-		if err != nil {
-			utils.Error(err)
-		}
-		conn.Close()
-		cancel()
+	// Ping seed.
+	protoNode := convertToProtoNode(this.ThisNode)
+	response, err := client.PingSeedGrpc(ctx, &proto.PingSeed{Authentication: convertToProtoAuthentication(authentication), Node: protoNode})
+	if err != nil {
+		return nil, err
 	}
-}
 
-// UpdateSoftwareGrpc
-func (this *DisGoverService) UpdateSoftwareGrpc(ctx context.Context, softwareUpdate *proto.SoftwareUpdate) (*proto.Empty, error) {
+	// Response?
+	if response == nil {
+		return nil, errors.New("unable to ping seed node")
+	}
 
 	// Verify seed node is authentic?
-	err := this.verifySeedNode(softwareUpdate.Authentication)
+	err = this.verifySeedNode(response.Authentication)
 	if err != nil {
-		utils.Error(err)
-		return &proto.Empty{}, err
+		return nil, err
 	}
 
-	// Valid software?
-	err = this.verifySoftwareUpdate(softwareUpdate)
+	// Signed and not older than the accepted delegate set?
+	delegateSet := convertToDomainDelegateSet(response)
+	_, err = this.acceptDelegateSet(delegateSet)
 	if err != nil {
-		utils.Error("unable to verify software update", err)
-		return &proto.Empty{}, err
+		utils.Warn(fmt.Sprintf("rejected delegate set from seed node [address=%s, epoch=%d]", seed.Address, delegateSet.Epoch), err)
+		return nil, err
 	}
+	utils.Info(fmt.Sprintf("pinged seed node [address=%s, delegates=%d, epoch=%d]", seed.Address, len(delegateSet.Delegates), delegateSet.Epoch))
 
-	// Remove Update directory.
-	directoryName := fmt.Sprintf("%sgo-binaries%supdate", string(os.PathSeparator), string(os.PathSeparator))
-	if utils.Exists(directoryName) {
-		err = os.RemoveAll(directoryName)
-		if err != nil {
-			utils.Error(fmt.Sprintf("unable to directory %s", directoryName), err)
-			return &proto.Empty{}, err
+	var delegates = make([]*types.Node, 0)
+	for _, delegate := range delegateSet.Delegates {
+		if delegate.GrpcEndpoint == nil {
+			continue
 		}
+		delegates = append(delegates, delegate)
 	}
+	return delegates, nil
+}
 
-	// Create update directory exists?
-	err = os.MkdirAll(directoryName, 0755)
-	if err != nil {
-		utils.Error(fmt.Sprintf("unable to create directory %s", directoryName), err)
-		return &proto.Empty{}, err
-	}
-	os.MkdirAll(directoryName, 0755)
+// UpdateGrpc - Accepts a delegate set signed by a seed that is newer than the accepted one
+func (this *DisGoverService) UpdateGrpc(ctx context.Context, update *proto.Update) (*proto.Empty, error) {
 
-	// Write file to update directory.
-	fileName := fmt.Sprintf("%s%s%s", directoryName, string(os.PathSeparator), softwareUpdate.FileName)
-	err = ioutil.WriteFile(fileName, softwareUpdate.Software, 0755)
+	// Signed and newer than the accepted delegate set?
+	delegateSet := convertToDomainDelegateSet(update)
+	accepted, err := this.acceptDelegateSet(delegateSet)
 	if err != nil {
-		utils.Error("unable to verify software update", err)
+		utils.Warn(fmt.Sprintf("rejected delegate set [seed=%s, epoch=%d]", delegateSet.Seed, delegateSet.Epoch), err)
 		return &proto.Empty{}, err
 	}
-	utils.Info(fmt.Sprintf("software updated from seed node [file=%s, scheduledReboot=%s]", fileName, softwareUpdate.ScheduledReboot))
-
-	// Unzip file.
-	command := exec.Command("/usr/bin/unzip", fileName, "-d", directoryName)
-	var out bytes.Buffer
-	command.Stdout = &out
-	err = command.Run()
-	if err != nil {
-		utils.Error(fmt.Sprintf("executed %s - %s", fileName, out.String()))
-		return &proto.Empty{}, err
+	if !accepted {
+		return &proto.Empty{}, nil
 	}
-	utils.Info(fmt.Sprintf("unzipped software update %s [output] '%s'", fileName, out.String()))
 
-	// Chmod.
-	fileName = fmt.Sprintf("%s%supdate.sh", directoryName, string(os.PathSeparator))
-	os.Chmod(fileName, 0777)
-	utils.Info(fmt.Sprintf("chmod 0777 on script %s...", fileName))
-
-	// Execute update script.
-	//	cmd := exec.Command("sudo", "systemd-run", fileName)
-	ex := fmt.Sprintf("sudo systemd-run %s", fileName)
-	cmd := exec.Command("/bin/sh", "-c", ex)
-
-	var outb bytes.Buffer
-	var errb bytes.Buffer
+	// Seeds only continue the epoch, their delegates are the nodes that ping them.
+	if this.ThisNode.Type == types.TypeSeed {
+		return &proto.Empty{}, nil
+	}
 
-	cmd.Stdout = &outb
-	cmd.Stderr = &errb
+	// Cache delegates.
+	forgetDepartedDelegates(delegateSet.Delegates)
+	delegates := make([]*types.Node, 0, len(delegateSet.Delegates))
+	for _, node := range delegateSet.Delegates {
+		if node.GrpcEndpoint == nil {
+			continue
+		}
+		node.Cache(services.GetCache())
+		this.updateContact(node) // Vouched for by the seed's signature of the set
+		delegates = append(delegates, node)
+		utils.Info(fmt.Sprintf("delegates updated [count=%d, epoch=%d] %s : %s:%d", len(delegateSet.Delegates), delegateSet.Epoch, node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port))
+	}
 
-	err = cmd.Run()
+	// Keep the list to restart from when no seed answers.
+	err = persistDelegates(delegates)
 	if err != nil {
-		utils.Error(fmt.Sprintf("ERROR executed %s - %s", fileName, outb.String()))
-	}
-	utils.Info(fmt.Sprintf("executing script %s...\n\t[output] '%s'\n\t[stderr] %s", fileName, outb.String(), errb.String()))
-
-	// Schedule the reboot.
-	//go func() {
-	//	gocron.Every(1).Day().At(softwareUpdate.ScheduledReboot).Do(func() {
-	//		gocron.Clear()
-	//		services.GetDbService().Close()
-	//
-	//		// Chmod.
-	//		fileName = fmt.Sprintf("%s%supdate.sh", directoryName, string(os.PathSeparator))
-	//		os.Chmod(fileName, 0777)
-	//		utils.Info(fmt.Sprintf("chmod 0777 on script %s...", fileName))
-	//
-	//		// Execute update script.
-	//		command = exec.Command("sudo", "systemd-run", fileName)
-	//		command.Stdout = &out
-	//
-	//		err = command.Run()
-	//		if err != nil {
-	//			utils.Error(fmt.Sprintf("executed %s - %s", fileName, out.String()))
-	//		}
-	//		utils.Info(fmt.Sprintf("executing script %s...[output] '%s'", fileName, out.String()))
-	//	})
-	//	<-gocron.Start()
-	//}()
-
+		utils.Error("unable to persist delegates", err)
+	}
 	return &proto.Empty{}, nil
 }
 
-// peerUpdateSoftwareGrpc
-func (this *DisGoverService) peerUpdateSoftwareGrpc(fileName string, software []byte) {
+// peerUpdateGrpc - Pushes the latest signed delegate set to the delegates and to the other seeds, which continue its epoch
+func (this *DisGoverService) peerUpdateGrpc() {
 
-	// Get delegates in cache.
-	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
-	if err != nil {
-		utils.Error(err)
+	// Push the latest signed delegate set.
+	delegateSet := this.GetDelegateSet()
+	if delegateSet == nil {
 		return
 	}
 
@@ -378,31 +270,16 @@ func (this *DisGoverService) peerUpdateSoftwareGrpc(fileName string, software []
 		utils.Error(err)
 		return
 	}
-
-	// Create hash and signature.
-	hashBytes := crypto.NewHash(software)
-	hash := hex.EncodeToString(hashBytes[:])
-
-	// Create signature of the hash.
-	privateKeyBytes, err := hex.DecodeString(types.GetKey())
-	if err != nil {
-		utils.Error(err)
-		return
-	}
-	signatureBytes, err := crypto.NewSignature(privateKeyBytes, hashBytes[:])
-	if err != nil {
-		utils.Error(err)
-		return
-	}
-	signature := hex.EncodeToString(signatureBytes)
+	update := convertToProtoUpdate(delegateSet, authentication)
 
 	txn := services.NewTxn(true)
 	defer txn.Discard()
-	reboot := time.Now()
-	reboot = reboot.Add(2 * time.Minute)
-	for _, delegate := range delegates {
-		maxSize := 1024 * 1024 * 1024 // TODO: Do we need this (ServerOptions sets this)?
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxSize), grpc.MaxCallSendMsgSize(maxSize)), grpc.WithInsecure())
+
+	for _, delegate := range delegateSet.Delegates {
+		if delegate.GrpcEndpoint == nil {
+			continue
+		}
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), services.GetGrpcDialOptions(delegate.Address)...)
 		if err != nil {
 			utils.Error(fmt.Sprintf("cannot dial node [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
 			err = delegate.Unset(txn, services.GetCache())
@@ -412,17 +289,40 @@ func (this *DisGoverService) peerUpdateSoftwareGrpc(fileName string, software []
 			continue
 		}
 		client := proto.NewDisgoverGrpcClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 
-		// Update software.
-		_, err = client.UpdateSoftwareGrpc(ctx, &proto.SoftwareUpdate{Authentication: convertToProtoAuthentication(authentication), Hash: hash, FileName: fileName, Software: software, Signature: signature, ScheduledReboot: reboot.Format("15:04")})
+		// Update.
+		_, err = client.UpdateGrpc(ctx, update)
 		if err != nil {
-			utils.Warn(fmt.Sprintf("unable to update sofware [address=%s, host=%s, port=%d]", delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-			continue
+			utils.Error(err)
 		}
 		conn.Close()
 		cancel()
 	}
+
+	// Other seeds continue the epoch of the set.
+	for _, seed := range types.GetConfig().Seeds {
+		if seed.GrpcEndpoint == nil || seed.Address == this.ThisNode.Address {
+			continue
+		}
+		err = pushSeedUpdate(seed, update)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("cannot update seed [address=%s]", seed.Address), err)
+		}
+	}
+}
+
+// pushSeedUpdate
+func pushSeedUpdate(node *types.Node, update *proto.Update) error {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), services.GetGrpcDialOptions(node.Address)...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = proto.NewDisgoverGrpcClient(conn).UpdateGrpc(ctx, update)
+	return err
 }
 
 // verifySeedNode
@@ -454,55 +354,22 @@ func (this *DisGoverService) verifySeedNode(protoAuthenticate *proto.Authenticat
 	return errors.New("you are not an authorized seed node")
 }
 
-// verifySoftwareUpdate
-func (this *DisGoverService) verifySoftwareUpdate(softwareUpdate *proto.SoftwareUpdate) error {
-
-	// Create hash.
-	hashBytes := crypto.NewHash(softwareUpdate.Software)
-	hash := hex.EncodeToString(hashBytes[:])
-
-	// Hash valid?
-	if hash != softwareUpdate.Hash {
-		return errors.New("invalid hash")
-	}
-
-	// Valid address?
-	signatureBytes, err := hex.DecodeString(softwareUpdate.Signature)
-	if err != nil {
-		return errors.New("unable to decode signature")
-	}
-	publicKeyBytes, err := crypto.ToPublicKey(hashBytes[:], signatureBytes)
-	if err != nil {
-		return errors.New("unable to generate public key from hash and signature")
-	}
-
-	// Is the computed address match a seed address?
-	computedAddress := hex.EncodeToString(crypto.ToAddress(publicKeyBytes))
-	for _, seedNode := range types.GetConfig().Seeds {
-		if seedNode.Address == computedAddress {
-			return nil
-		}
-	}
-
-	utils.Warn("attempted update software by a non-authorized seed node")
-	return errors.New("you are not an authorized seed node to update software")
-}
-
 /*
  *  Simple conversion functions from / to proto generated objects and domain level objects
  */
 func convertToDomainNode(node *proto.Node) *types.Node {
+	var version *types.Version
+	if node.Version != "" {
+		version = &types.Version{Version: node.Version, BuildTime: node.BuildTime}
+	}
 	return &types.Node{
-		Address: node.Address,
-		GrpcEndpoint: &types.Endpoint{
-			Host: node.GrpcEndpoint.Host,
-			Port: node.GrpcEndpoint.Port, //TODO: grpc endpoint?
-		},
-		HttpEndpoint: &types.Endpoint{
-			Host: node.HttpEndpoint.Host,
-			Port: node.HttpEndpoint.Port,
-		},
-		Type: node.Type,
+		Address:         node.Address,
+		GrpcEndpoint:    convertToDomainEndpoint(node.GrpcEndpoint),
+		HttpEndpoint:    convertToDomainEndpoint(node.HttpEndpoint),
+		Type:            node.Type,
+		Version:         version,
+		ProtocolVersion: node.ProtocolVersion,
+		Capabilities:    node.Capabilities,
 	}
 }
 
@@ -511,17 +378,40 @@ func convertToProtoNode(node *types.Node) *proto.Node {
 	if node == nil {
 		return nil
 	}
-	return &proto.Node{
-		Address: node.Address,
-		GrpcEndpoint: &proto.Endpoint{
-			Host: node.GrpcEndpoint.Host,
-			Port: node.GrpcEndpoint.Port,
-		},
-		HttpEndpoint: &proto.Endpoint{
-			Host: node.HttpEndpoint.Host,
-			Port: node.HttpEndpoint.Port,
-		},
-		Type: node.Type,
+	protoNode := &proto.Node{
+		Address:         node.Address,
+		GrpcEndpoint:    convertToProtoEndpoint(node.GrpcEndpoint),
+		HttpEndpoint:    convertToProtoEndpoint(node.HttpEndpoint),
+		Type:            node.Type,
+		ProtocolVersion: node.ProtocolVersion,
+		Capabilities:    node.Capabilities,
+	}
+	if node.Version != nil {
+		protoNode.Version = node.Version.Version
+		protoNode.BuildTime = node.Version.BuildTime
+	}
+	return protoNode
+}
+
+// convertToDomainEndpoint
+func convertToDomainEndpoint(endpoint *proto.Endpoint) *types.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	return &types.Endpoint{
+		Host: endpoint.Host,
+		Port: endpoint.Port,
+	}
+}
+
+// convertToProtoEndpoint
+func convertToProtoEndpoint(endpoint *types.Endpoint) *proto.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	return &proto.Endpoint{
+		Host: endpoint.Host,
+		Port: endpoint.Port,
 	}
 }
 
@@ -160,6 +160,10 @@ type Node struct {
 	GrpcEndpoint         *Endpoint `protobuf:"bytes,2,opt,name=GrpcEndpoint,proto3" json:"GrpcEndpoint,omitempty"`
 	HttpEndpoint         *Endpoint `protobuf:"bytes,3,opt,name=HttpEndpoint,proto3" json:"HttpEndpoint,omitempty"`
 	Type                 string    `protobuf:"bytes,4,opt,name=Type,proto3" json:"Type,omitempty"`
+	Version              string    `protobuf:"bytes,5,opt,name=Version,proto3" json:"Version,omitempty"`
+	BuildTime            string    `protobuf:"bytes,6,opt,name=BuildTime,proto3" json:"BuildTime,omitempty"`
+	ProtocolVersion      int64     `protobuf:"varint,7,opt,name=ProtocolVersion,proto3" json:"ProtocolVersion,omitempty"`
+	Capabilities         []string  `protobuf:"bytes,8,rep,name=Capabilities,proto3" json:"Capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -218,6 +222,34 @@ func (m *Node) GetType() string {
 	return ""
 }
 
+func (m *Node) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Node) GetBuildTime() string {
+	if m != nil {
+		return m.BuildTime
+	}
+	return ""
+}
+
+func (m *Node) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Node) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type PingSeed struct {
 	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
 	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
@@ -268,6 +300,11 @@ func (m *PingSeed) GetNode() *Node {
 type Update struct {
 	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
 	Delegates            []*Node         `protobuf:"bytes,2,rep,name=Delegates,proto3" json:"Delegates,omitempty"`
+	Epoch                int64           `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Time                 int64           `protobuf:"varint,4,opt,name=Time,proto3" json:"Time,omitempty"`
+	Seed                 string          `protobuf:"bytes,5,opt,name=Seed,proto3" json:"Seed,omitempty"`
+	Hash                 string          `protobuf:"bytes,6,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Signature            string          `protobuf:"bytes,7,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -312,85 +349,442 @@ func (m *Update) GetDelegates() []*Node {
 	return nil
 }
 
-type SoftwareUpdate struct {
-	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
-	Hash                 string          `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	FileName             string          `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
-	Software             []byte          `protobuf:"bytes,4,opt,name=Software,proto3" json:"Software,omitempty"`
-	Signature            string          `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty"`
-	ScheduledReboot      string          `protobuf:"bytes,6,opt,name=ScheduledReboot,proto3" json:"ScheduledReboot,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *Update) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *Update) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Update) GetSeed() string {
+	if m != nil {
+		return m.Seed
+	}
+	return ""
 }
 
-func (m *SoftwareUpdate) Reset()         { *m = SoftwareUpdate{} }
-func (m *SoftwareUpdate) String() string { return proto.CompactTextString(m) }
-func (*SoftwareUpdate) ProtoMessage()    {}
-func (*SoftwareUpdate) Descriptor() ([]byte, []int) {
+func (m *Update) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Update) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type DelegateSets struct {
+	Sets                 []*Update `protobuf:"bytes,1,rep,name=Sets,proto3" json:"Sets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DelegateSets) Reset()         { *m = DelegateSets{} }
+func (m *DelegateSets) String() string { return proto.CompactTextString(m) }
+func (*DelegateSets) ProtoMessage()    {}
+func (*DelegateSets) Descriptor() ([]byte, []int) {
 	return fileDescriptor_dc36fe1127734e88, []int{6}
 }
 
-func (m *SoftwareUpdate) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SoftwareUpdate.Unmarshal(m, b)
+func (m *DelegateSets) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DelegateSets.Unmarshal(m, b)
 }
-func (m *SoftwareUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SoftwareUpdate.Marshal(b, m, deterministic)
+func (m *DelegateSets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DelegateSets.Marshal(b, m, deterministic)
 }
-func (m *SoftwareUpdate) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SoftwareUpdate.Merge(m, src)
+func (m *DelegateSets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelegateSets.Merge(m, src)
 }
-func (m *SoftwareUpdate) XXX_Size() int {
-	return xxx_messageInfo_SoftwareUpdate.Size(m)
+func (m *DelegateSets) XXX_Size() int {
+	return xxx_messageInfo_DelegateSets.Size(m)
 }
-func (m *SoftwareUpdate) XXX_DiscardUnknown() {
-	xxx_messageInfo_SoftwareUpdate.DiscardUnknown(m)
+func (m *DelegateSets) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelegateSets.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SoftwareUpdate proto.InternalMessageInfo
+var xxx_messageInfo_DelegateSets proto.InternalMessageInfo
 
-func (m *SoftwareUpdate) GetAuthentication() *Authentication {
+func (m *DelegateSets) GetSets() []*Update {
 	if m != nil {
-		return m.Authentication
+		return m.Sets
 	}
 	return nil
 }
 
-func (m *SoftwareUpdate) GetHash() string {
+type ReleaseArtifact struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Hash                 string   `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=Data,proto3" json:"Data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseArtifact) Reset()         { *m = ReleaseArtifact{} }
+func (m *ReleaseArtifact) String() string { return proto.CompactTextString(m) }
+func (*ReleaseArtifact) ProtoMessage()    {}
+func (*ReleaseArtifact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc36fe1127734e88, []int{7}
+}
+
+func (m *ReleaseArtifact) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseArtifact.Unmarshal(m, b)
+}
+func (m *ReleaseArtifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseArtifact.Marshal(b, m, deterministic)
+}
+func (m *ReleaseArtifact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseArtifact.Merge(m, src)
+}
+func (m *ReleaseArtifact) XXX_Size() int {
+	return xxx_messageInfo_ReleaseArtifact.Size(m)
+}
+func (m *ReleaseArtifact) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseArtifact.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseArtifact proto.InternalMessageInfo
+
+func (m *ReleaseArtifact) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReleaseArtifact) GetHash() string {
 	if m != nil {
 		return m.Hash
 	}
 	return ""
 }
 
-func (m *SoftwareUpdate) GetFileName() string {
+func (m *ReleaseArtifact) GetSize() int64 {
 	if m != nil {
-		return m.FileName
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ReleaseArtifact) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type Release struct {
+	Authentication       *Authentication    `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Version              string             `protobuf:"bytes,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	Time                 int64              `protobuf:"varint,3,opt,name=Time,proto3" json:"Time,omitempty"`
+	Seed                 string             `protobuf:"bytes,4,opt,name=Seed,proto3" json:"Seed,omitempty"`
+	Rollout              int64              `protobuf:"varint,5,opt,name=Rollout,proto3" json:"Rollout,omitempty"`
+	Artifacts            []*ReleaseArtifact `protobuf:"bytes,6,rep,name=Artifacts,proto3" json:"Artifacts,omitempty"`
+	Hash                 string             `protobuf:"bytes,7,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Signature            string             `protobuf:"bytes,8,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Release) Reset()         { *m = Release{} }
+func (m *Release) String() string { return proto.CompactTextString(m) }
+func (*Release) ProtoMessage()    {}
+func (*Release) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc36fe1127734e88, []int{8}
+}
+
+func (m *Release) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Release.Unmarshal(m, b)
+}
+func (m *Release) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Release.Marshal(b, m, deterministic)
+}
+func (m *Release) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Release.Merge(m, src)
+}
+func (m *Release) XXX_Size() int {
+	return xxx_messageInfo_Release.Size(m)
+}
+func (m *Release) XXX_DiscardUnknown() {
+	xxx_messageInfo_Release.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Release proto.InternalMessageInfo
+
+func (m *Release) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Release) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Release) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Release) GetSeed() string {
+	if m != nil {
+		return m.Seed
 	}
 	return ""
 }
 
-func (m *SoftwareUpdate) GetSoftware() []byte {
+func (m *Release) GetRollout() int64 {
 	if m != nil {
-		return m.Software
+		return m.Rollout
+	}
+	return 0
+}
+
+func (m *Release) GetArtifacts() []*ReleaseArtifact {
+	if m != nil {
+		return m.Artifacts
 	}
 	return nil
 }
 
-func (m *SoftwareUpdate) GetSignature() string {
+func (m *Release) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Release) GetSignature() string {
 	if m != nil {
 		return m.Signature
 	}
 	return ""
 }
 
-func (m *SoftwareUpdate) GetScheduledReboot() string {
+type Ping struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Ping) Reset()         { *m = Ping{} }
+func (m *Ping) String() string { return proto.CompactTextString(m) }
+func (*Ping) ProtoMessage()    {}
+func (*Ping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc36fe1127734e88, []int{9}
+}
+
+func (m *Ping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ping.Unmarshal(m, b)
+}
+func (m *Ping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ping.Marshal(b, m, deterministic)
+}
+func (m *Ping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ping.Merge(m, src)
+}
+func (m *Ping) XXX_Size() int {
+	return xxx_messageInfo_Ping.Size(m)
+}
+func (m *Ping) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ping proto.InternalMessageInfo
+
+func (m *Ping) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Ping) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type Pong struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Pong) Reset()         { *m = Pong{} }
+func (m *Pong) String() string { return proto.CompactTextString(m) }
+func (*Pong) ProtoMessage()    {}
+func (*Pong) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc36fe1127734e88, []int{10}
+}
+
+func (m *Pong) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pong.Unmarshal(m, b)
+}
+func (m *Pong) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pong.Marshal(b, m, deterministic)
+}
+func (m *Pong) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pong.Merge(m, src)
+}
+func (m *Pong) XXX_Size() int {
+	return xxx_messageInfo_Pong.Size(m)
+}
+func (m *Pong) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pong.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pong proto.InternalMessageInfo
+
+func (m *Pong) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Pong) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type FindNode struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Target               string          `protobuf:"bytes,3,opt,name=Target,proto3" json:"Target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FindNode) Reset()         { *m = FindNode{} }
+func (m *FindNode) String() string { return proto.CompactTextString(m) }
+func (*FindNode) ProtoMessage()    {}
+func (*FindNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc36fe1127734e88, []int{11}
+}
+
+func (m *FindNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindNode.Unmarshal(m, b)
+}
+func (m *FindNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindNode.Marshal(b, m, deterministic)
+}
+func (m *FindNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindNode.Merge(m, src)
+}
+func (m *FindNode) XXX_Size() int {
+	return xxx_messageInfo_FindNode.Size(m)
+}
+func (m *FindNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindNode proto.InternalMessageInfo
+
+func (m *FindNode) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *FindNode) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *FindNode) GetTarget() string {
 	if m != nil {
-		return m.ScheduledReboot
+		return m.Target
 	}
 	return ""
 }
 
+type Nodes struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Nodes                []*Node         `protobuf:"bytes,2,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	Node                 *Node           `protobuf:"bytes,3,opt,name=Node,proto3" json:"Node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Nodes) Reset()         { *m = Nodes{} }
+func (m *Nodes) String() string { return proto.CompactTextString(m) }
+func (*Nodes) ProtoMessage()    {}
+func (*Nodes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc36fe1127734e88, []int{12}
+}
+
+func (m *Nodes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Nodes.Unmarshal(m, b)
+}
+func (m *Nodes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Nodes.Marshal(b, m, deterministic)
+}
+func (m *Nodes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Nodes.Merge(m, src)
+}
+func (m *Nodes) XXX_Size() int {
+	return xxx_messageInfo_Nodes.Size(m)
+}
+func (m *Nodes) XXX_DiscardUnknown() {
+	xxx_messageInfo_Nodes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Nodes proto.InternalMessageInfo
+
+func (m *Nodes) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Nodes) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *Nodes) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "disgover.Empty")
 	proto.RegisterType((*Authentication)(nil), "disgover.Authentication")
@@ -398,40 +792,62 @@ func init() {
 	proto.RegisterType((*Node)(nil), "disgover.Node")
 	proto.RegisterType((*PingSeed)(nil), "disgover.PingSeed")
 	proto.RegisterType((*Update)(nil), "disgover.Update")
-	proto.RegisterType((*SoftwareUpdate)(nil), "disgover.SoftwareUpdate")
+	proto.RegisterType((*DelegateSets)(nil), "disgover.DelegateSets")
+	proto.RegisterType((*ReleaseArtifact)(nil), "disgover.ReleaseArtifact")
+	proto.RegisterType((*Release)(nil), "disgover.Release")
+	proto.RegisterType((*Ping)(nil), "disgover.Ping")
+	proto.RegisterType((*Pong)(nil), "disgover.Pong")
+	proto.RegisterType((*FindNode)(nil), "disgover.FindNode")
+	proto.RegisterType((*Nodes)(nil), "disgover.Nodes")
 }
 
 func init() { proto.RegisterFile("proto/disgover.proto", fileDescriptor_dc36fe1127734e88) }
 
 var fileDescriptor_dc36fe1127734e88 = []byte{
-	// 422 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0xeb, 0x34, 0x8d, 0xa7, 0x51, 0x8a, 0x56, 0x1c, 0x56, 0x11, 0x87, 0x68, 0x4f, 0x39,
-	0xa0, 0x22, 0x8c, 0xc4, 0x99, 0x48, 0x2d, 0xf4, 0x54, 0x55, 0x6b, 0xe0, 0xee, 0x66, 0x07, 0x67,
-	0xa5, 0xd4, 0xbb, 0xb2, 0x37, 0x40, 0xdf, 0x87, 0xc7, 0xe0, 0x79, 0x78, 0x0e, 0xe4, 0xb1, 0xd7,
-	0x7f, 0x84, 0x5b, 0x6f, 0x33, 0xdf, 0xcc, 0x37, 0x3b, 0x3f, 0xdf, 0xc2, 0x4b, 0x5b, 0x18, 0x67,
-	0xde, 0x28, 0x5d, 0x66, 0xe6, 0x3b, 0x16, 0x57, 0xe4, 0xb2, 0x99, 0xf7, 0xc5, 0x39, 0x9c, 0xdd,
-	0x3c, 0x5a, 0xf7, 0x24, 0xbe, 0xc2, 0x62, 0x73, 0x70, 0x3b, 0xcc, 0x9d, 0xde, 0xa6, 0x4e, 0x9b,
-	0x9c, 0x31, 0x98, 0xdc, 0xa6, 0xe5, 0x8e, 0x9f, 0xae, 0x82, 0x75, 0x24, 0xc9, 0xae, 0xb0, 0xcf,
-	0xfa, 0x11, 0x79, 0xb8, 0x0a, 0xd6, 0xa1, 0x24, 0x9b, 0xbd, 0x82, 0x28, 0xd1, 0x59, 0x9e, 0xba,
-	0x43, 0x81, 0x7c, 0x42, 0xc9, 0x1d, 0x20, 0x62, 0x98, 0xdd, 0xe4, 0xca, 0x1a, 0x9d, 0x3b, 0xaa,
-	0x68, 0x4a, 0xc7, 0x83, 0xa6, 0xa2, 0x29, 0x09, 0xbb, 0x37, 0x85, 0xa3, 0x57, 0x42, 0x49, 0xb6,
-	0xf8, 0x15, 0xc0, 0xe4, 0xce, 0x28, 0x64, 0x1c, 0xce, 0x37, 0x4a, 0x15, 0x58, 0x96, 0x0d, 0xc7,
-	0xbb, 0xec, 0x3d, 0xcc, 0x3f, 0x15, 0x76, 0xeb, 0x4b, 0x13, 0xfd, 0x22, 0x66, 0x57, 0xed, 0xa0,
-	0x3e, 0x22, 0x07, 0x79, 0x15, 0xef, 0xd6, 0x39, 0xdb, 0xf2, 0xc2, 0xff, 0xf3, 0xfa, 0x79, 0x34,
-	0xf8, 0x93, 0xf5, 0xf3, 0x91, 0x2d, 0x2c, 0xcc, 0xee, 0x75, 0x9e, 0x25, 0x88, 0x8a, 0x7d, 0x18,
-	0xaf, 0x8f, 0x1a, 0xbe, 0x88, 0x79, 0x57, 0x79, 0x18, 0x97, 0xe3, 0x75, 0x8b, 0x7a, 0xe6, 0x66,
-	0x92, 0x45, 0xc7, 0xab, 0x50, 0x49, 0x31, 0xf1, 0x13, 0xa6, 0x5f, 0xac, 0x4a, 0x1d, 0x3e, 0xc3,
-	0x7b, 0xaf, 0x21, 0xba, 0xc6, 0x3d, 0x66, 0xa9, 0xc3, 0x92, 0x9f, 0xae, 0xc2, 0x23, 0x8f, 0x76,
-	0x09, 0xe2, 0x4f, 0x00, 0x8b, 0xc4, 0x7c, 0x73, 0x3f, 0xd2, 0x02, 0x9f, 0xad, 0x85, 0x63, 0x0a,
-	0x5b, 0xc2, 0xec, 0xa3, 0xde, 0xe3, 0x5d, 0xda, 0xa8, 0x2c, 0x92, 0xad, 0x5f, 0xc5, 0x7c, 0x0f,
-	0x74, 0x88, 0xb9, 0x6c, 0xfd, 0xa1, 0x0a, 0xcf, 0x46, 0x2a, 0x64, 0x6b, 0xb8, 0x4c, 0xb6, 0x3b,
-	0x54, 0x87, 0x3d, 0x2a, 0x89, 0x0f, 0xc6, 0x38, 0x3e, 0xa5, 0x9c, 0x31, 0x1c, 0xff, 0x0e, 0x60,
-	0x7e, 0xdd, 0xf4, 0x5f, 0x29, 0xa7, 0x52, 0x8c, 0xbf, 0x32, 0xf9, 0x3d, 0xad, 0x78, 0x7c, 0xf9,
-	0xa2, 0xc3, 0xea, 0xe5, 0x88, 0x13, 0xf6, 0x16, 0xa0, 0xb6, 0x89, 0xf5, 0x4f, 0xc6, 0xf2, 0xb2,
-	0xa7, 0x39, 0xfa, 0x81, 0x27, 0x6c, 0x03, 0xac, 0x0e, 0xfa, 0xa9, 0x88, 0xda, 0xdb, 0xe7, 0xf0,
-	0x02, 0x47, 0x4a, 0x3c, 0x4c, 0xe9, 0x83, 0xbf, 0xfb, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x1a, 0xee,
-	0x75, 0x37, 0xf8, 0x03, 0x00, 0x00,
+	// 683 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcd, 0x4e, 0xdb, 0x40,
+	0x10, 0x4e, 0x62, 0x27, 0x4e, 0x86, 0x08, 0xe8, 0x0a, 0x21, 0x37, 0xea, 0x21, 0x5a, 0x71, 0xc8,
+	0x01, 0x51, 0x35, 0xf4, 0xe7, 0x54, 0xa9, 0xb4, 0xd0, 0x72, 0x42, 0x68, 0x43, 0xb9, 0x2f, 0xf1,
+	0x36, 0xac, 0x64, 0xbc, 0x96, 0x77, 0x53, 0x89, 0x3e, 0x41, 0xcf, 0xed, 0x5b, 0xf4, 0x71, 0xfa,
+	0x14, 0x7d, 0x8c, 0x6a, 0xc7, 0x5e, 0xdb, 0x31, 0xe4, 0x86, 0x72, 0x9b, 0x19, 0xcf, 0xec, 0xcc,
+	0x7c, 0xf3, 0xcd, 0x24, 0xb0, 0x97, 0x66, 0xca, 0xa8, 0x97, 0x91, 0xd4, 0x0b, 0xf5, 0x5d, 0x64,
+	0x47, 0xa8, 0x92, 0xbe, 0xd3, 0x69, 0x00, 0xdd, 0xb3, 0xbb, 0xd4, 0xdc, 0xd3, 0x6b, 0xd8, 0x3e,
+	0x59, 0x9a, 0x5b, 0x91, 0x18, 0x39, 0xe7, 0x46, 0xaa, 0x84, 0x10, 0xf0, 0xcf, 0xb9, 0xbe, 0x0d,
+	0x3b, 0xe3, 0xf6, 0x64, 0xc0, 0x50, 0xb6, 0xb6, 0x2b, 0x79, 0x27, 0x42, 0x6f, 0xdc, 0x9e, 0x78,
+	0x0c, 0x65, 0xf2, 0x02, 0x06, 0x33, 0xb9, 0x48, 0xb8, 0x59, 0x66, 0x22, 0xf4, 0xd1, 0xb9, 0x32,
+	0xd0, 0x29, 0xf4, 0xcf, 0x92, 0x28, 0x55, 0x32, 0x31, 0xf8, 0xa2, 0xd2, 0x26, 0x6c, 0x17, 0x2f,
+	0x2a, 0x8d, 0xb6, 0x4b, 0x95, 0x19, 0xcc, 0xe2, 0x31, 0x94, 0xe9, 0x9f, 0x0e, 0xf8, 0x17, 0x2a,
+	0x12, 0x24, 0x84, 0xe0, 0x24, 0x8a, 0x32, 0xa1, 0x75, 0x11, 0xe3, 0x54, 0xf2, 0x16, 0x86, 0x5f,
+	0xb2, 0x74, 0xee, 0x9e, 0xc6, 0xf0, 0xad, 0x29, 0x39, 0x2a, 0x1b, 0x75, 0x5f, 0xd8, 0x8a, 0x9f,
+	0x8d, 0x3b, 0x37, 0x26, 0x2d, 0xe3, 0xbc, 0xf5, 0x71, 0x75, 0x3f, 0x6c, 0xfc, 0x3e, 0x75, 0xfd,
+	0xa1, 0x6c, 0xab, 0xbb, 0x16, 0x99, 0x96, 0x2a, 0x09, 0xbb, 0x79, 0x75, 0x85, 0x6a, 0x21, 0xf9,
+	0xb8, 0x94, 0x71, 0x84, 0x58, 0xf5, 0x72, 0x48, 0x4a, 0x03, 0x99, 0xc0, 0xce, 0xa5, 0x1d, 0xc3,
+	0x5c, 0xc5, 0x2e, 0x3e, 0xc0, 0xee, 0x9b, 0x66, 0x42, 0x61, 0xf8, 0x89, 0xa7, 0xfc, 0x46, 0xc6,
+	0xd2, 0x48, 0xa1, 0xc3, 0xfe, 0xd8, 0x9b, 0x0c, 0xd8, 0x8a, 0x8d, 0xa6, 0xd0, 0xbf, 0x94, 0xc9,
+	0x62, 0x26, 0x44, 0x44, 0x3e, 0x34, 0x87, 0x88, 0xb0, 0x6d, 0x4d, 0xc3, 0xaa, 0xbf, 0xd5, 0xef,
+	0xac, 0x39, 0x74, 0x9a, 0x23, 0x5f, 0xe0, 0xb9, 0x5d, 0xc5, 0x59, 0x2b, 0xc3, 0x6f, 0xf4, 0x5f,
+	0x1b, 0x7a, 0x5f, 0xd3, 0x88, 0x1b, 0xf1, 0x04, 0x09, 0x0f, 0x61, 0x70, 0x2a, 0x62, 0xb1, 0xe0,
+	0x46, 0xe8, 0xb0, 0x33, 0xf6, 0x1e, 0xc9, 0x5a, 0x39, 0x90, 0x3d, 0xe8, 0x9e, 0xa5, 0x6a, 0x7e,
+	0x5b, 0x10, 0x30, 0x57, 0x4a, 0x56, 0xfa, 0x35, 0x56, 0x12, 0xf0, 0x2d, 0x24, 0xc5, 0x64, 0x50,
+	0x2e, 0x19, 0xdd, 0xab, 0x31, 0x7a, 0x85, 0xbd, 0x41, 0x93, 0xbd, 0xaf, 0x61, 0xe8, 0x92, 0xcf,
+	0x84, 0xd1, 0xe4, 0xc0, 0xbe, 0x6a, 0x2c, 0x1b, 0x6d, 0xa1, 0xbb, 0x55, 0xa1, 0x39, 0x1e, 0x0c,
+	0xbf, 0x52, 0x0e, 0x3b, 0x4c, 0xc4, 0x82, 0x6b, 0x71, 0x92, 0x19, 0xf9, 0x8d, 0xcf, 0x91, 0x3f,
+	0x17, 0xfc, 0x4e, 0x38, 0xea, 0x5b, 0x79, 0xdd, 0x82, 0xcd, 0xe4, 0x8f, 0x72, 0xc1, 0xac, 0x6c,
+	0x6d, 0xa7, 0xdc, 0x70, 0x6c, 0x6f, 0xc8, 0x50, 0xa6, 0xbf, 0x3a, 0x10, 0x14, 0x39, 0x9e, 0x60,
+	0x08, 0x35, 0x26, 0x77, 0x56, 0x99, 0xfc, 0xd8, 0xc2, 0x3b, 0x68, 0xfd, 0x1a, 0xb4, 0x21, 0x04,
+	0x4c, 0xc5, 0xb1, 0x5a, 0x1a, 0x44, 0xdc, 0x63, 0x4e, 0x25, 0xef, 0x60, 0xe0, 0x50, 0xd0, 0x61,
+	0x0f, 0x71, 0x7b, 0x5e, 0x15, 0xd6, 0xc0, 0x89, 0x55, 0xbe, 0x25, 0x3c, 0xc1, 0xba, 0x69, 0xf5,
+	0x9b, 0xd3, 0x8a, 0xc1, 0xb7, 0xab, 0xb0, 0xa1, 0x35, 0xb0, 0xd9, 0xd4, 0xc6, 0xb2, 0xfd, 0x6c,
+	0x43, 0xff, 0xb3, 0x4c, 0x22, 0xab, 0x6c, 0x26, 0x25, 0xd9, 0x87, 0xde, 0x15, 0xcf, 0x16, 0x22,
+	0xbf, 0x92, 0x03, 0x56, 0x68, 0xf4, 0x77, 0x1b, 0xba, 0xd6, 0x41, 0x3f, 0x41, 0x1d, 0x07, 0xc5,
+	0x53, 0x6b, 0x56, 0xbf, 0xc8, 0xe3, 0xaa, 0xf5, 0xd6, 0x57, 0x3b, 0xfd, 0xdb, 0x81, 0xe1, 0x69,
+	0x61, 0xb7, 0x27, 0xdf, 0x9e, 0x7a, 0x77, 0x18, 0x51, 0xaf, 0x1d, 0x79, 0x67, 0x1f, 0x3d, 0xd8,
+	0x60, 0xda, 0x22, 0xaf, 0x00, 0x72, 0x19, 0xa3, 0x1e, 0x78, 0x8c, 0x76, 0x2a, 0x4b, 0xfe, 0xd3,
+	0xd9, 0x22, 0xc7, 0xb0, 0x55, 0x10, 0x19, 0x63, 0x9e, 0x3d, 0xe0, 0xf7, 0x63, 0x41, 0x87, 0xf9,
+	0xe1, 0xc6, 0x88, 0xed, 0xd5, 0xda, 0x46, 0x75, 0x5d, 0x25, 0x0b, 0xda, 0x22, 0x6f, 0x60, 0xe8,
+	0xc6, 0xdf, 0xec, 0xc6, 0xd9, 0xeb, 0x49, 0x10, 0x37, 0xda, 0x22, 0xef, 0x61, 0xb7, 0x7e, 0xc0,
+	0x30, 0xb4, 0x59, 0xcb, 0x68, 0xbf, 0x32, 0xd4, 0x9d, 0x69, 0xeb, 0xa6, 0x87, 0xff, 0x17, 0x8e,
+	0xff, 0x0f, 0x00, 0x99, 0x01, 0x18, 0xe5, 0x47, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -448,7 +864,10 @@ const _ = grpc.SupportPackageIsVersion4
 type DisgoverGrpcClient interface {
 	PingSeedGrpc(ctx context.Context, in *PingSeed, opts ...grpc.CallOption) (*Update, error)
 	UpdateGrpc(ctx context.Context, in *Update, opts ...grpc.CallOption) (*Empty, error)
-	UpdateSoftwareGrpc(ctx context.Context, in *SoftwareUpdate, opts ...grpc.CallOption) (*Empty, error)
+	ReleaseGrpc(ctx context.Context, in *Release, opts ...grpc.CallOption) (*Empty, error)
+	PingGrpc(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error)
+	FindNodeGrpc(ctx context.Context, in *FindNode, opts ...grpc.CallOption) (*Nodes, error)
+	DelegateSetsGrpc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DelegateSets, error)
 }
 
 type disgoverGrpcClient struct {
@@ -477,9 +896,36 @@ func (c *disgoverGrpcClient) UpdateGrpc(ctx context.Context, in *Update, opts ..
 	return out, nil
 }
 
-func (c *disgoverGrpcClient) UpdateSoftwareGrpc(ctx context.Context, in *SoftwareUpdate, opts ...grpc.CallOption) (*Empty, error) {
+func (c *disgoverGrpcClient) ReleaseGrpc(ctx context.Context, in *Release, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/UpdateSoftwareGrpc", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/ReleaseGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) PingGrpc(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error) {
+	out := new(Pong)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/PingGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) FindNodeGrpc(ctx context.Context, in *FindNode, opts ...grpc.CallOption) (*Nodes, error) {
+	out := new(Nodes)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/FindNodeGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) DelegateSetsGrpc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DelegateSets, error) {
+	out := new(DelegateSets)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/DelegateSetsGrpc", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -490,7 +936,10 @@ func (c *disgoverGrpcClient) UpdateSoftwareGrpc(ctx context.Context, in *Softwar
 type DisgoverGrpcServer interface {
 	PingSeedGrpc(context.Context, *PingSeed) (*Update, error)
 	UpdateGrpc(context.Context, *Update) (*Empty, error)
-	UpdateSoftwareGrpc(context.Context, *SoftwareUpdate) (*Empty, error)
+	ReleaseGrpc(context.Context, *Release) (*Empty, error)
+	PingGrpc(context.Context, *Ping) (*Pong, error)
+	FindNodeGrpc(context.Context, *FindNode) (*Nodes, error)
+	DelegateSetsGrpc(context.Context, *Empty) (*DelegateSets, error)
 }
 
 func RegisterDisgoverGrpcServer(s *grpc.Server, srv DisgoverGrpcServer) {
@@ -533,20 +982,74 @@ func _DisgoverGrpc_UpdateGrpc_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DisgoverGrpc_UpdateSoftwareGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SoftwareUpdate)
+func _DisgoverGrpc_ReleaseGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).ReleaseGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/ReleaseGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).ReleaseGrpc(ctx, req.(*Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_PingGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ping)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).PingGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/PingGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).PingGrpc(ctx, req.(*Ping))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_FindNodeGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNode)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DisgoverGrpcServer).UpdateSoftwareGrpc(ctx, in)
+		return srv.(DisgoverGrpcServer).FindNodeGrpc(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/disgover.DisgoverGrpc/UpdateSoftwareGrpc",
+		FullMethod: "/disgover.DisgoverGrpc/FindNodeGrpc",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DisgoverGrpcServer).UpdateSoftwareGrpc(ctx, req.(*SoftwareUpdate))
+		return srv.(DisgoverGrpcServer).FindNodeGrpc(ctx, req.(*FindNode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_DelegateSetsGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).DelegateSetsGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/DelegateSetsGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).DelegateSetsGrpc(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -564,8 +1067,20 @@ var _DisgoverGrpc_serviceDesc = grpc.ServiceDesc{
 			Handler:    _DisgoverGrpc_UpdateGrpc_Handler,
 		},
 		{
-			MethodName: "UpdateSoftwareGrpc",
-			Handler:    _DisgoverGrpc_UpdateSoftwareGrpc_Handler,
+			MethodName: "ReleaseGrpc",
+			Handler:    _DisgoverGrpc_ReleaseGrpc_Handler,
+		},
+		{
+			MethodName: "PingGrpc",
+			Handler:    _DisgoverGrpc_PingGrpc_Handler,
+		},
+		{
+			MethodName: "FindNodeGrpc",
+			Handler:    _DisgoverGrpc_FindNodeGrpc_Handler,
+		},
+		{
+			MethodName: "DelegateSetsGrpc",
+			Handler:    _DisgoverGrpc_DelegateSetsGrpc_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
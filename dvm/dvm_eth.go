@@ -0,0 +1,70 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"encoding/hex"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+)
+
+// CallContract - Read only call of a contract with already ABI encoded call data, the state is never committed
+func (dvm *DVMService) CallContract(from, to string, callData []byte) ([]byte, error) {
+	fromBytes := crypto.GetAddressBytes(from)
+	toBytes := crypto.GetAddressBytes(to)
+	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelper(toBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The call has no Dispatch transaction, its hash only scopes the logs of the call
+	hash := crypto.NewHash(fromBytes[:], toBytes[:], callData)
+	tx := &commonTypes.Transaction{Hash: hex.EncodeToString(hash[:]), From: from, To: to}
+	callMsg := ethTypes.NewMessage(
+		fromBytes,
+		&toBytes,
+		0, // nonce
+		vmstatehelperimplemtations.DefaultValue,
+		vmstatehelperimplemtations.DefaultGas.Uint64(),
+		vmstatehelperimplemtations.DefaultGasPrice,
+		callData,
+		false,
+	)
+	return dvm.call(tx, callMsg, stateHelper)
+}
+
+// GetCode - Runtime code of a contract, empty when there is none at address
+func (dvm *DVMService) GetCode(address string) ([]byte, error) {
+	addressBytes := crypto.GetAddressBytes(address)
+	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelper(addressBytes)
+	if err != nil {
+		return nil, err
+	}
+	return stateHelper.EthStateDB.GetCode(addressBytes), nil
+}
+
+// GetEthReceipt - The EVM receipt with logs of a deployed or executed contract transaction
+func (dvm *DVMService) GetEthReceipt(transactionHash string) (*ethTypes.Receipt, error) {
+	hashBytes, err := hex.DecodeString(transactionHash)
+	if err != nil {
+		return nil, err
+	}
+	return dvm.getReceipt(hashBytes)
+}
@@ -228,6 +228,10 @@ func (tx *Transaction) Size() common.StorageSize {
 func AsMessage(tx *types.Transaction, gasLimit uint64) Message {
 	// Start temporary code
 	price := big.NewInt(int64(0))
+	amount := tx.Value
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
 
 	var msg = Message{}
 	if tx.To == "" {
@@ -237,7 +241,7 @@ func AsMessage(tx *types.Transaction, gasLimit uint64) Message {
 			gasPrice:   price,
 			to:         nil,
 			from:       crypto.GetAddressBytes(tx.From),
-			amount:     big.NewInt(tx.Value),
+			amount:     amount,
 			data:       common.FromHex(tx.Code), // tx.Code,
 			checkNonce: false,
 		}
@@ -249,7 +253,7 @@ func AsMessage(tx *types.Transaction, gasLimit uint64) Message {
 			gasPrice:   price,
 			to:         &to,
 			from:       crypto.GetAddressBytes(tx.From),
-			amount:     big.NewInt(tx.Value),
+			amount:     amount,
 			data:       common.FromHex(tx.Code), // tx.Code,
 			checkNonce: false,
 		}
@@ -0,0 +1,95 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package vmstatehelperimplemtations
+
+import (
+	"bytes"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/badgerwrapper"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/trie"
+)
+
+var (
+	// AccountStatePrefix - keys mapping a smart contract address to the root of its state trie
+	AccountStatePrefix = acctounStatePrefix
+
+	emptyTrieRoot = crypto.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+	emptyCodeHash = crypto.NewHash(nil).Bytes()
+)
+
+// CollectContractState - adds the hashes of the state trie nodes, storage trie nodes and code reachable from a contract's state root to
+// keys, as they are stored, fails if any of them is missing
+func CollectContractState(root crypto.HashBytes, keys map[string]bool) error {
+	badgerWrapper, err := badgerwrapper.NewBadgerDatabase()
+	if err != nil {
+		return err
+	}
+	db := trie.NewDatabase(badgerWrapper)
+	return collectTrie(db, root, keys, func(blob []byte) error {
+		var account types.Account
+		err := rlp.DecodeBytes(blob, &account)
+		if err != nil {
+			return err
+		}
+		err = collectTrie(db, account.Root, keys, nil)
+		if err != nil {
+			return err
+		}
+		if len(account.CodeHash) == 0 || bytes.Equal(account.CodeHash, emptyCodeHash) {
+			return nil
+		}
+		_, err = db.Node(crypto.BytesToHash(account.CodeHash))
+		if err != nil {
+			return err
+		}
+		keys[string(account.CodeHash)] = true
+		return nil
+	})
+}
+
+// collectTrie - every hashed node of a trie, leaf is called with each leaf value
+func collectTrie(db *trie.Database, root crypto.HashBytes, keys map[string]bool, leaf func(blob []byte) error) error {
+	if root == (crypto.HashBytes{}) || root == emptyTrieRoot {
+		return nil
+	}
+	t, err := trie.New(root, db)
+	if err != nil {
+		return err
+	}
+	iterator := t.NodeIterator(nil)
+	for iterator.Next(true) {
+		if hash := iterator.Hash(); hash != (crypto.HashBytes{}) {
+			if !keys[string(hash[:])] {
+				_, err := db.Node(hash)
+				if err != nil {
+					return err
+				}
+				keys[string(hash[:])] = true
+			}
+		}
+		if iterator.Leaf() && leaf != nil {
+			err = leaf(iterator.LeafBlob())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return iterator.Error()
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/sdk"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -45,6 +46,9 @@ func (this *LocalAPIService) WithHttp() *LocalAPIService {
 	services.GetHttpRouter().HandleFunc("/v1/local/packageTx", this.getPackageTxHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/local/getAccount", this.getAccountHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/local/getNewAccount", this.createAccountHandler).Methods("GET")
+	services.GetLocalHttpRouter().HandleFunc("/v1/local/backup", this.backupHandler).Methods("GET")
+	services.GetLocalHttpRouter().HandleFunc("/v1/local/reputation", this.reputationHandler).Methods("GET")
+	services.GetLocalHttpRouter().HandleFunc("/v1/local/quarantine", this.quarantineHandler).Methods("GET")
 
 	return this
 }
@@ -105,7 +109,7 @@ func (this *LocalAPIService) transferHandler(responseWriter http.ResponseWriter,
 		types.GetKey(),
 		types.GetAccount().Address,
 		transfer.To,
-		transfer.Amount,
+		transfer.Amount.String(),
 	)
 
 	// Send Reply
@@ -245,7 +249,7 @@ func (this *LocalAPIService) getPackageTxHandler(responseWriter http.ResponseWri
 		return
 	}
 
-	tx, err := sdk.PackageTx(pack.To, pack.Amount, pack.Time)
+	tx, err := sdk.PackageTx(pack.To, pack.Amount.String(), pack.Time)
 	if err != nil {
 		utils.Error("Error packaging transaction", err)
 		response.Status = types.StatusInternalError
@@ -300,3 +304,59 @@ func (this *LocalAPIService) createAccountHandler(responseWriter http.ResponseWr
 	setHeaders(&responseWriter)
 	responseWriter.Write([]byte(response.String()))
 }
+
+// backupHandler - streams a Badger backup, the version to use for the next incremental backup is sent in the BackupVersionTrailer trailer
+func (this *LocalAPIService) backupHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	if !checkAuth(responseWriter, request) {
+		responseWriter.Header().Set("WWW-Authenticate", `realm="Dispatch Local"`)
+		responseWriter.WriteHeader(401)
+		responseWriter.Write([]byte("401 Unauthorized\n"))
+		return
+	}
+	var since uint64
+	if value := request.URL.Query().Get("since"); value != "" {
+		var err error
+		since, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			services.Error(responseWriter, fmt.Sprintf(`{"status":"%v: %v"}`, types.ErrInvalidRequest, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	responseWriter.Header().Set("content-type", "application/octet-stream")
+	responseWriter.Header().Set("Trailer", BackupVersionTrailer)
+	version, err := services.Backup(responseWriter, since)
+	if err != nil {
+		// Headers are gone, an absent trailer tells the client the backup is incomplete.
+		return
+	}
+	responseWriter.Header().Set(BackupVersionTrailer, strconv.FormatUint(version, 10))
+}
+
+// reputationHandler - the peer score table
+func (this *LocalAPIService) reputationHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	if !checkAuth(responseWriter, request) {
+		responseWriter.Header().Set("WWW-Authenticate", `realm="Dispatch Local"`)
+		responseWriter.WriteHeader(401)
+		responseWriter.Write([]byte("401 Unauthorized\n"))
+		return
+	}
+	response := types.NewResponse()
+	response.Status = types.StatusOk
+	response.Data = disgover.GetDisGoverService().GetReputations()
+	setHeaders(&responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// quarantineHandler - the synced transactions that were not persisted
+func (this *LocalAPIService) quarantineHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	if !checkAuth(responseWriter, request) {
+		responseWriter.Header().Set("WWW-Authenticate", `realm="Dispatch Local"`)
+		responseWriter.WriteHeader(401)
+		responseWriter.Write([]byte("401 Unauthorized\n"))
+		return
+	}
+	response := dapos.GetDAPoSService().GetQuarantine()
+	setHeaders(&responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
@@ -0,0 +1,61 @@
+package localapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/disgover"
+)
+
+// TestMain - the DB and config are created in a temporary working directory
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "disgo-localapi")
+	if err != nil {
+		panic(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	services.GetDb().Close()
+	os.Chdir(wd)
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// TestReputationHandler
+func TestReputationHandler(t *testing.T) {
+	disgover.GetDisGoverService().Penalize("10.0.0.1", "delegate", disgover.OffenseInvalidGossip)
+	service := &LocalAPIService{}
+
+	recorder := httptest.NewRecorder()
+	service.reputationHandler(recorder, httptest.NewRequest(http.MethodGet, "/v1/local/reputation", nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated request returned %d", recorder.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/local/reputation", nil)
+	request.Header.Set("Authorization", types.GetConfig().LocalHttpApiUsername+" "+types.GetConfig().LocalHttpApiPassword)
+	recorder = httptest.NewRecorder()
+	service.reputationHandler(recorder, request)
+	response := &struct {
+		Status string                 `json:"status"`
+		Data   []*disgover.Reputation `json:"data"`
+	}{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != types.StatusOk || len(response.Data) != 1 || response.Data[0].Host != "10.0.0.1" || response.Data[0].Score != -25 {
+		t.Errorf("unexpected response %s", recorder.Body.String())
+	}
+}
@@ -1,9 +1,11 @@
 package localapi
 
+import "encoding/json"
+
 // Transfer -
 type Transfer struct {
-	To     string `json:"to"`
-	Amount int64  `json:"amount"`
+	To     string      `json:"to"`
+	Amount json.Number `json:"amount"` // Decimal string, numbers are accepted for older clients
 }
 
 // Deploy -
@@ -21,7 +23,10 @@ type Execute struct {
 }
 
 type Package struct {
-	To     string `json:"to"`
-	Amount int64  `json:"amount"`
+	To     string      `json:"to"`
+	Amount json.Number `json:"amount"` // Decimal string, numbers are accepted for older clients
 	Time   int64
 }
+
+// BackupVersionTrailer - HTTP trailer carrying the version to request the next incremental backup from
+const BackupVersionTrailer = "X-Disgo-Backup-Version"
@@ -18,6 +18,9 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/dispatchlabs/disgo/bootstrap"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/commons/types"
@@ -33,6 +36,14 @@ func main() {
 		types.SetVersion(version, date)
 	} 
 
+	if ok, err := bootstrap.RunCommand(os.Args[1:]); ok {
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	server := bootstrap.NewServer()
 	server.Go()
 }
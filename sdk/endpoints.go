@@ -143,14 +143,18 @@ func GetAccount(delegateNode types.Node, address string) (*types.Account, error)
 }
 
 // PackageTx - Package a Transaction
-func PackageTx(to string, tokens int64, time int64) (*types.Transaction, error) {
+func PackageTx(to string, tokens string, time int64) (*types.Transaction, error) {
 
 	// Valid time?
 	if time <= 0 {
 		return nil, errors.New("invalid time")
 	}
 
-	transaction, err := types.NewTransferTokensTransaction(types.GetKey(), types.GetAccount().Address, to, tokens, 0, time)
+	value, err := types.ToAmount(tokens)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := types.NewTransferTokensTransaction(types.GetKey(), types.GetAccount().Address, to, value, 0, time)
 	if err != nil {
 		return nil, err
 	}
@@ -158,10 +162,15 @@ func PackageTx(to string, tokens int64, time int64) (*types.Transaction, error)
 	return transaction, nil
 }
 
-// TransferTokens - Send tokens FROM TO
-func TransferTokens(delegateNode types.Node, privateKey, from, to string, tokens int64) (string, error) {
+// TransferTokens - Send tokens FROM TO, tokens is a decimal string
+func TransferTokens(delegateNode types.Node, privateKey, from, to string, tokens string) (string, error) {
+	value, err := types.ToAmount(tokens)
+	if err != nil {
+		return "", err
+	}
+
 	// Create transfer tokens transaction.
-	transaction, err := types.NewTransferTokensTransaction(privateKey, from, to, tokens, 0, utils.ToMilliSeconds(time.Now()))
+	transaction, err := types.NewTransferTokensTransaction(privateKey, from, to, value, 0, utils.ToMilliSeconds(time.Now()))
 	if err != nil {
 		return "", err
 	}
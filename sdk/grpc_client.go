@@ -0,0 +1,334 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// GrpcTimeout - Per call, streams are bound by their context instead
+var GrpcTimeout = 10 * time.Second
+
+// GrpcClient - Talks to a delegate's client gRPC API, one connection is reused for every call
+type GrpcClient struct {
+	connection *grpc.ClientConn
+	client     proto.ClientGrpcClient
+}
+
+// NewGrpcClient - Plaintext connection to a delegate
+func NewGrpcClient(delegateNode types.Node) (*GrpcClient, error) {
+	return newGrpcClient(delegateNode, grpc.WithInsecure())
+}
+
+// NewGrpcTlsClient - Mutual TLS connection to a delegate that has gRPC TLS enabled, the client certificate is bound to
+// address with its private key and the delegate's certificate must be bound to the delegate's address
+func NewGrpcTlsClient(delegateNode types.Node, privateKey, address string) (*GrpcClient, error) {
+	privateKeyBytes, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	certificatePem, keyPem, err := crypto.NewNodeCertificate(privateKeyBytes, address)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := tls.X509KeyPair(certificatePem, keyPem)
+	if err != nil {
+		return nil, err
+	}
+	return newGrpcClient(delegateNode, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		Certificates:       []tls.Certificate{certificate},
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCertificates [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if len(rawCertificates) == 0 {
+				return errors.New("delegate presented no certificate")
+			}
+			peerCertificate, err := x509.ParseCertificate(rawCertificates[0])
+			if err != nil {
+				return err
+			}
+			boundAddress, err := crypto.ToNodeCertificateAddress(peerCertificate)
+			if err != nil {
+				return err
+			}
+			if boundAddress != delegateNode.Address {
+				return errors.Errorf("delegate certificate is bound to %s, expected %s", boundAddress, delegateNode.Address)
+			}
+			return nil
+		},
+	})))
+}
+
+// newGrpcClient
+func newGrpcClient(delegateNode types.Node, transport grpc.DialOption) (*GrpcClient, error) {
+	if delegateNode.GrpcEndpoint == nil {
+		return nil, errors.Errorf("delegate %s has no gRPC endpoint", delegateNode.Address)
+	}
+	connection, err := grpc.Dial(fmt.Sprintf("%s:%d", delegateNode.GrpcEndpoint.Host, delegateNode.GrpcEndpoint.Port), transport)
+	if err != nil {
+		return nil, err
+	}
+	return &GrpcClient{connection: connection, client: proto.NewClientGrpcClient(connection)}, nil
+}
+
+// Close
+func (this *GrpcClient) Close() error {
+	return this.connection.Close()
+}
+
+// SendTransaction - Submits a signed transaction, get the TX hash as result
+func (this *GrpcClient) SendTransaction(transaction *types.Transaction) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GrpcTimeout)
+	defer cancel()
+	response, err := this.client.SubmitTransactionGrpc(ctx, convertToProtoTransaction(transaction))
+	if err != nil {
+		return "", err
+	}
+
+	// Status?
+	if response.Status != types.StatusPending {
+		return "", errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+	return response.Hash, nil
+}
+
+// CallTransaction - Submits a signed read smart contract transaction, get the receipt as result
+func (this *GrpcClient) CallTransaction(transaction *types.Transaction) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GrpcTimeout)
+	defer cancel()
+	response, err := this.client.SubmitTransactionGrpc(ctx, convertToProtoTransaction(transaction))
+	if err != nil {
+		return nil, err
+	}
+	if response.Receipt == nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+	return convertToDomainReceipt(response.Receipt)
+}
+
+// GetAccount - Get account details
+func (this *GrpcClient) GetAccount(address string) (*types.Account, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GrpcTimeout)
+	defer cancel()
+	response, err := this.client.GetAccountGrpc(ctx, &proto.GetAccountRequest{Address: address})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status != types.StatusOk || response.Account == nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+	return convertToDomainAccount(response.Account)
+}
+
+// GetTransaction - Get a transaction and its receipt
+func (this *GrpcClient) GetTransaction(hash string) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GrpcTimeout)
+	defer cancel()
+	response, err := this.client.GetTransactionGrpc(ctx, &proto.GetTransactionRequest{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status != types.StatusOk || response.Transaction == nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+	transaction, err := convertToDomainTransaction(response.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	if response.Receipt != nil {
+		receipt, err := convertToDomainReceipt(response.Receipt)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Receipt = *receipt
+	}
+	return transaction, nil
+}
+
+// GetReceipt - Get details about a transaction base on a TX hash
+func (this *GrpcClient) GetReceipt(hash string) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GrpcTimeout)
+	defer cancel()
+	response, err := this.client.GetReceiptGrpc(ctx, &proto.GetReceiptRequest{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status != types.StatusOk || response.Receipt == nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+	return convertToDomainReceipt(response.Receipt)
+}
+
+// GetDelegates - Get the delegates known to the delegate
+func (this *GrpcClient) GetDelegates() ([]types.Node, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GrpcTimeout)
+	defer cancel()
+	response, err := this.client.ListDelegatesGrpc(ctx, &proto.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+	nodes := make([]types.Node, 0, len(response.Delegates))
+	for _, delegate := range response.Delegates {
+		nodes = append(nodes, *convertToDomainNode(delegate))
+	}
+	return nodes, nil
+}
+
+// StreamReceipts - Calls receive with each receipt as its status changes, returns once every receipt is final or ctx is done
+func (this *GrpcClient) StreamReceipts(ctx context.Context, hashes []string, receive func(*types.Receipt)) error {
+	stream, err := this.client.StreamReceiptsGrpc(ctx, &proto.StreamReceiptsRequest{Hashes: hashes})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if response.Receipt == nil {
+			continue
+		}
+		receipt, err := convertToDomainReceipt(response.Receipt)
+		if err != nil {
+			return err
+		}
+		receive(receipt)
+	}
+}
+
+// convertToProtoTransaction
+func convertToProtoTransaction(transaction *types.Transaction) *proto.Transaction {
+	return &proto.Transaction{
+		Hash:      transaction.Hash,
+		Type:      int32(transaction.Type),
+		From:      transaction.From,
+		To:        transaction.To,
+		Amount:    types.AmountString(transaction.Value),
+		Code:      transaction.Code,
+		Abi:       transaction.Abi,
+		Method:    transaction.Method,
+		Params:    transaction.Params,
+		Time:      transaction.Time,
+		Signature: transaction.Signature,
+		Hertz:     transaction.Hertz,
+		FromName:  transaction.FromName,
+		ToName:    transaction.ToName,
+	}
+}
+
+// convertToDomainTransaction
+func convertToDomainTransaction(transaction *proto.Transaction) (*types.Transaction, error) {
+	value := big.NewInt(transaction.Value)
+	if transaction.Amount != "" {
+		var err error
+		value, err = types.ToAmount(transaction.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &types.Transaction{
+		Hash:      transaction.Hash,
+		Type:      byte(transaction.Type),
+		From:      transaction.From,
+		To:        transaction.To,
+		Value:     value,
+		Code:      transaction.Code,
+		Abi:       transaction.Abi,
+		Method:    transaction.Method,
+		Params:    transaction.Params,
+		Time:      transaction.Time,
+		Signature: transaction.Signature,
+		Hertz:     transaction.Hertz,
+		FromName:  transaction.FromName,
+		ToName:    transaction.ToName,
+	}, nil
+}
+
+// convertToDomainAccount
+func convertToDomainAccount(account *proto.Account) (*types.Account, error) {
+	balance, err := types.ToAmount(account.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Account{
+		Address:         account.Address,
+		Name:            account.Name,
+		Balance:         balance,
+		HertzAvailable:  new(big.Int).SetUint64(account.HertzAvailable),
+		TransactionHash: account.TransactionHash,
+		Created:         utils.ToTimeFromMilliseconds(account.Created),
+		Updated:         utils.ToTimeFromMilliseconds(account.Updated),
+		Nonce:           account.Nonce,
+	}, nil
+}
+
+// convertToDomainReceipt
+func convertToDomainReceipt(receipt *proto.Receipt) (*types.Receipt, error) {
+	var contractResult []interface{}
+	if len(receipt.ContractResult) > 0 {
+		err := json.Unmarshal(receipt.ContractResult, &contractResult)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &types.Receipt{
+		TransactionHash:     receipt.TransactionHash,
+		Status:              receipt.Status,
+		HumanReadableStatus: receipt.HumanReadableStatus,
+		ContractAddress:     receipt.ContractAddress,
+		ContractResult:      contractResult,
+		Created:             toTimeFromNanoseconds(receipt.Created),
+	}, nil
+}
+
+// convertToDomainNode
+func convertToDomainNode(node *proto.Node) *types.Node {
+	domainNode := &types.Node{
+		Address:          node.Address,
+		GrpcEndpoint:     convertToDomainEndpoint(node.GrpcEndpoint),
+		HttpEndpoint:     convertToDomainEndpoint(node.HttpEndpoint),
+		LocalHttpApiPort: node.LocalHttpApiPort,
+		Type:             node.Type,
+		Status:           node.Status,
+		StatusTime:       toTimeFromNanoseconds(node.StatusTime),
+	}
+	if node.Version != nil {
+		domainNode.Version = &types.Version{Version: node.Version.Version, BuildTime: node.Version.BuildTime}
+	}
+	return domainNode
+}
+
+// convertToDomainEndpoint
+func convertToDomainEndpoint(endpoint *proto.Endpoint) *types.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	return &types.Endpoint{Host: endpoint.Host, Port: endpoint.Port}
+}
+
+// toTimeFromNanoseconds - the zero time when unset
+func toTimeFromNanoseconds(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
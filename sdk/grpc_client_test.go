@@ -0,0 +1,192 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// testClientServer - answers from fixed data, transactions are accepted unless their hash is "refused"
+type testClientServer struct{}
+
+// SubmitTransactionGrpc
+func (this *testClientServer) SubmitTransactionGrpc(ctx context.Context, request *proto.Transaction) (*proto.SubmitTransactionResponse, error) {
+	if request.Hash == "refused" {
+		return &proto.SubmitTransactionResponse{Status: types.StatusInsufficientTokens, HumanReadableStatus: "refused", Hash: request.Hash}, nil
+	}
+	if request.Type == int32(types.TypeReadSmartContract) {
+		receipt := &proto.Receipt{TransactionHash: request.Hash, Status: types.StatusOk, ContractResult: []byte(`["` + request.Method + `"]`)}
+		return &proto.SubmitTransactionResponse{Status: types.StatusOk, Hash: request.Hash, Receipt: receipt}, nil
+	}
+	return &proto.SubmitTransactionResponse{Status: types.StatusPending, Hash: request.Hash + request.Amount}, nil
+}
+
+// GetAccountGrpc
+func (this *testClientServer) GetAccountGrpc(ctx context.Context, request *proto.GetAccountRequest) (*proto.AccountResponse, error) {
+	if request.Address != "account" {
+		return &proto.AccountResponse{Status: types.StatusNotFound, HumanReadableStatus: "not found"}, nil
+	}
+	return &proto.AccountResponse{Status: types.StatusOk, Account: &proto.Account{Address: request.Address, Balance: "12345678901234567890", HertzAvailable: 7, Nonce: 3}}, nil
+}
+
+// GetTransactionGrpc
+func (this *testClientServer) GetTransactionGrpc(ctx context.Context, request *proto.GetTransactionRequest) (*proto.TransactionResponse, error) {
+	return &proto.TransactionResponse{
+		Status:      types.StatusOk,
+		Transaction: &proto.Transaction{Hash: request.Hash, Amount: "5", Time: 1},
+		Receipt:     &proto.Receipt{TransactionHash: request.Hash, Status: types.StatusOk, Created: 1000},
+	}, nil
+}
+
+// GetReceiptGrpc
+func (this *testClientServer) GetReceiptGrpc(ctx context.Context, request *proto.GetReceiptRequest) (*proto.ReceiptResponse, error) {
+	return &proto.ReceiptResponse{Status: types.StatusNotFound, HumanReadableStatus: "not found"}, nil
+}
+
+// ListDelegatesGrpc
+func (this *testClientServer) ListDelegatesGrpc(ctx context.Context, request *proto.Empty) (*proto.ListDelegatesResponse, error) {
+	return &proto.ListDelegatesResponse{Status: types.StatusOk, Delegates: []*proto.Node{
+		{Address: "delegate", GrpcEndpoint: &proto.Endpoint{Host: "127.0.0.1", Port: 1973}, Type: types.TypeDelegate, Version: &proto.Version{Version: "1"}},
+	}}, nil
+}
+
+// StreamReceiptsGrpc - each hash is sent pending then ok, a response without a receipt is skipped by the client
+func (this *testClientServer) StreamReceiptsGrpc(request *proto.StreamReceiptsRequest, stream proto.ClientGrpc_StreamReceiptsGrpcServer) error {
+	for _, status := range []string{types.StatusPending, types.StatusOk} {
+		for _, hash := range request.Hashes {
+			if err := stream.Send(&proto.ReceiptResponse{Status: types.StatusOk, Receipt: &proto.Receipt{TransactionHash: hash, Status: status}}); err != nil {
+				return err
+			}
+		}
+	}
+	return stream.Send(&proto.ReceiptResponse{Status: types.StatusNotFound})
+}
+
+// startTestClientServer - returns the delegate node to dial
+func startTestClientServer(t *testing.T, options ...grpc.ServerOption) (types.Node, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(options...)
+	proto.RegisterClientGrpcServer(server, &testClientServer{})
+	go server.Serve(listener)
+	port := listener.Addr().(*net.TCPAddr).Port
+	return types.Node{Address: "delegate", GrpcEndpoint: &types.Endpoint{Host: "127.0.0.1", Port: int64(port)}}, server.Stop
+}
+
+// TestGrpcClient
+func TestGrpcClient(t *testing.T) {
+	delegateNode, stop := startTestClientServer(t)
+	defer stop()
+	client, err := NewGrpcClient(delegateNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	hash, err := client.SendTransaction(&types.Transaction{Hash: "hash", Type: types.TypeTransferTokens, Value: big.NewInt(10)})
+	if err != nil || hash != "hash10" {
+		t.Errorf("SendTransaction returned %q, %v", hash, err)
+	}
+	if _, err = client.SendTransaction(&types.Transaction{Hash: "refused", Value: big.NewInt(0)}); err == nil {
+		t.Error("SendTransaction of a refused transaction succeeded")
+	}
+	receipt, err := client.CallTransaction(&types.Transaction{Hash: "call", Type: types.TypeReadSmartContract, Method: "get", Value: big.NewInt(0)})
+	if err != nil || fmt.Sprint(receipt.ContractResult) != "[get]" {
+		t.Errorf("CallTransaction returned %v, %v", receipt, err)
+	}
+
+	account, err := client.GetAccount("account")
+	if err != nil || account.Balance.String() != "12345678901234567890" || account.HertzAvailable.Uint64() != 7 || account.Nonce != 3 {
+		t.Errorf("GetAccount returned %v, %v", account, err)
+	}
+	if _, err = client.GetAccount("missing"); err == nil {
+		t.Error("GetAccount of a missing account succeeded")
+	}
+
+	transaction, err := client.GetTransaction("hash")
+	if err != nil || transaction.Value.Int64() != 5 || transaction.Receipt.TransactionHash != "hash" || !transaction.Receipt.Created.Equal(time.Unix(0, 1000)) {
+		t.Errorf("GetTransaction returned %v, %v", transaction, err)
+	}
+	if _, err = client.GetReceipt("hash"); err == nil {
+		t.Error("GetReceipt of a missing receipt succeeded")
+	}
+
+	delegates, err := client.GetDelegates()
+	if err != nil || len(delegates) != 1 || delegates[0].GrpcEndpoint.Port != 1973 || delegates[0].Version.Version != "1" {
+		t.Errorf("GetDelegates returned %v, %v", delegates, err)
+	}
+
+	var received []string
+	err = client.StreamReceipts(context.Background(), []string{"a", "b"}, func(receipt *types.Receipt) {
+		received = append(received, receipt.TransactionHash+"="+receipt.Status)
+	})
+	expected := []string{"a=Pending", "b=Pending", "a=Ok", "b=Ok"}
+	if err != nil || fmt.Sprint(received) != fmt.Sprint(expected) {
+		t.Errorf("StreamReceipts received %v, %v, expected %v", received, err, expected)
+	}
+}
+
+// TestNewGrpcClientWithoutEndpoint
+func TestNewGrpcClientWithoutEndpoint(t *testing.T) {
+	if _, err := NewGrpcClient(types.Node{Address: "delegate"}); err == nil {
+		t.Error("client created for a delegate without a gRPC endpoint")
+	}
+}
+
+// TestNewGrpcTlsClient - the delegate's certificate must be bound to its address
+func TestNewGrpcTlsClient(t *testing.T) {
+	newKey := func() (string, string) {
+		publicKey, privateKey := crypto.GenerateKeyPair()
+		return hex.EncodeToString(privateKey), hex.EncodeToString(crypto.ToAddress(publicKey))
+	}
+	delegatePrivateKey, delegateAddress := newKey()
+	clientPrivateKey, clientAddress := newKey()
+	privateKeyBytes, _ := hex.DecodeString(delegatePrivateKey)
+	certificatePem, keyPem, err := crypto.NewNodeCertificate(privateKeyBytes, delegateAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := tls.X509KeyPair(certificatePem, keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegateNode, stop := startTestClientServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})))
+	defer stop()
+
+	tests := []struct {
+		name    string
+		address string
+		ok      bool
+	}{
+		{"bound to the delegate", delegateAddress, true},
+		{"bound to another address", clientAddress, false},
+	}
+	for _, test := range tests {
+		delegateNode.Address = test.address
+		client, err := NewGrpcTlsClient(delegateNode, clientPrivateKey, clientAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.GetAccount("account")
+		if (err == nil) != test.ok {
+			t.Errorf("%s: GetAccount returned %v", test.name, err)
+		}
+		client.Close()
+	}
+}